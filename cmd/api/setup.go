@@ -8,13 +8,29 @@ import (
 	"github.com/quessapp/core-go/configs"
 	"github.com/quessapp/core-go/docs"
 	"github.com/quessapp/core-go/internal/auth"
+	"github.com/quessapp/core-go/internal/bans"
 	"github.com/quessapp/core-go/internal/blocks"
+	"github.com/quessapp/core-go/internal/deletions"
+	"github.com/quessapp/core-go/internal/exports"
+	"github.com/quessapp/core-go/internal/identities"
+	"github.com/quessapp/core-go/internal/lockouts"
 	"github.com/quessapp/core-go/internal/middlewares"
+	personaltokens "github.com/quessapp/core-go/internal/personal-tokens"
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/internal/queues"
 	"github.com/quessapp/core-go/internal/reports"
+	"github.com/quessapp/core-go/internal/roles"
 	"github.com/quessapp/core-go/internal/settings"
+	signingkeys "github.com/quessapp/core-go/internal/signing-keys"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/denylist"
+	"github.com/quessapp/core-go/pkg/geoip"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/oidc"
+	"github.com/quessapp/core-go/pkg/passwords"
 
 	healthcheck "github.com/quessapp/core-go/internal/health-check"
 
@@ -60,6 +76,15 @@ func initCache(cfg *configs.Conf) *configs.Cache {
 	return cache
 }
 
+func initDenylist(cfg *configs.Conf, cache *configs.Cache) *denylist.Denylist {
+	if cfg.Cache.URI == "" {
+		log.Printf("[WARNING!!] CACHE_URI not set, revoked tokens are kept only in memory")
+		return denylist.New(nil)
+	}
+
+	return denylist.New(cache)
+}
+
 func initMessageBroker(cfg *configs.Conf) (*amqp.Connection, *amqp.Channel) {
 	return queue.Connect(cfg.Queue.URI)
 }
@@ -98,23 +123,122 @@ func initS3(cfg *configs.Conf) *AWS_S3.S3 {
 	return S3Client
 }
 
-func initRepositories(db *mongo.Database) (*auth.AuthRepository, *users.UsersRepository, *questions.QuestionsRepository, *blocks.BlocksRepository, *reports.ReportsRepository) {
-	return auth.NewAuthRepository(db), users.NewRepository(db), questions.NewRepository(db), blocks.NewRepository(db), reports.NewRepository(db)
+func initOIDCRegistry(cfg *configs.Conf) *oidc.Registry {
+	providers, err := oidc.ParseProviders(cfg.OIDC.Providers)
+
+	if err != nil {
+		log.Fatalf("failed to parse OIDC providers: %s", err)
+	}
+
+	return oidc.NewRegistry(providers, nil)
 }
 
-func initRoutes(appCtx *configs.AppCtx, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository, questionsRepository *questions.QuestionsRepository, blocksRepository *blocks.BlocksRepository, reportsRepository *reports.ReportsRepository) {
-	auth.LoadRoutes(appCtx, authRepository, usersRepository)
+func initPasswordPolicy(cfg *configs.Conf) *passwords.Policy {
+	policy := passwords.NewPolicy(
+		passwords.LengthRule{Min: cfg.Password.MinLength},
+		passwords.EntropyRule{MinBits: cfg.Password.MinEntropy},
+		passwords.PersonalInfoRule{},
+	)
+
+	if cfg.Password.BreachedPasswordsFile != "" {
+		corpus, err := passwords.LoadCorpusFile(cfg.Password.BreachedPasswordsFile)
+
+		if err != nil {
+			log.Fatalf("failed to load breached passwords: %s", err)
+		}
+
+		policy.Rules = append(policy.Rules, passwords.BreachedRule{Source: corpus})
+	}
+
+	return policy
+}
+
+func initNickPolicy(cfg *configs.Conf) *nicks.Policy {
+	if cfg.Nicks.ReservedNicksFile == "" {
+		return nicks.NewPolicy()
+	}
+
+	reserved, err := nicks.LoadReservedFile(cfg.Nicks.ReservedNicksFile)
+
+	if err != nil {
+		log.Fatalf("failed to load reserved nicks: %s", err)
+	}
+
+	return nicks.NewPolicy(reserved...)
+}
+
+func initKeyring(cfg *configs.Conf, db *mongo.Database) *keyring.Keyring {
+	kr, err := signingkeys.InitKeyring(cfg, signingkeys.NewRepository(db))
+
+	if err != nil {
+		log.Fatalf("failed to init JWT keyring: %s", err)
+	}
+
+	return kr
+}
+
+func initGeoIP(cfg *configs.Conf) *geoip.Resolver {
+	resolver, err := geoip.LoadResolver(cfg.GeoIP.CityDatabaseFile, cfg.GeoIP.ASNDatabaseFile)
+
+	if err != nil {
+		log.Fatalf("failed to load GeoIP databases: %s", err)
+	}
+
+	return resolver
+}
+
+func initIdentitiesIndexes(identitiesRepository *identities.IdentitiesRepository) {
+	if err := identitiesRepository.CreateIndexes(); err != nil {
+		log.Fatalf("failed to create the identities indexes: %s", err)
+	}
+}
+
+func initQuestionsIndexes(questionsRepository *questions.QuestionsRepository) {
+	if err := questionsRepository.CreateSearchIndex(); err != nil {
+		log.Fatalf("failed to create the questions search index: %s", err)
+	}
+
+	if err := questionsRepository.CreateFollowUpsIndex(); err != nil {
+		log.Fatalf("failed to create the follow-ups index: %s", err)
+	}
+
+	if err := questionsRepository.CreatePollsIndex(); err != nil {
+		log.Fatalf("failed to create the polls index: %s", err)
+	}
+
+	if err := questionsRepository.CreateDraftsIndex(); err != nil {
+		log.Fatalf("failed to create the drafts index: %s", err)
+	}
+}
+
+func initRepositories(db *mongo.Database) (*auth.AuthRepository, *users.UsersRepository, *questions.QuestionsRepository, *blocks.BlocksRepository, *reports.ReportsRepository, *twofactor.TwoFactorRepository, *identities.IdentitiesRepository, *lockouts.LockoutsRepository, *bans.BansRepository, *trustedlocations.TrustedLocationsRepository, *personaltokens.PersonalTokensRepository, *roles.RolesRepository, *deletions.DeletionsRepository, *exports.ExportsRepository) {
+	return auth.NewAuthRepository(db), users.NewRepository(db), questions.NewRepository(db), blocks.NewRepository(db), reports.NewRepository(db), twofactor.NewRepository(db), identities.NewRepository(db), lockouts.NewRepository(db), bans.NewRepository(db), trustedlocations.NewRepository(db), personaltokens.NewRepository(db), roles.NewRepository(db), deletions.NewRepository(db), exports.NewRepository(db)
+}
+
+func initRoutes(appCtx *configs.AppCtx, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository, questionsRepository *questions.QuestionsRepository, blocksRepository *blocks.BlocksRepository, reportsRepository *reports.ReportsRepository, twoFactorRepository *twofactor.TwoFactorRepository, identitiesRepository *identities.IdentitiesRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository, personalTokensRepository *personaltokens.PersonalTokensRepository, rolesRepository *roles.RolesRepository, exportsRepository *exports.ExportsRepository) {
+	auth.LoadRoutes(appCtx, initPasswordPolicy(appCtx.Cfg), authRepository, usersRepository, twoFactorRepository, lockoutsRepository, bansRepository, trustedLocationsRepository)
 	questions.LoadRoutes(appCtx, usersRepository, questionsRepository, blocksRepository)
 	blocks.LoadRoutes(appCtx, usersRepository, blocksRepository)
 	users.LoadRoutes(appCtx, usersRepository)
 	healthcheck.LoadRoutes(appCtx, authRepository, questionsRepository, usersRepository)
 	settings.LoadRoutes(appCtx, usersRepository)
 	reports.LoadRoutes(appCtx, questionsRepository, usersRepository, reportsRepository)
+	twofactor.LoadRoutes(appCtx, twoFactorRepository, usersRepository)
+	identities.LoadRoutes(appCtx, initOIDCRegistry(appCtx.Cfg), identitiesRepository, authRepository, usersRepository, bansRepository, trustedLocationsRepository)
+	lockouts.LoadRoutes(appCtx, lockoutsRepository, usersRepository)
+	bans.LoadRoutes(appCtx, bansRepository, usersRepository)
+	signingkeys.LoadRoutes(appCtx)
+	trustedlocations.LoadRoutes(appCtx, trustedLocationsRepository, usersRepository, twoFactorRepository)
+	personaltokens.LoadRoutes(appCtx, personalTokensRepository)
+	roles.LoadRoutes(appCtx, rolesRepository, usersRepository)
+	deletions.LoadRoutes(appCtx, usersRepository, twoFactorRepository)
+	exports.LoadRoutes(appCtx, exportsRepository, usersRepository)
 	docs.LoadRoutes(appCtx)
 }
 
 func initServer(cfg *configs.Conf, messageBrokerChannel *amqp.Channel, S3Client *AWS_S3.S3, db *mongo.Database) {
 	app := fiber.New()
+	cache := initCache(cfg)
 
 	AppCtx := &configs.AppCtx{
 		App:             app,
@@ -124,13 +248,27 @@ func initServer(cfg *configs.Conf, messageBrokerChannel *amqp.Channel, S3Client
 		S3Client:        S3Client,
 		EmailsQueue:     initEmailsQueue(messageBrokerChannel, cfg.Queue.SendEmailsQueueName),
 		TrustedIPsQueue: initTrustedIPsQueue(messageBrokerChannel, cfg.Queue.CheckTrustedIPsQueueName),
-		Cache:           initCache(cfg),
+		Cache:           cache,
+		Keyring:         initKeyring(cfg, db),
+		Denylist:        initDenylist(cfg, cache),
+		GeoIP:           initGeoIP(cfg),
+		Nicks:           initNickPolicy(cfg),
 	}
 
 	middlewares.ApplyMiddlewares(AppCtx.App, AppCtx.Cfg)
 
-	authRepository, usersRepository, questionsRepository, blocksRepository, reportsRepository := initRepositories(db)
-	initRoutes(AppCtx, authRepository, usersRepository, questionsRepository, blocksRepository, reportsRepository)
+	authRepository, usersRepository, questionsRepository, blocksRepository, reportsRepository, twoFactorRepository, identitiesRepository, lockoutsRepository, bansRepository, trustedLocationsRepository, personalTokensRepository, rolesRepository, deletionsRepository, exportsRepository := initRepositories(db)
+	AppCtx.PersonalTokens = personaltokens.NewVerifier(personalTokensRepository, bansRepository)
+	AppCtx.Policy = roles.NewPolicy(usersRepository)
+
+	initIdentitiesIndexes(identitiesRepository)
+	initQuestionsIndexes(questionsRepository)
+
+	initRoutes(AppCtx, authRepository, usersRepository, questionsRepository, blocksRepository, reportsRepository, twoFactorRepository, identitiesRepository, lockoutsRepository, bansRepository, trustedLocationsRepository, personalTokensRepository, rolesRepository, exportsRepository)
+
+	deletions.StartDeletionJob(AppCtx, deletionsRepository, usersRepository)
+	exports.StartExportJob(AppCtx, exportsRepository, usersRepository, trustedLocationsRepository)
+	questions.StartScheduledRepliesJob(AppCtx, questionsRepository, usersRepository, bansRepository)
 
 	log.Fatal(AppCtx.App.Listen(AppCtx.Cfg.App.ServerPort))
 }
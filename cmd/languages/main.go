@@ -0,0 +1,62 @@
+// Command languages sets the language of the questions created before the languages of the questions, from the
+// locale of their receivers, so they are indexed with the same stems as the searches of the receivers. It is safe
+// to run more than once.
+//
+// Usage:
+//
+//	go run ./cmd/languages
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/search"
+	"github.com/quessapp/toolkit/database"
+)
+
+func main() {
+	cfg, err := configs.LoadConfig(".")
+
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+
+	db, err := database.Connect(fmt.Sprintf("%s:%s", cfg.DB.Host, cfg.DB.Port), cfg.DB.Name)
+
+	if err != nil {
+		log.Fatalf("failed to connect to database: %s", err)
+	}
+
+	defer db.Client().Disconnect(context.Background())
+
+	usersRepository := users.NewRepository(db)
+	questionsRepository := questions.NewRepository(db)
+
+	receivers, err := questionsRepository.FindReceiversWithoutLanguage()
+
+	if err != nil {
+		log.Fatalf("failed to find receivers: %s", err)
+	}
+
+	var updated int64
+
+	for _, receiverID := range receivers {
+		// receivers that can't be found are indexed in the default language
+		language := search.Language(usersRepository.FindUserByID(receiverID).Locale)
+
+		count, err := questionsRepository.SetLanguageOfReceived(receiverID, language)
+
+		if err != nil {
+			log.Fatalf("failed to update the language of the questions of %s: %s", receiverID.Hex(), err)
+		}
+
+		updated += count
+	}
+
+	log.Printf("language of %d questions of %d users updated", updated, len(receivers))
+}
@@ -0,0 +1,50 @@
+// Command nicks sets the confusable skeleton of the nicks of the users created before the skeletons,
+// so look-alikes of their nicks can't be taken by new users. It is safe to run more than once.
+//
+// Usage:
+//
+//	go run ./cmd/nicks
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/toolkit/database"
+)
+
+func main() {
+	cfg, err := configs.LoadConfig(".")
+
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+
+	db, err := database.Connect(fmt.Sprintf("%s:%s", cfg.DB.Host, cfg.DB.Port), cfg.DB.Name)
+
+	if err != nil {
+		log.Fatalf("failed to connect to database: %s", err)
+	}
+
+	defer db.Client().Disconnect(context.Background())
+
+	usersRepository := users.NewRepository(db)
+
+	found, err := usersRepository.FindUsersWithoutNickSkeleton()
+
+	if err != nil {
+		log.Fatalf("failed to find users: %s", err)
+	}
+
+	for _, u := range *found {
+		if err := usersRepository.UpdateNickSkeleton(u.ID, nicks.Skeleton(u.Nick)); err != nil {
+			log.Fatalf("failed to update nick skeleton of %s: %s", u.Nick, err)
+		}
+	}
+
+	log.Printf("nick skeletons of %d users updated", len(*found))
+}
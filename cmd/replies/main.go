@@ -0,0 +1,49 @@
+// Command replies rebuilds the edit histories of the replies edited before the history kept the original reply, which
+// also had the content of the question, and flags these replies as edited. It is safe to run more than once.
+//
+// Usage:
+//
+//	go run ./cmd/replies
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/toolkit/database"
+)
+
+func main() {
+	cfg, err := configs.LoadConfig(".")
+
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+
+	db, err := database.Connect(fmt.Sprintf("%s:%s", cfg.DB.Host, cfg.DB.Port), cfg.DB.Name)
+
+	if err != nil {
+		log.Fatalf("failed to connect to database: %s", err)
+	}
+
+	defer db.Client().Disconnect(context.Background())
+
+	questionsRepository := questions.NewRepository(db)
+
+	found, err := questionsRepository.FindLegacyReplyHistories()
+
+	if err != nil {
+		log.Fatalf("failed to find questions: %s", err)
+	}
+
+	for i := range found {
+		if err := questionsRepository.RebuildReplyHistory(&found[i]); err != nil {
+			log.Fatalf("failed to rebuild the reply history of %s: %s", found[i].ID.Hex(), err)
+		}
+	}
+
+	log.Printf("reply histories of %d questions rebuilt", len(found))
+}
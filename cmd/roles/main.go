@@ -0,0 +1,57 @@
+// Command roles changes the role of an user, like granting the first admin, which can't be done through the API.
+// Every change is recorded on the audit trail with the CLI as its source.
+//
+// Usage:
+//
+//	go run ./cmd/roles -nick john -role admin -reason "first admin"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/roles"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/toolkit/database"
+)
+
+func main() {
+	nick := flag.String("nick", "", "nick of the user")
+	role := flag.String("role", "", fmt.Sprintf("new role of the user, one of %v", users.ROLES))
+	reason := flag.String("reason", "", "reason of the change, recorded on the audit trail")
+	flag.Parse()
+
+	cfg, err := configs.LoadConfig(".")
+
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+
+	db, err := database.Connect(fmt.Sprintf("%s:%s", cfg.DB.Host, cfg.DB.Port), cfg.DB.Name)
+
+	if err != nil {
+		log.Fatalf("failed to connect to database: %s", err)
+	}
+
+	defer db.Client().Disconnect(context.Background())
+
+	usersRepository := users.NewRepository(db)
+	u := usersRepository.FindUserByNick(*nick)
+
+	if err := users.UserExists(u); err != nil {
+		log.Fatalf("failed to find user %q: %s", *nick, err)
+	}
+
+	payload := &roles.ChangeRoleDTO{Role: *role, Reason: *reason}
+
+	change, err := roles.ChangeRole(payload, u.ID, nil, roles.NewRepository(db), usersRepository)
+
+	if err != nil {
+		log.Fatalf("failed to change role: %s", err)
+	}
+
+	log.Printf("role of %s changed from %s to %s", u.Nick, change.PreviousRole, change.Role)
+}
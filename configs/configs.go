@@ -1,8 +1,14 @@
 package configs
 
 import (
+	"errors"
 	"log"
 
+	"github.com/quessapp/core-go/pkg/denylist"
+	"github.com/quessapp/core-go/pkg/geoip"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/nicks"
+
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/redis/go-redis/v9"
@@ -42,6 +48,10 @@ type CORSConfig struct {
 type JWTConfig struct {
 	Secret    string `mapstructure:"JWT_SECRET"`
 	ExpiresIn int    `mapstructure:"JWT_EXPIRES_IN"`
+	// Algorithm is the signing algorithm of the tokens: HS256 (default), RS256 or EdDSA.
+	Algorithm string `mapstructure:"JWT_ALGORITHM"`
+	// KeyRotationInterval is how many hours an asymmetric key signs tokens before a new one is created.
+	KeyRotationInterval int `mapstructure:"JWT_KEY_ROTATION_INTERVAL"`
 }
 
 // QueueConfig holds the message broker configuration.
@@ -75,6 +85,83 @@ type CacheConfig struct {
 	URI string `mapstructure:"CACHE_URI"`
 }
 
+// VerificationConfig holds the email verification configuration.
+type VerificationConfig struct {
+	// EmailVerificationExpiresIn is how many hours a verification link is valid for.
+	EmailVerificationExpiresIn int `mapstructure:"EMAIL_VERIFICATION_EXPIRES_IN"`
+	// EmailVerificationResendInterval is how many seconds an user must wait before requesting a new verification email.
+	EmailVerificationResendInterval int `mapstructure:"EMAIL_VERIFICATION_RESEND_INTERVAL"`
+	// RestrictUnverifiedUsers prevents users that did not verify their email from sending questions.
+	RestrictUnverifiedUsers bool `mapstructure:"RESTRICT_UNVERIFIED_USERS"`
+}
+
+// OIDCConfig holds the OpenID Connect providers configuration.
+type OIDCConfig struct {
+	// Providers is a JSON array of providers, like [{"name": "google", "issuer": "https://accounts.google.com", "clientId": "", "clientSecret": "", "redirectUrl": ""}].
+	Providers string `mapstructure:"OIDC_PROVIDERS"`
+}
+
+// Defaults of the password policy, used when PASSWORD_MIN_LENGTH or PASSWORD_MIN_ENTROPY are not set.
+const (
+	DEFAULT_PASSWORD_MIN_LENGTH  = 8
+	DEFAULT_PASSWORD_MIN_ENTROPY = 36
+)
+
+// PasswordConfig holds the password policy configuration.
+type PasswordConfig struct {
+	// MinLength is the minimum number of characters of new passwords. It defaults to DEFAULT_PASSWORD_MIN_LENGTH.
+	MinLength int `mapstructure:"PASSWORD_MIN_LENGTH"`
+	// MinEntropy is the minimum estimated entropy, in bits, of new passwords. It defaults to DEFAULT_PASSWORD_MIN_ENTROPY.
+	MinEntropy float64 `mapstructure:"PASSWORD_MIN_ENTROPY"`
+	// BreachedPasswordsFile is the path of a file with the SHA-1 hashes of breached passwords. If empty, the check is disabled.
+	BreachedPasswordsFile string `mapstructure:"BREACHED_PASSWORDS_FILE"`
+}
+
+// Validate returns error if a rule of the password policy is disabled by a value that is not positive,
+// like an empty PASSWORD_MIN_LENGTH or PASSWORD_MIN_ENTROPY.
+func (c PasswordConfig) Validate() error {
+	if c.MinLength <= 0 {
+		return errors.New("PASSWORD_MIN_LENGTH must be greater than 0")
+	}
+
+	if c.MinEntropy <= 0 {
+		return errors.New("PASSWORD_MIN_ENTROPY must be greater than 0")
+	}
+
+	return nil
+}
+
+// NicksConfig holds the nicks policy configuration.
+type NicksConfig struct {
+	// ReservedNicksFile is the path of a file with reserved nicks, one per line, reserved along with nicks.DEFAULT_RESERVED.
+	ReservedNicksFile string `mapstructure:"RESERVED_NICKS_FILE"`
+	// ReservationPeriod is how many hours an old nick is kept for the user after a nick change, redirecting to the new one.
+	ReservationPeriod int `mapstructure:"NICK_RESERVATION_PERIOD"`
+	// ChangeInterval is how many hours an user must wait between nick changes.
+	ChangeInterval int `mapstructure:"NICK_CHANGE_INTERVAL"`
+}
+
+// GeoIPConfig holds the local GeoIP databases configuration.
+type GeoIPConfig struct {
+	// CityDatabaseFile is the path of a MaxMind DB file with the location of IPs, like GeoLite2-City.mmdb. If empty, locations are not resolved.
+	CityDatabaseFile string `mapstructure:"GEOIP_CITY_DATABASE_FILE"`
+	// ASNDatabaseFile is the path of a MaxMind DB file with the autonomous system of IPs, like GeoLite2-ASN.mmdb. If empty, autonomous systems are not resolved.
+	ASNDatabaseFile string `mapstructure:"GEOIP_ASN_DATABASE_FILE"`
+}
+
+// AccountDeletionConfig holds the account deletion configuration.
+type AccountDeletionConfig struct {
+	// GracePeriod is how many hours an account is kept after its deletion is requested, so the user can reactivate it.
+	GracePeriod int `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+}
+
+// TimelinesConfig holds the timelines of answers configuration.
+type TimelinesConfig struct {
+	// PublicTimelinesEnabled allows visitors that are not signed in to see the answers of users, for web profile pages.
+	// Only the answers of users with the public answers visibility are shown.
+	PublicTimelinesEnabled bool `mapstructure:"PUBLIC_TIMELINES_ENABLED"`
+}
+
 // Conf is a model for app config. Like the app name, app port.
 // Also it can initialize DB configs, JWT, etc.
 type Conf struct {
@@ -87,6 +174,15 @@ type Conf struct {
 	S3     S3Config     `mapstructure:",squash"`
 	CDN    CDNConfig    `mapstructure:",squash"`
 	Cache  CacheConfig  `mapstructure:",squash"`
+
+	Verification VerificationConfig `mapstructure:",squash"`
+	OIDC         OIDCConfig         `mapstructure:",squash"`
+	Password     PasswordConfig     `mapstructure:",squash"`
+	Nicks        NicksConfig        `mapstructure:",squash"`
+	GeoIP        GeoIPConfig        `mapstructure:",squash"`
+
+	AccountDeletion AccountDeletionConfig `mapstructure:",squash"`
+	Timelines       TimelinesConfig       `mapstructure:",squash"`
 }
 
 var cfg *Conf
@@ -102,6 +198,28 @@ type AppCtx struct {
 	TrustedIPsQueue *amqp.Queue
 	S3Client        *s3.S3
 	Cache           *Cache
+	Keyring         *keyring.Keyring
+	Denylist        *denylist.Denylist
+	GeoIP           *geoip.Resolver
+	Nicks           *nicks.Policy
+	PersonalTokens  PersonalTokenVerifier
+	Policy          Policy
+}
+
+// PersonalTokenVerifier verifies the personal access tokens used by bots and integrations, see personaltokens.Verifier.
+// It is an interface so middlewares can verify tokens without depending on the personal tokens package.
+type PersonalTokenVerifier interface {
+	// IsPersonalToken returns true if the token looks like a personal access token, and not a JWT.
+	IsPersonalToken(token string) bool
+	// Verify returns the ID of the owner and the scopes of a personal access token, or an error if it is not valid.
+	Verify(token, ip string) (string, []string, error)
+}
+
+// Policy checks the permissions granted by the role of an user, see roles.Policy.
+// It is an interface so middlewares can check permissions without depending on the users package.
+type Policy interface {
+	// HasPermissions returns true if the user with the given ID was granted all the given permissions.
+	HasPermissions(userID string, permissions ...string) bool
 }
 
 // HandlersCtx is a global model for handlers. It defines the fiber context, app context, etc.
@@ -121,6 +239,9 @@ func LoadConfig(path string) (*Conf, error) {
 	viper.SetConfigFile(".env")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("PASSWORD_MIN_LENGTH", DEFAULT_PASSWORD_MIN_LENGTH)
+	viper.SetDefault("PASSWORD_MIN_ENTROPY", DEFAULT_PASSWORD_MIN_ENTROPY)
+
 	if err := viper.ReadInConfig(); err != nil {
 		panic(err)
 	}
@@ -129,6 +250,10 @@ func LoadConfig(path string) (*Conf, error) {
 		panic(err)
 	}
 
+	if err := cfg.Password.Validate(); err != nil {
+		return nil, err
+	}
+
 	log.Printf("======= %s ======= \n", cfg.App.APPName)
 	log.Printf("PORT: %s", cfg.App.ServerPort)
 	log.Printf("ENV: %s", cfg.App.Env)
@@ -20,6 +20,7 @@ require (
 	github.com/swaggo/swag v1.8.10
 	go.mongodb.org/mongo-driver v1.11.3
 	golang.org/x/crypto v0.7.0
+	golang.org/x/text v0.8.0
 )
 
 require (
@@ -72,7 +73,6 @@ require (
 	golang.org/x/net v0.8.0 // indirect
 	golang.org/x/sync v0.1.0 // indirect
 	golang.org/x/sys v0.6.0 // indirect
-	golang.org/x/text v0.8.0 // indirect
 	golang.org/x/tools v0.7.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/nicks"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/quessapp/toolkit/regexes"
 	"github.com/quessapp/toolkit/validations"
@@ -25,6 +26,12 @@ type SignUpUserDTO struct {
 	Locale    string
 }
 
+// VerifyEmailDTO is DTO for payload for verify-email handler.
+type VerifyEmailDTO struct {
+	// The token of the verification link sent to the user's email.
+	Token string
+}
+
 // ForgotPasswordDTO is DTO for payload for forgot-password handler.
 type ForgotPasswordDTO struct {
 	Email string
@@ -48,11 +55,36 @@ type SignInUserDTO struct {
 	TrustIP  bool
 }
 
+// SignInTwoFactorDTO is DTO for payload for the second step of signin handler, when two-factor authentication is enabled.
+type SignInTwoFactorDTO struct {
+	// The challenge token returned by the first step of the signin.
+	ChallengeToken string
+	// A code generated by the authenticator app or a recovery code.
+	Code    string
+	TrustIP bool
+}
+
+// PasswordlessRequestDTO is DTO for payload for the passwordless sign-in request handler.
+type PasswordlessRequestDTO struct {
+	Email string
+}
+
+// SignInPasswordlessDTO is DTO for payload for the passwordless sign-in handler.
+// Either the token of the link or the code sent to the email is required.
+type SignInPasswordlessDTO struct {
+	// The device token returned by the passwordless sign-in request.
+	DeviceToken string
+	// The token of the link sent to the email.
+	Token string
+	// The code sent to the email.
+	Code    string
+	TrustIP bool
+}
+
 // Format formats DTO information.
-// It removes special characters from nick and trim email.
+// It normalises the nick, see nicks.Normalize, and trim email.
 func (d *SignUpUserDTO) Format() {
-	d.Nick = regexp.MustCompile(regexes.SPECIAL_CHARS).ReplaceAllString(d.Nick, "")
-	d.Nick = strings.TrimSpace(strings.ToLower(d.Nick))
+	d.Nick = nicks.Normalize(d.Nick)
 	d.Email = strings.TrimSpace(d.Email)
 }
 
@@ -118,3 +150,61 @@ func (d ResetPasswordDTO) Validate() error {
 
 	return validations.GetValidationError(validationResult)
 }
+
+// Validate is a method of SignInTwoFactorDTO that validates the fields of the struct.
+// The ChallengeToken and Code fields are required.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+// If there are no validation errors, the method returns nil.
+func (d SignInTwoFactorDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.ChallengeToken, validation.Required.Error(errors.CHALLENGE_TOKEN_REQUIRED)),
+		validation.Field(&d.Code, validation.Required.Error(errors.TWO_FACTOR_CODE_REQUIRED)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of VerifyEmailDTO that validates the fields of the struct.
+// The Token field is required.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d VerifyEmailDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Token, validation.Required.Error(errors.VERIFICATION_TOKEN_REQUIRED)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Format formats DTO information. It trims and lowercases the email.
+func (d *PasswordlessRequestDTO) Format() {
+	d.Email = strings.TrimSpace(strings.ToLower(d.Email))
+}
+
+// Validate is a method of PasswordlessRequestDTO that validates the fields of the struct.
+// The Email field is required and must match a valid email format using the is.Email method.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d PasswordlessRequestDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Email, validation.Required.Error(errors.EMAIL_FIELD_REQUIRED), validation.Length(5, 200).Error(errors.EMAIL_FIELD_LENGTH), is.Email.Error(errors.EMAIL_FORMAT_INVALID)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of SignInPasswordlessDTO that validates the fields of the struct.
+// The DeviceToken field is required, and the Code field is required when the Token field is empty.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d SignInPasswordlessDTO) Validate() error {
+	codeRules := []validation.Rule{}
+
+	if d.Token == "" {
+		codeRules = append(codeRules, validation.Required.Error(errors.PASSWORDLESS_CODE_REQUIRED))
+	}
+
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.DeviceToken, validation.Required.Error(errors.DEVICE_TOKEN_REQUIRED)),
+		validation.Field(&d.Code, codeRules...),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
@@ -3,9 +3,38 @@ package auth
 import (
 	"time"
 
+	toolkitConstants "github.com/quessapp/toolkit/constants"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
 
+// TWO_FACTOR_CHALLENGE_TOKEN_TYPE is the type of the short-lived token issued when the second signin step is required.
+const TWO_FACTOR_CHALLENGE_TOKEN_TYPE = "TwoFactorChallenge"
+
+// TWO_FACTOR_CHALLENGE_EXPIRES_IN is how long the user has to complete the second signin step.
+const TWO_FACTOR_CHALLENGE_EXPIRES_IN = time.Minute * 5
+
+// ACCESS_TOKEN_EXPIRES_IN is the lifetime of the access tokens.
+const ACCESS_TOKEN_EXPIRES_IN = toolkitConstants.ONE_DAY_IN_HOURS
+
+// REFRESH_TOKEN_EXPIRES_IN is the lifetime of the refresh tokens, the longest of the user tokens.
+// It is how long the user watermarks are kept on the denylist.
+const REFRESH_TOKEN_EXPIRES_IN = toolkitConstants.THIRTY_DAYS_IN_HOURS
+
+const (
+	// PASSWORDLESS_EXPIRES_IN is how long a passwordless sign-in link or code is valid for.
+	PASSWORDLESS_EXPIRES_IN = time.Minute * 10
+	// PASSWORDLESS_RESEND_INTERVAL is how long an email must wait before requesting a new passwordless sign-in.
+	PASSWORDLESS_RESEND_INTERVAL = time.Minute
+	// PASSWORDLESS_MAX_REQUESTS is how many passwordless sign-ins an email can request in PASSWORDLESS_REQUESTS_WINDOW.
+	PASSWORDLESS_MAX_REQUESTS = 5
+	// PASSWORDLESS_REQUESTS_WINDOW is the window of PASSWORDLESS_MAX_REQUESTS.
+	PASSWORDLESS_REQUESTS_WINDOW = time.Hour
+	// PASSWORDLESS_MAX_ATTEMPTS is how many wrong codes can be tried before the sign-in is invalidated.
+	PASSWORDLESS_MAX_ATTEMPTS = 5
+	// PASSWORDLESS_CODE_DIGITS is the number of digits of the passwordless sign-in codes.
+	PASSWORDLESS_CODE_DIGITS = 6
+)
+
 // Token is a struct that represents an authentication token.
 // It can be a refresh token, an access token, or a code.
 type Token struct {
@@ -20,6 +49,41 @@ type Token struct {
 
 	AccessToken  string `json:"accessToken,omitempty" bson:"accessToken,omitempty"`
 	RefreshToken string `json:"refreshToken,omitempty" bson:"refreshToken,omitempty"`
+	// AccessTokenID and RefreshTokenID are the "jti" of the tokens issued together, revoked on logout.
+	AccessTokenID  string `json:"-" bson:"accessTokenId,omitempty"`
+	RefreshTokenID string `json:"-" bson:"refreshTokenId,omitempty"`
 	// It can be a code because it can be used for email verification like reset password.
+	// For two-factor challenges it holds the SHA-256 hash of the challenge token.
 	Code string `json:"-" bson:"code,omitempty"`
 }
+
+// PasswordPolicyErrors holds the translated rules of the password policy that a password did not satisfy.
+type PasswordPolicyErrors struct {
+	Errors []string `json:"errors"`
+}
+
+// PasswordlessSignIn is a passwordless sign-in requested by email. It is completed with the link or the code sent to the email,
+// along with the device token returned to the device that requested it, so a link opened on another device can't be used.
+// Only hashes are stored. Requests for unknown emails are stored too, without user, so they are rate-limited the same way.
+type PasswordlessSignIn struct {
+	ID     toolkitEntities.ID  `json:"id" bson:"_id"`
+	UserID *toolkitEntities.ID `json:"-" bson:"userId"`
+	Email  string              `json:"-" bson:"email"`
+	// DeviceHash is the SHA-256 hash of the device token.
+	DeviceHash string `json:"-" bson:"deviceHash"`
+	// LinkHash is the SHA-256 hash of the token of the link.
+	LinkHash string `json:"-" bson:"linkHash"`
+	// CodeHash is the SHA-256 hash of the code along with the device token, so codes can't be brute-forced from the hash alone.
+	CodeHash   string     `json:"-" bson:"codeHash"`
+	Attempts   int        `json:"-" bson:"attempts"`
+	ConsumedAt *time.Time `json:"-" bson:"consumedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt" bson:"expiresAt"`
+	CreatedAt  time.Time  `json:"-" bson:"createdAt"`
+}
+
+// PasswordlessRequest is the response of a passwordless sign-in request.
+type PasswordlessRequest struct {
+	// DeviceToken must be sent along with the link token or the code to complete the sign-in. It is shown once.
+	DeviceToken string    `json:"deviceToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
@@ -1,11 +1,18 @@
 package auth
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/lockouts"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
 	"github.com/quessapp/core-go/internal/users"
 	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/core-go/pkg/passwords"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/quessapp/toolkit/responses"
 
 	"net/http"
@@ -15,17 +22,17 @@ import (
 // It receives a HandlersCtx containing the HTTP request context, an AuthRepository for authentication,
 // and a UsersRepository for user data access. It parses the request body into a SignUpUserDTO,
 // creates a new user using the provided AuthRepository and UsersRepository, and returns a JSON response with the created user data.
-func SignUpUserHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+func SignUpUserHandler(handlerCtx *configs.HandlersCtx, passwordPolicy *passwords.Policy, authRepository *AuthRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
 	payload := SignUpUserDTO{}
 
 	if err := handlerCtx.C.BodyParser(&payload); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	u, err := SignUp(handlerCtx, &payload, authRepository, usersRepository)
+	u, err := SignUp(handlerCtx, &payload, passwordPolicy, authRepository, usersRepository, trustedLocationsRepository)
 
 	if err != nil {
-		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+		return parseUnsuccessfulPassword(handlerCtx, err)
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, u)
@@ -35,14 +42,71 @@ func SignUpUserHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepo
 // It receives a HandlersCtx containing the HTTP request context, an AuthRepository for authentication,
 // and a UsersRepository for user data access. It parses the request body into a SignInUserDTO,
 // authenticates the user using the provided AuthRepository, and returns a JSON response with the authenticated user data.
-func SignInUserHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+func SignInUserHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
 	payload := SignInUserDTO{}
 
 	if err := handlerCtx.C.BodyParser(&payload); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	u, err := SignIn(handlerCtx, &payload, authRepository, usersRepository)
+	u, err := SignIn(handlerCtx, &payload, authRepository, usersRepository, lockoutsRepository, bansRepository, trustedLocationsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, u)
+}
+
+// SignInTwoFactorHandler is an HTTP handler function that handles the second step of the sign-in,
+// for users with two-factor authentication enabled. It parses the request body into a SignInTwoFactorDTO,
+// verifies the challenge token and the two-factor code, and returns a JSON response with the authenticated user data.
+func SignInTwoFactorHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
+	payload := SignInTwoFactorDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	u, err := SignInWithTwoFactor(handlerCtx, &payload, authRepository, usersRepository, twoFactorRepository, lockoutsRepository, bansRepository, trustedLocationsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, u)
+}
+
+// RequestPasswordlessSignInHandler is an HTTP handler function that handles requests for a passwordless sign-in.
+// It parses the request body into a PasswordlessRequestDTO, sends a sign-in link and code to the email
+// and returns a JSON response with the device token that must be sent along with them.
+func RequestPasswordlessSignInHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+	payload := PasswordlessRequestDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	p, err := RequestPasswordlessSignIn(handlerCtx, &payload, authRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, p)
+}
+
+// SignInPasswordlessHandler is an HTTP handler function that completes a passwordless sign-in.
+// It parses the request body into a SignInPasswordlessDTO, verifies the link token or the code
+// and returns a JSON response with the authenticated user data.
+func SignInPasswordlessHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
+	payload := SignInPasswordlessDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	u, err := SignInWithPasswordless(handlerCtx, &payload, authRepository, usersRepository, bansRepository, trustedLocationsRepository)
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
@@ -114,16 +178,59 @@ func ForgotPasswordHandler(handlerCtx *configs.HandlersCtx, authRepository *Auth
 // Then, it calls the ResetPassword function passing the extracted DTO and repositories.
 // If the ResetPassword function returns an error, the function returns an HTTP response with a status code of 400 and the error message.
 // If the ResetPassword function does not return an error, the function returns an HTTP response with a status code of 201 and a null body.
-func ResetPasswordHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+func ResetPasswordHandler(handlerCtx *configs.HandlersCtx, passwordPolicy *passwords.Policy, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
 	payload := ResetPasswordDTO{}
 
 	if err := handlerCtx.C.BodyParser(&payload); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	if err := ResetPassword(handlerCtx, payload, authRepository, usersRepository); err != nil {
-		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	if err := ResetPassword(handlerCtx, payload, passwordPolicy, authRepository, usersRepository); err != nil {
+		return parseUnsuccessfulPassword(handlerCtx, err)
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
+
+// VerifyEmailHandler is an HTTP handler function that handles the verification links sent to the users.
+// It does not require authentication, since the link can be opened on any device.
+// It parses the request body into a VerifyEmailDTO and verifies the email of the token.
+func VerifyEmailHandler(handlerCtx *configs.HandlersCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+	payload := VerifyEmailDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	if err := VerifyEmail(handlerCtx, payload, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
+
+// parseUnsuccessfulPassword parses an unsuccessful response like responses.ParseUnsuccesfull.
+// When the password does not satisfy the policy, every failed rule is translated and returned in the data,
+// so the user can fix all of them at once. The message is the first failed rule.
+func parseUnsuccessfulPassword(handlerCtx *configs.HandlersCtx, err error) error {
+	var policyErr *passwords.ValidationError
+
+	if !errors.As(err, &policyErr) {
+		return users.ParseUnsuccessfulNick(handlerCtx, err)
+	}
+
+	data := PasswordPolicyErrors{Errors: []string{}}
+
+	for _, key := range policyErr.Keys {
+		data.Errors = append(data.Errors, i18n.Translate(handlerCtx, key))
+	}
+
+	handlerCtx.C.Status(http.StatusBadRequest)
+
+	return handlerCtx.C.JSON(&toolkitEntities.Response{
+		Ok:      false,
+		Error:   true,
+		Message: data.Errors[0],
+		Data:    data,
+	})
+}
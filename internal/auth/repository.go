@@ -2,9 +2,16 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 
 	"github.com/google/uuid"
+	"github.com/quessapp/core-go/internal/middlewares"
 	"github.com/quessapp/core-go/internal/users"
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/nicks"
 	"go.mongodb.org/mongo-driver/bson"
 
 	toolkitConstants "github.com/quessapp/toolkit/constants"
@@ -41,6 +48,7 @@ func (a AuthRepository) SignUp(payload *SignUpUserDTO) (*users.User, error) {
 	user := users.User{
 		ID:              payload.ID,
 		Nick:            payload.Nick,
+		NickSkeleton:    nicks.Skeleton(payload.Nick),
 		Name:            payload.Name,
 		Email:           payload.Email,
 		Password:        payload.Password,
@@ -67,6 +75,7 @@ func (a AuthRepository) SignUp(payload *SignUpUserDTO) (*users.User, error) {
 // UpdateUserPassword updates the password for a user with the given userID.
 // It takes in the userID and the newHashedPassword as parameters and updates the password
 // of the user in the database with the newHashedPassword.
+// Users created by a social sign-in have a password from now on, so the "passwordNotSet" flag is removed.
 // It returns an error if the update fails.
 func (a AuthRepository) UpdateUserPassword(userID toolkitEntities.ID, newHashedPassword []byte) error {
 	coll := a.db.Collection(toolkitConstants.USERS)
@@ -76,6 +85,10 @@ func (a AuthRepository) UpdateUserPassword(userID toolkitEntities.ID, newHashedP
 			Key:   "$set",
 			Value: bson.D{{Key: "password", Value: string(newHashedPassword)}},
 		},
+		{
+			Key:   "$unset",
+			Value: bson.D{{Key: "passwordNotSet", Value: ""}},
+		},
 	}
 
 	_, err := coll.UpdateByID(context.Background(), userID, update)
@@ -83,41 +96,43 @@ func (a AuthRepository) UpdateUserPassword(userID toolkitEntities.ID, newHashedP
 	return err
 }
 
-// CreateUserToken function creates a new JWT token with a given user ID, expiration time and secret key and returns it as a signed string.
-// It takes a user ID, an expiration time and a secret string as arguments.
-// The function first creates a MapClaims object with "id" and "exp" fields set to the provided user ID and expiration time, respectively.
-// Next, it creates a new JWT token using jwt.NewWithClaims method, with the HS256 signing method and the claims object.
-// Finally, the function signs the token with the provided secret key using the token.SignedString method and returns the signed token as a string.
+// CreateUserToken function creates a new JWT token with a given user ID, token ID, type and expiration time and returns it as a signed string.
+// It takes a user ID, a token ID, the token type, an expiration time and the keyring as arguments.
+// The function first creates a MapClaims object with "id", "jti", "typ", "iat" and "exp" fields.
+// The token ID and the issue time are used to revoke the token, see denylist.Denylist.
+// The type tells access tokens from refresh tokens, since only access tokens authenticate requests, see middlewares.JWTMiddleware.
+// Finally, the function signs the claims with the current signing key of the keyring and returns the signed token as a string.
 // If any error occurs during the token creation or signing process, the function returns an empty string and the error.
-func (a *AuthRepository) CreateUserToken(userID toolkitEntities.ID, expiresIn time.Time, secret string) (string, error) {
+func (a *AuthRepository) CreateUserToken(userID toolkitEntities.ID, tokenID, tokenType string, expiresIn time.Time, kr *keyring.Keyring) (string, error) {
 	claims := jwt.MapClaims{
 		"id":  userID,
+		"jti": tokenID,
+		"typ": tokenType,
+		"iat": time.Now().Unix(),
 		"exp": expiresIn.Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	return token.SignedString([]byte(secret))
+	return kr.Sign(claims)
 }
 
 // CreateAccessToken function generates a new access token for a given user and returns it as a string.
-// It takes a user ID and a secret string as arguments.
-// The function calls the CreateUserToken method of the AuthRepository with the given user ID, an expiration time 1 day in the future,
-// and the secret string.
+// It takes a user ID, a token ID and the keyring as arguments.
+// The function calls the CreateUserToken method of the AuthRepository with the given user ID and token ID, the access token type,
+// an expiration time 1 day in the future, and the keyring.
 // If the CreateUserToken function returns an error, the function returns an empty string and the error.
 // Otherwise, it returns the generated access token as a string.
-func (a *AuthRepository) CreateAccessToken(userID toolkitEntities.ID, secret string) (string, error) {
-	return a.CreateUserToken(userID, time.Now().Add(toolkitConstants.ONE_DAY_IN_HOURS), secret)
+func (a *AuthRepository) CreateAccessToken(userID toolkitEntities.ID, tokenID string, kr *keyring.Keyring) (string, error) {
+	return a.CreateUserToken(userID, tokenID, middlewares.TOKEN_TYPE_ACCESS, time.Now().Add(ACCESS_TOKEN_EXPIRES_IN), kr)
 }
 
 // CreateRefreshToken function generates a new refresh token for a given user and returns it as a string.
-// It takes a user ID and a secret string as arguments.
-// The function calls the CreateUserToken method of the AuthRepository with the given user ID, an expiration time 30 days in the future,
-// and the secret string.
+// It takes a user ID, a token ID and the keyring as arguments.
+// The function calls the CreateUserToken method of the AuthRepository with the given user ID and token ID, the refresh token type,
+// an expiration time 30 days in the future, and the keyring.
 // If the CreateUserToken function returns an error, the function returns an empty string and the error.
 // Otherwise, it returns the generated refresh token as a string.
-func (a *AuthRepository) CreateRefreshToken(userID toolkitEntities.ID, secret string) (string, error) {
-	return a.CreateUserToken(userID, time.Now().Add(toolkitConstants.THIRTY_DAYS_IN_HOURS), secret)
+func (a *AuthRepository) CreateRefreshToken(userID toolkitEntities.ID, tokenID string, kr *keyring.Keyring) (string, error) {
+	return a.CreateUserToken(userID, tokenID, middlewares.TOKEN_TYPE_REFRESH, time.Now().Add(REFRESH_TOKEN_EXPIRES_IN), kr)
 }
 
 // CreateCodeToken creates a code token with followed fields:
@@ -146,35 +161,39 @@ func (a *AuthRepository) CreateCodeToken(userID toolkitEntities.ID) (*Token, err
 }
 
 // CreateAuthTokens function creates a new token pair (access token and refresh token) and saves them in the database.
-// It takes a user ID and a secret string as arguments.
+// It takes a user ID and the keyring as arguments.
 // The function first creates an access token using the CreateAccessToken function of the AuthRepository.
 // Then, it creates a refresh token using the CreateRefreshToken function of the AuthRepository.
-// Next, it creates a Token object with the generated tokens, expiration date, creation date, user ID, and type ("Bearer").
+// Next, it creates a Token object with the generated tokens, the IDs of both tokens, expiration date, creation date, user ID, and type ("Bearer").
 // It then inserts the token object into the tokens collection of the database using MongoDB driver's InsertOne method.
 // If the insertion is successful, the function sets the access token in the token object and returns it.
 // If any error occurs, the function returns nil and the error.
-func (a *AuthRepository) CreateAuthTokens(userID toolkitEntities.ID, secret string) (*Token, error) {
+func (a *AuthRepository) CreateAuthTokens(userID toolkitEntities.ID, kr *keyring.Keyring) (*Token, error) {
 	coll := a.db.Collection(toolkitConstants.TOKENS)
 
-	accessToken, err := a.CreateAccessToken(userID, secret)
+	accessTokenID := uuid.New().String()
+	accessToken, err := a.CreateAccessToken(userID, accessTokenID, kr)
 
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := a.CreateRefreshToken(userID, secret)
+	refreshTokenID := uuid.New().String()
+	refreshToken, err := a.CreateRefreshToken(userID, refreshTokenID, kr)
 
 	if err != nil {
 		return nil, err
 	}
 
 	tokens := Token{
-		ID:           toolkitEntities.NewID(),
-		Type:         "Bearer",
-		ExpiresAt:    time.Now().Add(toolkitConstants.THIRTY_DAYS_IN_HOURS),
-		CreatedAt:    time.Now(),
-		CreatedBy:    &userID,
-		RefreshToken: refreshToken,
+		ID:             toolkitEntities.NewID(),
+		Type:           "Bearer",
+		ExpiresAt:      time.Now().Add(REFRESH_TOKEN_EXPIRES_IN),
+		CreatedAt:      time.Now(),
+		CreatedBy:      &userID,
+		RefreshToken:   refreshToken,
+		AccessTokenID:  accessTokenID,
+		RefreshTokenID: refreshTokenID,
 	}
 
 	_, err = coll.InsertOne(context.Background(), tokens)
@@ -212,8 +231,9 @@ func (a AuthRepository) FindTokenByUserIDAndRefreshToken(userID toolkitEntities.
 }
 
 // DeleteRefreshToken deletes a refresh token from the database.
-// It takes in the refresh token as a parameter and returns an error if one occurs.
-func (a AuthRepository) DeleteRefreshToken(token string) error {
+// It takes in the refresh token as a parameter and returns the deleted token, so its access token can be revoked.
+// The returned token has a zero ID if the refresh token does not exist.
+func (a AuthRepository) DeleteRefreshToken(token string) (*Token, error) {
 	coll := a.db.Collection(toolkitConstants.TOKENS)
 
 	filter := bson.D{
@@ -222,20 +242,27 @@ func (a AuthRepository) DeleteRefreshToken(token string) error {
 		},
 	}
 
-	_, err := coll.DeleteOne(context.Background(), filter)
+	t := Token{}
 
-	return err
+	err := coll.FindOneAndDelete(context.Background(), filter).Decode(&t)
+
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	return &t, nil
 }
 
-// CheckIfTrustedIPExists checks if a given IP address exists in the user's trusted IPs list.
+// CheckIfTrustedIPExists checks if a given IP address exists in the user's legacy trusted IPs list.
 // It takes in the user ID and the IP address as parameters and returns true if the IP address exists in the list.
 // Otherwise, it returns false.
+// New IPs are trusted on the trusted locations, see trustedlocations.TrustIP.
 func (a AuthRepository) CheckIfTrustedIPExists(userID toolkitEntities.ID, ip string) bool {
 	coll := a.db.Collection(toolkitConstants.USERS)
 
 	filter := bson.D{
 		{
-			Key: "id", Value: userID,
+			Key: "_id", Value: userID,
 		},
 		{
 			Key: "trustedIps", Value: ip,
@@ -247,9 +274,9 @@ func (a AuthRepository) CheckIfTrustedIPExists(userID toolkitEntities.ID, ip str
 	return count > 0
 }
 
-// AddNewTrustedIPIfDontExists adds a new trusted IP to the user's trusted IPs list if it does not already exist.
+// RemoveTrustedIP removes an IP from the user's legacy trusted IPs list.
 // It takes in the user ID and the IP address as parameters and returns an error if one occurs.
-func (a AuthRepository) AddNewTrustedIPIfDontExists(userID toolkitEntities.ID, ip string) error {
+func (a AuthRepository) RemoveTrustedIP(userID toolkitEntities.ID, ip string) error {
 	coll := a.db.Collection(toolkitConstants.USERS)
 
 	filter := bson.D{
@@ -260,10 +287,8 @@ func (a AuthRepository) AddNewTrustedIPIfDontExists(userID toolkitEntities.ID, i
 
 	update := bson.D{
 		{
-			Key: "$addToSet", Value: bson.D{
-				{Key: "trustedIps", Value: bson.D{
-					{Key: "$each", Value: []string{ip}}},
-				},
+			Key: "$pull", Value: bson.D{
+				{Key: "trustedIps", Value: ip},
 			},
 		},
 	}
@@ -334,3 +359,132 @@ func (a AuthRepository) DeleteAllUserTokens(userID toolkitEntities.ID, tokenType
 
 	return err
 }
+
+// hashChallengeToken hashes a two-factor challenge token with SHA-256, so only the hash is stored.
+func hashChallengeToken(challengeToken string) string {
+	sum := sha256.Sum256([]byte(challengeToken))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateTwoFactorChallengeToken creates a short-lived token to be exchanged, along with a two-factor code, for the auth tokens.
+// It stores only the hash of the challenge and returns the plain challenge, that is shown once to the client.
+func (a *AuthRepository) CreateTwoFactorChallengeToken(userID toolkitEntities.ID) (string, error) {
+	coll := a.db.Collection(toolkitConstants.TOKENS)
+
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	challengeToken := hex.EncodeToString(b)
+
+	t := Token{
+		ID:        toolkitEntities.NewID(),
+		Type:      TWO_FACTOR_CHALLENGE_TOKEN_TYPE,
+		ExpiresAt: time.Now().Add(TWO_FACTOR_CHALLENGE_EXPIRES_IN),
+		CreatedAt: time.Now(),
+		CreatedBy: &userID,
+		Code:      hashChallengeToken(challengeToken),
+	}
+
+	if _, err := coll.InsertOne(context.Background(), t); err != nil {
+		return "", err
+	}
+
+	return challengeToken, nil
+}
+
+// FindTwoFactorChallengeToken finds a two-factor challenge token in the database that matches the given plain challenge.
+// It returns a pointer to a Token object. If no token is found, the ID of the token is zero.
+func (a AuthRepository) FindTwoFactorChallengeToken(challengeToken string) *Token {
+	coll := a.db.Collection(toolkitConstants.TOKENS)
+
+	filter := bson.D{
+		{
+			Key: "type", Value: TWO_FACTOR_CHALLENGE_TOKEN_TYPE,
+		},
+		{
+			Key: "code", Value: hashChallengeToken(challengeToken),
+		},
+	}
+
+	t := Token{}
+
+	coll.FindOne(context.Background(), filter).Decode(&t)
+
+	return &t
+}
+
+// CountPasswordlessSignIns counts the passwordless sign-ins requested by an email since the given time.
+func (a AuthRepository) CountPasswordlessSignIns(email string, since time.Time) int64 {
+	coll := a.db.Collection(pkgConstants.PASSWORDLESS_SIGN_INS)
+
+	filter := bson.D{
+		{Key: "email", Value: email},
+		{Key: "createdAt", Value: bson.D{{Key: "$gt", Value: since}}},
+	}
+
+	count, _ := coll.CountDocuments(context.Background(), filter)
+
+	return count
+}
+
+// CreatePasswordlessSignIn inserts a new passwordless sign-in.
+func (a AuthRepository) CreatePasswordlessSignIn(p *PasswordlessSignIn) error {
+	coll := a.db.Collection(pkgConstants.PASSWORDLESS_SIGN_INS)
+
+	_, err := coll.InsertOne(context.Background(), p)
+
+	return err
+}
+
+// FindPasswordlessSignIn finds the passwordless sign-in of a device token that did not expire and was not used.
+// If no sign-in is found, the ID of the returned sign-in is zero.
+func (a AuthRepository) FindPasswordlessSignIn(deviceToken string) *PasswordlessSignIn {
+	coll := a.db.Collection(pkgConstants.PASSWORDLESS_SIGN_INS)
+
+	filter := bson.D{
+		{Key: "deviceHash", Value: hashChallengeToken(deviceToken)},
+		{Key: "consumedAt", Value: nil},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	p := PasswordlessSignIn{}
+
+	coll.FindOne(context.Background(), filter).Decode(&p)
+
+	return &p
+}
+
+// RegisterPasswordlessAttempt increments the wrong attempts of a passwordless sign-in.
+func (a AuthRepository) RegisterPasswordlessAttempt(id toolkitEntities.ID) error {
+	coll := a.db.Collection(pkgConstants.PASSWORDLESS_SIGN_INS)
+
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "attempts", Value: 1}}},
+	}
+
+	_, err := coll.UpdateByID(context.Background(), id, update)
+
+	return err
+}
+
+// ConsumePasswordlessSignIn marks a passwordless sign-in as used.
+// It returns false if the sign-in was already used, so concurrent requests can't use it twice.
+func (a AuthRepository) ConsumePasswordlessSignIn(id toolkitEntities.ID) bool {
+	coll := a.db.Collection(pkgConstants.PASSWORDLESS_SIGN_INS)
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "consumedAt", Value: nil},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "consumedAt", Value: time.Now()}}},
+	}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err == nil && result.ModifiedCount == 1
+}
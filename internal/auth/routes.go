@@ -2,21 +2,38 @@ package auth
 
 import (
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/lockouts"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/passwords"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // LoadRoutes is a function that sets up the routes for the auth API.
-// It takes in an AppCtx, a AuthRepository, and a UserRepository.
-func LoadRoutes(AppCtx *configs.AppCtx, authRepository *AuthRepository, usersRepository *users.UsersRepository) {
+// It takes in an AppCtx, the password Policy, a AuthRepository, a UserRepository, a TwoFactorRepository, a LockoutsRepository, a BansRepository, and a TrustedLocationsRepository.
+func LoadRoutes(AppCtx *configs.AppCtx, passwordPolicy *passwords.Policy, authRepository *AuthRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) {
 	g := AppCtx.App.Group("/auth")
 
 	g.Post("/signup", func(c *fiber.Ctx) error {
-		return SignUpUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
+		return SignUpUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, passwordPolicy, authRepository, usersRepository, trustedLocationsRepository)
 	})
 	g.Post("/signin", func(c *fiber.Ctx) error {
-		return SignInUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
+		return SignInUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository, lockoutsRepository, bansRepository, trustedLocationsRepository)
+	})
+	g.Post("/signin/2fa", func(c *fiber.Ctx) error {
+		return SignInTwoFactorHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository, twoFactorRepository, lockoutsRepository, bansRepository, trustedLocationsRepository)
+	})
+	g.Post("/passwordless", func(c *fiber.Ctx) error {
+		return RequestPasswordlessSignInHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
+	})
+	g.Post("/passwordless/verify", func(c *fiber.Ctx) error {
+		return SignInPasswordlessHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository, bansRepository, trustedLocationsRepository)
+	})
+	g.Post("/verify-email", func(c *fiber.Ctx) error {
+		return VerifyEmailHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
 	})
 	g.Post("/refresh", func(c *fiber.Ctx) error {
 		return RefreshTokenHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
@@ -28,6 +45,6 @@ func LoadRoutes(AppCtx *configs.AppCtx, authRepository *AuthRepository, usersRep
 		return ForgotPasswordHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
 	})
 	g.Put("/reset-password", func(c *fiber.Ctx) error {
-		return ResetPasswordHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, authRepository, usersRepository)
+		return ResetPasswordHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, passwordPolicy, authRepository, usersRepository)
 	})
 }
@@ -1,25 +1,43 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
 	"log"
+	"math"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/lockouts"
 	"github.com/quessapp/core-go/internal/queues/emails"
 	trustedIPs "github.com/quessapp/core-go/internal/queues/trusted-ips"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/passwords"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyHashedPassword is compared on sign-in when the nick does not exist, so unknown nicks take as long as existing ones.
+var dummyHashedPassword, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
 // SignUp is a function for signing up a user. It takes in several parameters, including a HandlersCtx struct, a SignUpUserDTO payload, an AuthRepository, and a UsersRepository.
 // The function first formats the payload using the Format() method defined in the SignUpUserDTO struct. It then validates the payload using the Validate() method also defined in the SignUpUserDTO struct.
-// Next, the function checks if the email and nick are already in use using the IsEmailInUse() and IsNickInUse() methods defined in the users package.
+// Next, the function checks if the email is already in use, and if the nick is available using users.CheckNickAvailability.
 // If the payload is valid and the email and nick are not already in use, the function generates a hashed password using the bcrypt package and the payload's password.
 // The function then calls the SignUp() method of the AuthRepository and passes in the payload. If the signup is successful,
 // the function creates an access token and refresh token for the user using the CreateAccessToken() and CreateRefreshToken() methods defined in the users package.
+// The password must satisfy the password policy, see passwords.Policy.
+// A verification link is sent to the user's email, see users.SendVerificationEmail.
 // Finally, the function creates a ResponseWithUser struct containing the user's ID, name, email, locale, access token, and refresh token, and returns it along with any error that occurred during the process.
-func SignUp(handlerCtx *configs.HandlersCtx, payload *SignUpUserDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository) (*users.ResponseWithUser, error) {
+func SignUp(handlerCtx *configs.HandlersCtx, payload *SignUpUserDTO, passwordPolicy *passwords.Policy, authRepository *AuthRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
 	payload.Format()
 
 	if err := payload.Validate(); err != nil {
@@ -30,7 +48,11 @@ func SignUp(handlerCtx *configs.HandlersCtx, payload *SignUpUserDTO, authReposit
 		return nil, err
 	}
 
-	if err := users.IsNickInUse(usersRepository.IsNickInUse(payload.Nick)); err != nil {
+	if err := users.CheckNickAvailability(handlerCtx, payload.Nick, toolkitEntities.ID{}, usersRepository); err != nil {
+		return nil, err
+	}
+
+	if err := passwordPolicy.Validate(payload.Password, passwords.UserInfo{Nick: payload.Nick, Name: payload.Name, Email: payload.Email}); err != nil {
 		return nil, err
 	}
 
@@ -50,11 +72,16 @@ func SignUp(handlerCtx *configs.HandlersCtx, payload *SignUpUserDTO, authReposit
 
 	ip := handlerCtx.C.IP()
 
-	if err := authRepository.AddNewTrustedIPIfDontExists(u.ID, ip); err != nil {
+	if err := trustedlocations.TrustIP(handlerCtx, u.ID, ip, trustedLocationsRepository); err != nil {
 		log.Printf("Error adding new trusted IP: %v for user %v-%v", err, u.ID, u.Nick)
 	}
 
-	authTokens, err := authRepository.CreateAuthTokens(u.ID, handlerCtx.Cfg.JWT.Secret)
+	// the user can ask for a new verification email, so signup does not fail if it can't be sent
+	if err := users.SendVerificationEmail(handlerCtx, u, usersRepository); err != nil {
+		log.Printf("Error sending verification email: %v for user %v-%v", err, u.ID, u.Nick)
+	}
+
+	authTokens, err := authRepository.CreateAuthTokens(u.ID, handlerCtx.Keyring)
 
 	if err != nil {
 		return nil, err
@@ -84,36 +111,167 @@ func SignUp(handlerCtx *configs.HandlersCtx, payload *SignUpUserDTO, authReposit
 //
 // It returns a ResponseWithUser struct containing the authenticated user's information,
 // an access token and a refresh token if the authentication was successful.
+// If the user has two-factor authentication enabled and the IP is not trusted, the password is not enough:
+// no tokens are returned, only a challenge token to be exchanged with a two-factor code on SignInWithTwoFactor.
+// Failed attempts are tracked per nick and per IP, and block new attempts with an exponential backoff, see lockouts.Policy.
 // Otherwise, it returns an error.
-func SignIn(handlerCtx *configs.HandlersCtx, payload *SignInUserDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository) (*users.ResponseWithUser, error) {
+func SignIn(handlerCtx *configs.HandlersCtx, payload *SignInUserDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
 	if err := payload.Validate(); err != nil {
 		return nil, err
 	}
 
 	ip := handlerCtx.C.IP()
+
+	if err := lockouts.IsAllowed(payload.Nick, ip, lockoutsRepository); err != nil {
+		return nil, err
+	}
+
 	u := usersRepository.FindUserByNick(payload.Nick)
+	isTrustedIP := isTrustedIP(handlerCtx, u.ID, ip, authRepository, trustedLocationsRepository)
 
-	if !authRepository.CheckIfTrustedIPExists(u.ID, ip) {
+	if !isTrustedIP {
 		log.Printf("IP %s is not trusted \n", ip)
 		trustedIPs.SendIPToQueue(handlerCtx.Cfg, handlerCtx.MessageQueueCh, handlerCtx.TrustedIPsQueue, u.Locale, ip, u.Email)
 	}
 
+	// the password is compared even if the nick does not exist, so the response time does not tell if an account exists
+	hashedPassword := []byte(u.Password)
+
+	if users.UserExists(u) != nil {
+		hashedPassword = dummyHashedPassword
+	}
+
+	if err := IsSignInDataCorrect(u, bcrypt.CompareHashAndPassword(hashedPassword, []byte(payload.Password))); err != nil {
+		lockouts.RegisterFailure(handlerCtx, payload.Nick, ip, u, lockoutsRepository)
+
+		return nil, err
+	}
+
+	if err := bans.IsNotBanned(bansRepository.FindActiveBan(u.ID)); err != nil {
+		return nil, err
+	}
+
+	// untrusted IPs must complete the second step even when the password is correct
+	if u.IsTwoFactorEnabled() && !isTrustedIP {
+		return createTwoFactorChallengeResponse(u, authRepository)
+	}
+
+	lockouts.RegisterSuccess(payload.Nick, lockoutsRepository)
+
+	return createSignInResponse(handlerCtx, u, payload.TrustIP, authRepository, trustedLocationsRepository)
+}
+
+// AuthenticateUser signs in an user whose identity was already proven by other means than the password, like a social sign-in.
+// The same ban and two-factor rules of SignIn are applied, so it can return a challenge token instead of the auth tokens.
+func AuthenticateUser(handlerCtx *configs.HandlersCtx, u *users.User, trustIP bool, authRepository *AuthRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
+	if err := bans.IsNotBanned(bansRepository.FindActiveBan(u.ID)); err != nil {
+		return nil, err
+	}
+
+	if u.IsTwoFactorEnabled() && !isTrustedIP(handlerCtx, u.ID, handlerCtx.C.IP(), authRepository, trustedLocationsRepository) {
+		return createTwoFactorChallengeResponse(u, authRepository)
+	}
+
+	return createSignInResponse(handlerCtx, u, trustIP, authRepository, trustedLocationsRepository)
+}
+
+// SignInWithTwoFactor completes the signin of an user with two-factor authentication enabled.
+// It receives the challenge token returned by SignIn and a code, that can be a TOTP code or a recovery code.
+// The challenge token is deleted once the code is verified, so it can't be used again.
+// It returns a ResponseWithUser struct containing the authenticated user's information, an access token and a refresh token.
+func SignInWithTwoFactor(handlerCtx *configs.HandlersCtx, payload *SignInTwoFactorDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository, lockoutsRepository *lockouts.LockoutsRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	t := authRepository.FindTwoFactorChallengeToken(payload.ChallengeToken)
+
+	if err := ChallengeTokenExists(t); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(*t.CreatedBy)
+
 	if err := users.UserExists(u); err != nil {
 		return nil, err
 	}
 
-	if err := IsPasswordCorrect(bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(payload.Password))); err != nil {
+	ip := handlerCtx.C.IP()
+
+	if err := lockouts.IsAllowed(u.Nick, ip, lockoutsRepository); err != nil {
+		return nil, err
+	}
+
+	if err := twofactor.VerifyCode(handlerCtx, u, payload.Code, twoFactorRepository); err != nil {
+		lockouts.RegisterFailure(handlerCtx, u.Nick, ip, u, lockoutsRepository)
+
+		return nil, err
+	}
+
+	if err := authRepository.DeleteTokenByID(t.ID); err != nil {
+		return nil, err
+	}
+
+	lockouts.RegisterSuccess(u.Nick, lockoutsRepository)
+
+	if err := bans.IsNotBanned(bansRepository.FindActiveBan(u.ID)); err != nil {
+		return nil, err
+	}
+
+	return createSignInResponse(handlerCtx, u, payload.TrustIP, authRepository, trustedLocationsRepository)
+}
+
+// isTrustedIP checks if the IP belongs to a trusted location of the user, see trustedlocations.IsTrusted.
+// IPs on the legacy trusted IPs list of the user are moved to the trusted locations.
+func isTrustedIP(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, ip string, authRepository *AuthRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) bool {
+	if trustedlocations.IsTrusted(handlerCtx, userID, ip, trustedLocationsRepository) {
+		return true
+	}
+
+	if toolkitEntities.IsZeroID(userID) || !authRepository.CheckIfTrustedIPExists(userID, ip) {
+		return false
+	}
+
+	if err := trustedlocations.TrustIP(handlerCtx, userID, ip, trustedLocationsRepository); err != nil {
+		log.Printf("Error moving legacy trusted IP %v of user %v: %v", ip, userID, err)
+		return true
+	}
+
+	if err := authRepository.RemoveTrustedIP(userID, ip); err != nil {
+		log.Printf("Error removing legacy trusted IP %v of user %v: %v", ip, userID, err)
+	}
+
+	return true
+}
+
+// createTwoFactorChallengeResponse creates a challenge token for an user with two-factor authentication enabled.
+// No user data or tokens are returned until the challenge is completed on SignInWithTwoFactor.
+func createTwoFactorChallengeResponse(u *users.User, authRepository *AuthRepository) (*users.ResponseWithUser, error) {
+	challengeToken, err := authRepository.CreateTwoFactorChallengeToken(u.ID)
+
+	if err != nil {
 		return nil, err
 	}
 
-	authTokens, err := authRepository.CreateAuthTokens(u.ID, handlerCtx.Cfg.JWT.Secret)
+	data := &users.ResponseWithUser{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}
+
+	return data, nil
+}
+
+// createSignInResponse creates the auth tokens of an user that is already authenticated,
+// trusts the request IP if asked to, and returns the ResponseWithUser struct.
+func createSignInResponse(handlerCtx *configs.HandlersCtx, u *users.User, trustIP bool, authRepository *AuthRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
+	authTokens, err := authRepository.CreateAuthTokens(u.ID, handlerCtx.Keyring)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if payload.TrustIP {
-		if err := authRepository.AddNewTrustedIPIfDontExists(u.ID, ip); err != nil {
+	if trustIP {
+		if err := trustedlocations.TrustIP(handlerCtx, u.ID, handlerCtx.C.IP(), trustedLocationsRepository); err != nil {
 			log.Printf("Error adding new trusted IP: %v for user %v-%v", err, u.ID, u.Nick)
 		}
 	}
@@ -142,7 +300,8 @@ func SignIn(handlerCtx *configs.HandlersCtx, payload *SignInUserDTO, authReposit
 // database using the AuthRepository's FindTokenByUserIDAndRefreshToken function. If the token
 // doesn't exist, it returns an error. Otherwise, it deletes the existing token using the
 // AuthRepository's DeleteByID function and creates a new token pair using the CreateAuthTokens
-// function. It returns the new token pair or an error if there was an issue.
+// function. Sessions created before the tokens of the user were revoked, see denylist.Denylist.RevokeUserTokens,
+// can't be refreshed. It returns the new token pair or an error if there was an issue.
 func RefreshToken(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, refreshToken string, authRepository *AuthRepository) (*Token, error) {
 	t := authRepository.FindTokenByUserIDAndRefreshToken(authenticatedUserID, refreshToken)
 
@@ -162,15 +321,34 @@ func RefreshToken(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEn
 		return nil, err
 	}
 
-	return authRepository.CreateAuthTokens(*t.CreatedBy, handlerCtx.Cfg.JWT.Secret)
+	if err := IsTokenNotRevoked(handlerCtx.Denylist.IsRevoked(t.RefreshTokenID, t.CreatedBy.Hex(), t.CreatedAt)); err != nil {
+		return nil, err
+	}
+
+	return authRepository.CreateAuthTokens(*t.CreatedBy, handlerCtx.Keyring)
 }
 
 // Logout deletes the refresh token from the database.
 // It takes a HandlersCtx, an authenticatedUserID, a token, and an AuthRepository as arguments.
 // The function first deletes the token from the database using the AuthRepository's DeleteRefreshToken function.
+// Then, the access and refresh tokens are put on the denylist, so they are rejected right away instead of when they expire.
 // If any error occurs, the function returns the error. Otherwise, it returns nil.
 func Logout(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, token string, authRepository *AuthRepository) error {
-	return authRepository.DeleteRefreshToken(token)
+	t, err := authRepository.DeleteRefreshToken(token)
+
+	if err != nil {
+		return err
+	}
+
+	if t.AccessTokenID != "" {
+		handlerCtx.Denylist.RevokeToken(t.AccessTokenID, t.CreatedAt.Add(ACCESS_TOKEN_EXPIRES_IN))
+	}
+
+	if t.RefreshTokenID != "" {
+		handlerCtx.Denylist.RevokeToken(t.RefreshTokenID, t.ExpiresAt)
+	}
+
+	return nil
 }
 
 // ForgotPassword function handles the password reset process.
@@ -218,13 +396,13 @@ func ForgotPassword(handlerCtx *configs.HandlersCtx, payload ForgotPasswordDTO,
 // If the code exists, the function checks if the code is expired using the IsCodeExpired function.
 // If the code is expired, it deletes the code token from the database using the AuthRepository's DeleteTokenByID function.
 // Then, it returns an error.
-// If the code is not expired, the function generates a new hashed password using the bcrypt package.
-// Then, it finds the user associated with the code token using the UsersRepository's FindUserByID function.
+// If the code is not expired, the function finds the user associated with the code token using the UsersRepository's FindUserByID function.
 // If the user does not exist, it returns an error.
+// Then, it checks the new password against the password policy and generates a new hashed password using the bcrypt package.
 // If the user exists, the function updates the user's password using the UsersRepository's UpdateUserPassword function.
 // Then, it deletes the code token from the database using the AuthRepository's DeleteTokenByID function.
 // Finally, it returns nil.
-func ResetPassword(handlerCtx *configs.HandlersCtx, payload ResetPasswordDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
+func ResetPassword(handlerCtx *configs.HandlersCtx, payload ResetPasswordDTO, passwordPolicy *passwords.Policy, authRepository *AuthRepository, usersRepository *users.UsersRepository) error {
 	if err := payload.Validate(); err != nil {
 		return err
 	}
@@ -243,15 +421,19 @@ func ResetPassword(handlerCtx *configs.HandlersCtx, payload ResetPasswordDTO, au
 		return err
 	}
 
-	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	u := usersRepository.FindUserByID(*t.CreatedBy)
 
-	if err != nil {
+	if err := users.UserExists(u); err != nil {
 		return err
 	}
 
-	u := usersRepository.FindUserByID(*t.CreatedBy)
+	if err := passwordPolicy.Validate(payload.Password, passwords.UserInfo{Nick: u.Nick, Name: u.Name, Email: u.Email}); err != nil {
+		return err
+	}
 
-	if err := users.UserExists(u); err != nil {
+	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+
+	if err != nil {
 		return err
 	}
 
@@ -269,6 +451,9 @@ func ResetPassword(handlerCtx *configs.HandlersCtx, payload ResetPasswordDTO, au
 		if err := authRepository.DeleteAllUserTokens(u.ID, &tokenType); err != nil {
 			return err
 		}
+
+		// the access tokens are not stored, so they are revoked by the user watermark
+		handlerCtx.Denylist.RevokeUserTokens(u.ID.Hex(), REFRESH_TOKEN_EXPIRES_IN)
 	}
 
 	if err := authRepository.DeleteTokenByID(t.ID); err != nil {
@@ -279,3 +464,155 @@ func ResetPassword(handlerCtx *configs.HandlersCtx, payload ResetPasswordDTO, au
 
 	return nil
 }
+
+// VerifyEmail validates the VerifyEmailDTO and verifies the email of the token using users.VerifyEmail.
+func VerifyEmail(handlerCtx *configs.HandlersCtx, payload VerifyEmailDTO, usersRepository *users.UsersRepository) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	return users.VerifyEmail(handlerCtx, payload.Token, usersRepository)
+}
+
+// RequestPasswordlessSignIn sends a sign-in link and a code to the email, if it belongs to an user.
+// The response is the same for unknown emails, so it does not tell if an account exists.
+// The link and the code expire after PASSWORDLESS_EXPIRES_IN, can be used once,
+// and only along with the returned device token, see SignInWithPasswordless.
+// Requests are rate-limited by email, see IsPasswordlessRequestAllowed.
+func RequestPasswordlessSignIn(handlerCtx *configs.HandlersCtx, payload *PasswordlessRequestDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository) (*PasswordlessRequest, error) {
+	payload.Format()
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	recentRequests := authRepository.CountPasswordlessSignIns(payload.Email, now.Add(-PASSWORDLESS_RESEND_INTERVAL))
+	windowRequests := authRepository.CountPasswordlessSignIns(payload.Email, now.Add(-PASSWORDLESS_REQUESTS_WINDOW))
+
+	if err := IsPasswordlessRequestAllowed(recentRequests, windowRequests); err != nil {
+		return nil, err
+	}
+
+	deviceToken, err := generatePasswordlessToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	linkToken, err := generatePasswordlessToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := generatePasswordlessCode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PasswordlessSignIn{
+		ID:         toolkitEntities.NewID(),
+		Email:      payload.Email,
+		DeviceHash: hashChallengeToken(deviceToken),
+		LinkHash:   hashChallengeToken(linkToken),
+		CodeHash:   hashPasswordlessCode(deviceToken, code),
+		ExpiresAt:  now.Add(PASSWORDLESS_EXPIRES_IN),
+		CreatedAt:  now,
+	}
+
+	u := usersRepository.FindUserByEmail(payload.Email)
+
+	if users.UserExists(u) == nil {
+		p.UserID = &u.ID
+	}
+
+	if err := authRepository.CreatePasswordlessSignIn(p); err != nil {
+		return nil, err
+	}
+
+	if p.UserID != nil {
+		link := fmt.Sprintf("%s/passwordless?token=%s", handlerCtx.Cfg.App.FrontendURL, linkToken)
+
+		go emails.SendEmailPasswordlessSignIn(handlerCtx, code, link, u)
+	}
+
+	data := &PasswordlessRequest{
+		DeviceToken: deviceToken,
+		ExpiresAt:   p.ExpiresAt,
+	}
+
+	return data, nil
+}
+
+// SignInWithPasswordless completes a passwordless sign-in with the device token and the token of the link or the code.
+// After PASSWORDLESS_MAX_ATTEMPTS wrong codes the sign-in is invalidated and a new one must be requested.
+// The same ban and two-factor rules of SignIn are applied, see AuthenticateUser.
+func SignInWithPasswordless(handlerCtx *configs.HandlersCtx, payload *SignInPasswordlessDTO, authRepository *AuthRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := authRepository.FindPasswordlessSignIn(payload.DeviceToken)
+
+	if err := IsPasswordlessSignInValid(p); err != nil {
+		return nil, err
+	}
+
+	isCorrect := false
+
+	if payload.Token != "" {
+		isCorrect = subtle.ConstantTimeCompare([]byte(hashChallengeToken(payload.Token)), []byte(p.LinkHash)) == 1
+	} else {
+		isCorrect = subtle.ConstantTimeCompare([]byte(hashPasswordlessCode(payload.DeviceToken, payload.Code)), []byte(p.CodeHash)) == 1
+	}
+
+	if err := IsPasswordlessSecretCorrect(isCorrect); err != nil {
+		if err := authRepository.RegisterPasswordlessAttempt(p.ID); err != nil {
+			log.Printf("Error registering passwordless attempt %v: %v", p.ID, err)
+		}
+
+		return nil, err
+	}
+
+	if err := IsPasswordlessSecretCorrect(authRepository.ConsumePasswordlessSignIn(p.ID)); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(*p.UserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	return AuthenticateUser(handlerCtx, u, payload.TrustIP, authRepository, bansRepository, trustedLocationsRepository)
+}
+
+// generatePasswordlessToken generates a random token for the device and the link of a passwordless sign-in.
+func generatePasswordlessToken() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// generatePasswordlessCode generates a random code of PASSWORDLESS_CODE_DIGITS digits.
+func generatePasswordlessCode() (string, error) {
+	max := big.NewInt(int64(math.Pow10(PASSWORDLESS_CODE_DIGITS)))
+	n, err := rand.Int(rand.Reader, max)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", PASSWORDLESS_CODE_DIGITS, n.Int64()), nil
+}
+
+// hashPasswordlessCode hashes a passwordless code along with the device token.
+func hashPasswordlessCode(deviceToken, code string) string {
+	return hashChallengeToken(deviceToken + ":" + strings.TrimSpace(code))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/quessapp/core-go/internal/users"
 	pkgErrors "github.com/quessapp/core-go/pkg/errors"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
@@ -18,6 +19,16 @@ func IsPasswordCorrect(hashResult error) error {
 	return nil
 }
 
+// IsSignInDataCorrect returns the same error when the user does not exist or the password is incorrect,
+// so the sign-in response does not tell if an account exists.
+func IsSignInDataCorrect(u *users.User, hashResult error) error {
+	if toolkitEntities.IsZeroID(u.ID) {
+		return errors.New(pkgErrors.INCORRECT_SIGNIN_DATA)
+	}
+
+	return IsPasswordCorrect(hashResult)
+}
+
 // TokenExists checks if the given token exists in the database. It returns an error if the
 // token's ID is zero, indicating that the token does not exist, or nil if the token exists.
 func TokenExists(t *Token) error {
@@ -28,6 +39,15 @@ func TokenExists(t *Token) error {
 	return nil
 }
 
+// IsTokenNotRevoked returns an error if the token was revoked, see denylist.Denylist.
+func IsTokenNotRevoked(isRevoked bool) error {
+	if isRevoked {
+		return errors.New(pkgErrors.TOKEN_REVOKED)
+	}
+
+	return nil
+}
+
 // CodeExists checks if the given code exists in the database. It returns an error if the
 // code's ID is zero, indicating that the code does not exist, or nil if the code exists.
 func CodeExists(t *Token) error {
@@ -59,3 +79,42 @@ func IsCodeExpired(c *Token) error {
 
 	return nil
 }
+
+// ChallengeTokenExists checks if the given two-factor challenge token exists and has not expired.
+// It returns the same error in both cases, so clients can't tell them apart.
+func ChallengeTokenExists(t *Token) error {
+	if toolkitEntities.IsZeroID(t.ID) || t.ExpiresAt.Before(time.Now()) {
+		return errors.New(pkgErrors.CHALLENGE_TOKEN_INVALID)
+	}
+
+	return nil
+}
+
+// IsPasswordlessRequestAllowed returns an error if the email requested a passwordless sign-in less than PASSWORDLESS_RESEND_INTERVAL ago,
+// or more than PASSWORDLESS_MAX_REQUESTS times in PASSWORDLESS_REQUESTS_WINDOW.
+func IsPasswordlessRequestAllowed(recentRequests, windowRequests int64) error {
+	if recentRequests > 0 || windowRequests >= PASSWORDLESS_MAX_REQUESTS {
+		return errors.New(pkgErrors.PASSWORDLESS_RECENTLY_REQUESTED)
+	}
+
+	return nil
+}
+
+// IsPasswordlessSignInValid returns an error if the passwordless sign-in does not exist, belongs to an unknown email,
+// or has no attempts left.
+func IsPasswordlessSignInValid(p *PasswordlessSignIn) error {
+	if toolkitEntities.IsZeroID(p.ID) || p.UserID == nil || p.Attempts >= PASSWORDLESS_MAX_ATTEMPTS {
+		return errors.New(pkgErrors.PASSWORDLESS_CODE_INVALID)
+	}
+
+	return nil
+}
+
+// IsPasswordlessSecretCorrect returns an error if the link token or the code does not match the passwordless sign-in.
+func IsPasswordlessSecretCorrect(isCorrect bool) error {
+	if !isCorrect {
+		return errors.New(pkgErrors.PASSWORDLESS_CODE_INVALID)
+	}
+
+	return nil
+}
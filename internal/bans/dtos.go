@@ -0,0 +1,41 @@
+package bans
+
+import (
+	"errors"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// BanUserDTO is DTO for payload for ban user handler.
+type BanUserDTO struct {
+	Reason string
+	// ExpiresAt is when the ban is lifted. If empty, the ban is permanent.
+	ExpiresAt *time.Time
+}
+
+// checkIfExpiresAtIsInTheFuture returns error if the given time is not in the future.
+func checkIfExpiresAtIsInTheFuture(value any) error {
+	expiresAt, _ := value.(*time.Time)
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errors.New(pkgErrors.BAN_EXPIRES_AT_INVALID)
+	}
+
+	return nil
+}
+
+// Validate is a method of BanUserDTO that validates the fields of the struct.
+// The Reason field is required and must have at most REASON_MAX_LENGTH characters.
+// The ExpiresAt field, if present, must be in the future.
+func (d BanUserDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Reason, validation.Required.Error(pkgErrors.REASON_FIELD_REQUIRED), validation.Length(1, REASON_MAX_LENGTH).Error(pkgErrors.BAN_REASON_LENGTH)),
+		validation.Field(&d.ExpiresAt, validation.By(checkIfExpiresAtIsInTheFuture)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
@@ -0,0 +1,24 @@
+package bans
+
+import (
+	"time"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// REASON_MAX_LENGTH is the maximum length of the reason of a ban.
+const REASON_MAX_LENGTH = 500
+
+// Ban is a ban applied to an user by an admin. Banned users can't sign in and their tokens are revoked.
+type Ban struct {
+	ID     toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID toolkitEntities.ID `json:"userId" bson:"userId"`
+	Reason string             `json:"reason" bson:"reason"`
+	// ExpiresAt is nil for permanent bans.
+	ExpiresAt *time.Time `json:"expiresAt" bson:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
+
+	// BannedBy is the moderator or admin that banned the user, and LiftedBy the one that lifted the ban, if it was lifted.
+	BannedBy toolkitEntities.ID  `json:"bannedBy" bson:"bannedBy"`
+	LiftedBy *toolkitEntities.ID `json:"liftedBy,omitempty" bson:"liftedBy,omitempty"`
+}
@@ -0,0 +1,70 @@
+package bans
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// BanUserHandler bans the user with the given ID.
+func BanUserHandler(handlerCtx *configs.HandlersCtx, bansRepository *BansRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	payload := BanUserDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	actorID := users.GetUserByToken(handlerCtx).ID
+
+	ban, err := BanUser(handlerCtx, &payload, id, actorID, bansRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, ban)
+}
+
+// GetBanHandler returns the active ban of the user with the given ID.
+func GetBanHandler(handlerCtx *configs.HandlersCtx, bansRepository *BansRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	ban, err := GetBan(handlerCtx, id, bansRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusNotFound, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, ban)
+}
+
+// LiftBanHandler lifts the active ban of the user with the given ID.
+func LiftBanHandler(handlerCtx *configs.HandlersCtx, bansRepository *BansRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	actorID := users.GetUserByToken(handlerCtx).ID
+
+	if err := LiftBan(handlerCtx, id, actorID, bansRepository, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
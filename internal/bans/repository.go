@@ -0,0 +1,66 @@
+package bans
+
+import (
+	"context"
+	"time"
+
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BansRepository represents bans repository.
+type BansRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns bans repository.
+func NewRepository(db *mongo.Database) *BansRepository {
+	return &BansRepository{db}
+}
+
+// getActiveBanFilter returns the filter of the bans of the user that are permanent or did not expire.
+func getActiveBanFilter(userID toolkitEntities.ID) bson.D {
+	return bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "expiresAt", Value: nil}},
+			bson.D{{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}}},
+		}},
+	}
+}
+
+// FindActiveBan finds the active ban of an user.
+func (b *BansRepository) FindActiveBan(userID toolkitEntities.ID) *Ban {
+	coll := b.db.Collection(toolkitConstants.USERS_BANS)
+
+	var foundBan Ban
+
+	coll.FindOne(context.Background(), getActiveBanFilter(userID)).Decode(&foundBan)
+
+	return &foundBan
+}
+
+// Create inserts a new ban.
+func (b *BansRepository) Create(ban *Ban) error {
+	coll := b.db.Collection(toolkitConstants.USERS_BANS)
+
+	_, err := coll.InsertOne(context.Background(), ban)
+
+	return err
+}
+
+// Lift ends the active bans of an user. The bans are kept as history, expiring now, with who lifted them.
+func (b *BansRepository) Lift(userID, liftedBy toolkitEntities.ID) error {
+	coll := b.db.Collection(toolkitConstants.USERS_BANS)
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "expiresAt", Value: time.Now()}, {Key: "liftedBy", Value: liftedBy}}},
+	}
+
+	_, err := coll.UpdateMany(context.Background(), getActiveBanFilter(userID), update)
+
+	return err
+}
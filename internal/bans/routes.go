@@ -0,0 +1,26 @@
+package bans
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the admin routes for the bans.
+// It takes in an AppCtx, a BansRepository, and a UsersRepository.
+// Only signed in users whose role grants users.PERMISSION_USERS_BAN can use them.
+func LoadRoutes(AppCtx *configs.AppCtx, bansRepository *BansRepository, usersRepository *users.UsersRepository) {
+	g := AppCtx.App.Group("/admin/users/:id/ban", middlewares.JWTMiddleware(AppCtx), middlewares.PolicyMiddleware(AppCtx, users.PERMISSION_USERS_BAN))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetBanHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, bansRepository)
+	})
+	g.Post("/", func(c *fiber.Ctx) error {
+		return BanUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, bansRepository, usersRepository)
+	})
+	g.Delete("/", func(c *fiber.Ctx) error {
+		return LiftBanHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, bansRepository, usersRepository)
+	})
+}
@@ -0,0 +1,103 @@
+package bans
+
+import (
+	"log"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// TOKENS_MAX_EXPIRES_IN is the lifetime of the longest user token, the refresh token.
+// Every token issued before a ban is revoked for this long, so no session survives it.
+const TOKENS_MAX_EXPIRES_IN = toolkitConstants.THIRTY_DAYS_IN_HOURS
+
+// BanUser bans an user. Every token of the user is revoked right away, see denylist.Denylist.RevokeUserTokens,
+// and the user can't sign in until the ban expires or is lifted. The actor is recorded on the ban, and can only ban
+// users with a less privileged role, never themselves, see checkActor.
+func BanUser(handlerCtx *configs.HandlersCtx, payload *BanUserDTO, userID, actorID toolkitEntities.ID, bansRepository *BansRepository, usersRepository *users.UsersRepository) (*Ban, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(userID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := checkActor(u, actorID, usersRepository); err != nil {
+		return nil, err
+	}
+
+	if err := IsNotBanned(bansRepository.FindActiveBan(u.ID)); err != nil {
+		return nil, err
+	}
+
+	ban := &Ban{
+		ID:        toolkitEntities.NewID(),
+		UserID:    u.ID,
+		Reason:    payload.Reason,
+		ExpiresAt: payload.ExpiresAt,
+		CreatedAt: time.Now(),
+		BannedBy:  actorID,
+	}
+
+	if err := bansRepository.Create(ban); err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %s banned by %s: %s", u.Nick, actorID.Hex(), ban.Reason)
+
+	handlerCtx.Denylist.RevokeUserTokens(u.ID.Hex(), TOKENS_MAX_EXPIRES_IN)
+
+	return ban, nil
+}
+
+// GetBan returns the active ban of an user.
+func GetBan(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, bansRepository *BansRepository) (*Ban, error) {
+	ban := bansRepository.FindActiveBan(userID)
+
+	if err := BanExists(ban); err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+// LiftBan lifts the active ban of an user, so the user can sign in again. The actor is recorded on the ban, with the
+// same restrictions as BanUser. The tokens revoked by the ban stay revoked.
+func LiftBan(handlerCtx *configs.HandlersCtx, userID, actorID toolkitEntities.ID, bansRepository *BansRepository, usersRepository *users.UsersRepository) error {
+	u := usersRepository.FindUserByID(userID)
+
+	if err := users.UserExists(u); err != nil {
+		return err
+	}
+
+	if err := checkActor(u, actorID, usersRepository); err != nil {
+		return err
+	}
+
+	if err := BanExists(bansRepository.FindActiveBan(userID)); err != nil {
+		return err
+	}
+
+	return bansRepository.Lift(userID, actorID)
+}
+
+// checkActor validates that the moderator or admin with the given ID can ban or lift the ban of the user.
+func checkActor(u *users.User, actorID toolkitEntities.ID, usersRepository *users.UsersRepository) error {
+	if err := IsNotBanningYourself(u, actorID); err != nil {
+		return err
+	}
+
+	actor := usersRepository.FindUserByID(actorID)
+
+	if err := users.UserExists(actor); err != nil {
+		return err
+	}
+
+	return CanModerate(actor, u)
+}
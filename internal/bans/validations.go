@@ -0,0 +1,46 @@
+package bans
+
+import (
+	"errors"
+
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// IsNotBanned returns error if the user has an active ban.
+func IsNotBanned(b *Ban) error {
+	if !toolkitEntities.IsZeroID(b.ID) {
+		return errors.New(pkgErrors.USER_BANNED)
+	}
+
+	return nil
+}
+
+// IsNotBanningYourself returns error if the moderator or admin is trying to ban or lift the ban of their own account.
+func IsNotBanningYourself(u *users.User, actorID toolkitEntities.ID) error {
+	if u.ID == actorID {
+		return errors.New(pkgErrors.CANT_BAN_YOURSELF)
+	}
+
+	return nil
+}
+
+// CanModerate returns error if the role of the user is not less privileged than the role of the actor, so moderators can't
+// ban other moderators or admins, see users.User.Outranks.
+func CanModerate(actor *users.User, u *users.User) error {
+	if !actor.Outranks(*u) {
+		return errors.New(pkgErrors.CANT_BAN_USER_ROLE)
+	}
+
+	return nil
+}
+
+// BanExists returns error if the user has no active ban.
+func BanExists(b *Ban) error {
+	if toolkitEntities.IsZeroID(b.ID) {
+		return errors.New(pkgErrors.BAN_NOT_FOUND)
+	}
+
+	return nil
+}
@@ -60,3 +60,18 @@ func (b *BlocksRepository) IsUserBlocked(userID toolkitEntities.ID) bool {
 
 	return !toolkitEntities.IsZeroID(foundRegistry.ID)
 }
+
+// IsBlockedBetween checks if one of the given users blocked the other one, in either direction.
+func (b *BlocksRepository) IsBlockedBetween(firstUserID, secondUserID toolkitEntities.ID) bool {
+	coll := b.db.Collection(collections.BLOCKS)
+
+	filter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "userToBlock", Value: firstUserID}, {Key: "blockedBy", Value: secondUserID}},
+		bson.D{{Key: "userToBlock", Value: secondUserID}, {Key: "blockedBy", Value: firstUserID}},
+	}}}
+	foundRegistry := BlockedUser{}
+
+	coll.FindOne(context.Background(), filter).Decode(&foundRegistry)
+
+	return !toolkitEntities.IsZeroID(foundRegistry.ID)
+}
@@ -11,7 +11,7 @@ import (
 // LoadRoutes is a function that sets up the routes for the blocks API.
 // It takes in an AppCtx, a UsersRepository, and a BlocksRepository.
 func LoadRoutes(AppCtx *configs.AppCtx, usersRepository *users.UsersRepository, blocksRepository *BlocksRepository) {
-	g := AppCtx.App.Group("/blocks", middlewares.JWTMiddleware(AppCtx.App, AppCtx.Cfg))
+	g := AppCtx.App.Group("/blocks", middlewares.JWTMiddleware(AppCtx))
 
 	g.Post("/user/:id", func(c *fiber.Ctx) error {
 		return BlockUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, blocksRepository)
@@ -0,0 +1,41 @@
+package deletions
+
+import (
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// RequestDeletionDTO is DTO for payload for request account deletion handler.
+// The deletion requires the user to authenticate again.
+type RequestDeletionDTO struct {
+	// Password is the current password of the user. Users that never defined a password must have signed in recently instead.
+	Password string
+	// Code is a two-factor code, or a recovery code, required when two-factor authentication is enabled.
+	Code string
+}
+
+// Validate is a method of RequestDeletionDTO that validates the fields of the struct for the given user.
+// The Password field is required unless the user never defined a password.
+// The Code field is required if the user has two-factor authentication enabled.
+func (d RequestDeletionDTO) Validate(u *users.User) error {
+	passwordRules := []validation.Rule{}
+	codeRules := []validation.Rule{}
+
+	if !u.PasswordNotSet {
+		passwordRules = append(passwordRules, validation.Required.Error(errors.PASSWORD_FIELD_REQUIRED))
+	}
+
+	if u.IsTwoFactorEnabled() {
+		codeRules = append(codeRules, validation.Required.Error(errors.TWO_FACTOR_CODE_REQUIRED))
+	}
+
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Password, passwordRules...),
+		validation.Field(&d.Code, codeRules...),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
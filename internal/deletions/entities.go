@@ -0,0 +1,50 @@
+package deletions
+
+import (
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+)
+
+const (
+	// DEFAULT_GRACE_PERIOD is used when ACCOUNT_DELETION_GRACE_PERIOD is not set.
+	DEFAULT_GRACE_PERIOD = time.Hour * 24 * 30
+	// JOB_INTERVAL is how often the accounts whose grace period ended are deleted.
+	JOB_INTERVAL = time.Hour
+	// REAUTHENTICATION_MAX_AGE is how recent the sign-in of users without a password must be to request the deletion,
+	// since they have no password to confirm it.
+	REAUTHENTICATION_MAX_AGE = time.Minute * 10
+)
+
+// Cascade is a collection with documents of the users, deleted along with their accounts.
+type Cascade struct {
+	Collection string
+	// Fields that reference the user. Documents that match any of them are deleted.
+	Fields []string
+}
+
+// CASCADES are the collections cleaned when an account is deleted.
+// Questions are not here: the received ones are deleted and the sent ones are anonymised, see DeletionsRepository.
+// Reactions are deleted before, along with their counters, see DeletionsRepository.DeleteReactions.
+// Role changes are not here either, they are kept for the audit trail and anonymised, see DeletionsRepository.AnonymiseRoleChanges.
+var CASCADES = []Cascade{
+	{Collection: toolkitConstants.TOKENS, Fields: []string{"createdBy"}},
+	{Collection: toolkitConstants.BLOCKS, Fields: []string{"blockedBy", "userToBlock"}},
+	// sendTo references the user on reports of users
+	{Collection: toolkitConstants.REPORTS, Fields: []string{"sentBy", "sendTo"}},
+	{Collection: toolkitConstants.USERS_BANS, Fields: []string{"userId"}},
+	{Collection: pkgConstants.IDENTITIES, Fields: []string{"userId"}},
+	{Collection: pkgConstants.PASSWORDLESS_SIGN_INS, Fields: []string{"userId"}},
+	{Collection: pkgConstants.TRUSTED_LOCATIONS, Fields: []string{"userId"}},
+	{Collection: pkgConstants.PERSONAL_TOKENS, Fields: []string{"userId"}},
+	{Collection: pkgConstants.NICK_HISTORY, Fields: []string{"userId"}},
+	// the files of the exports are deleted before, see DeletionsRepository.FindExportFiles
+	{Collection: pkgConstants.DATA_EXPORTS, Fields: []string{"userId"}},
+}
+
+// DeletionRequest is the response of an account deletion request.
+type DeletionRequest struct {
+	// DeleteAt is when the account is deleted. The user can reactivate it until then.
+	DeleteAt time.Time `json:"deleteAt"`
+}
@@ -0,0 +1,41 @@
+package deletions
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// RequestDeletionHandler schedules the deletion of the account of the authenticated user.
+func RequestDeletionHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) error {
+	payload := RequestDeletionDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	request, err := RequestDeletion(handlerCtx, &payload, authenticatedUserID, usersRepository, twoFactorRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusAccepted, request)
+}
+
+// CancelDeletionHandler reactivates the account of the authenticated user, cancelling its deletion.
+func CancelDeletionHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := CancelDeletion(handlerCtx, authenticatedUserID, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
@@ -0,0 +1,248 @@
+package deletions
+
+import (
+	"context"
+
+	"github.com/quessapp/core-go/internal/exports"
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeletionsRepository represents the account deletions repository.
+// It cleans the documents of deleted users across the collections of the other features.
+type DeletionsRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns the account deletions repository.
+func NewRepository(db *mongo.Database) *DeletionsRepository {
+	return &DeletionsRepository{db}
+}
+
+// DeleteUserDocuments deletes the documents of an user from every collection of CASCADES.
+func (d *DeletionsRepository) DeleteUserDocuments(userID toolkitEntities.ID) error {
+	for _, cascade := range CASCADES {
+		coll := d.db.Collection(cascade.Collection)
+
+		conditions := bson.A{}
+
+		for _, field := range cascade.Fields {
+			conditions = append(conditions, bson.D{{Key: field, Value: userID}})
+		}
+
+		if _, err := coll.DeleteMany(context.Background(), bson.D{{Key: "$or", Value: conditions}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteSignInAttempts deletes the failed sign-in attempts of a key, like the nick of a deleted user.
+func (d *DeletionsRepository) DeleteSignInAttempts(key string) error {
+	coll := d.db.Collection(pkgConstants.SIGN_IN_ATTEMPTS)
+
+	_, err := coll.DeleteMany(context.Background(), bson.D{{Key: "key", Value: key}})
+
+	return err
+}
+
+// DeleteReceivedQuestions deletes the questions sent to an user, along with the reports, the reactions and the follow-ups of them.
+func (d *DeletionsRepository) DeleteReceivedQuestions(userID toolkitEntities.ID) error {
+	questionsColl := d.db.Collection(toolkitConstants.QUESTIONS)
+
+	filter := bson.D{{Key: "sendTo", Value: userID}}
+	opts := options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := questionsColl.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return err
+	}
+
+	var received []struct {
+		ID toolkitEntities.ID `bson:"_id"`
+	}
+
+	if err := cursor.All(context.Background(), &received); err != nil {
+		return err
+	}
+
+	if len(received) > 0 {
+		ids := bson.A{}
+
+		for _, q := range received {
+			ids = append(ids, q.ID)
+		}
+
+		reportsColl := d.db.Collection(toolkitConstants.REPORTS)
+
+		if _, err := reportsColl.DeleteMany(context.Background(), bson.D{{Key: "sendTo", Value: bson.D{{Key: "$in", Value: ids}}}}); err != nil {
+			return err
+		}
+
+		// reactions and follow-ups belong to the questions
+		for _, collection := range []string{pkgConstants.REACTIONS, pkgConstants.FOLLOW_UPS} {
+			if _, err := d.db.Collection(collection).DeleteMany(context.Background(), bson.D{{Key: "questionId", Value: bson.D{{Key: "$in", Value: ids}}}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = questionsColl.DeleteMany(context.Background(), filter)
+
+	return err
+}
+
+// AnonymiseSentQuestions removes the sender of the questions sent by an user, so the users that received them keep them.
+func (d *DeletionsRepository) AnonymiseSentQuestions(userID toolkitEntities.ID) error {
+	coll := d.db.Collection(toolkitConstants.QUESTIONS)
+
+	filter := bson.D{{Key: "sentBy", Value: userID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "sentBy", Value: nil},
+			{Key: "IsAnonymous", Value: true},
+		}},
+	}
+
+	_, err := coll.UpdateMany(context.Background(), filter, update)
+
+	return err
+}
+
+// AnonymiseRoleChanges removes an user from the role changes of the account, which are kept for the audit trail.
+func (d *DeletionsRepository) AnonymiseRoleChanges(userID toolkitEntities.ID) error {
+	coll := d.db.Collection(pkgConstants.ROLE_CHANGES)
+
+	filter := bson.D{{Key: "userId", Value: userID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "userId", Value: nil}}},
+	}
+
+	_, err := coll.UpdateMany(context.Background(), filter, update)
+
+	return err
+}
+
+// DeleteReactions deletes the reactions of an user, decrementing the counters of the questions that the user reacted to.
+// Each counter is only decremented if its reaction was deleted, so a deletion retried after a failure does not
+// decrement the counters twice.
+func (d *DeletionsRepository) DeleteReactions(userID toolkitEntities.ID) error {
+	coll := d.db.Collection(pkgConstants.REACTIONS)
+
+	cursor, err := coll.Find(context.Background(), bson.D{{Key: "userId", Value: userID}})
+
+	if err != nil {
+		return err
+	}
+
+	var reactions []struct {
+		ID         toolkitEntities.ID `bson:"_id"`
+		QuestionID toolkitEntities.ID `bson:"questionId"`
+		Type       string             `bson:"type"`
+	}
+
+	if err := cursor.All(context.Background(), &reactions); err != nil {
+		return err
+	}
+
+	questionsColl := d.db.Collection(toolkitConstants.QUESTIONS)
+
+	for _, r := range reactions {
+		result, err := coll.DeleteOne(context.Background(), bson.D{{Key: "_id", Value: r.ID}})
+
+		if err != nil {
+			return err
+		}
+
+		if result.DeletedCount == 0 {
+			continue
+		}
+
+		filter := bson.D{{Key: "_id", Value: r.QuestionID}}
+		update := bson.D{{Key: "$inc", Value: bson.D{{Key: "reactions." + r.Type, Value: -1}}}}
+
+		if _, err := questionsColl.UpdateOne(context.Background(), filter, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindExportFiles finds the files of the data exports of an user that are still on the storage.
+func (d *DeletionsRepository) FindExportFiles(userID toolkitEntities.ID) ([]string, error) {
+	coll := d.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "fileName", Value: bson.D{{Key: "$exists", Value: true}}},
+		{Key: "status", Value: bson.D{{Key: "$ne", Value: exports.STATUS_EXPIRED}}},
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "fileName", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found []struct {
+		FileName string `bson:"fileName"`
+	}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	fileNames := []string{}
+
+	for _, e := range found {
+		fileNames = append(fileNames, e.FileName)
+	}
+
+	return fileNames, nil
+}
+
+// FindAttachmentFiles finds the files of the attachments of the replies of an user.
+func (d *DeletionsRepository) FindAttachmentFiles(userID toolkitEntities.ID) ([]string, error) {
+	coll := d.db.Collection(toolkitConstants.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "sendTo", Value: userID},
+		{Key: "attachments.fileName", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "attachments.fileName", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found []struct {
+		Attachments []struct {
+			FileName string `bson:"fileName"`
+		} `bson:"attachments"`
+	}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	fileNames := []string{}
+
+	for _, q := range found {
+		for _, a := range q.Attachments {
+			fileNames = append(fileNames, a.FileName)
+		}
+	}
+
+	return fileNames, nil
+}
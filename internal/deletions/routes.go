@@ -0,0 +1,24 @@
+package deletions
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the account deletion.
+// It takes in an AppCtx, a UsersRepository and a TwoFactorRepository.
+// The routes require the JWT of a signed in user, so a personal access token can't delete an account.
+func LoadRoutes(AppCtx *configs.AppCtx, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) {
+	g := AppCtx.App.Group("/users/me/deletion", middlewares.JWTMiddleware(AppCtx))
+
+	g.Post("/", func(c *fiber.Ctx) error {
+		return RequestDeletionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, twoFactorRepository)
+	})
+	g.Delete("/", func(c *fiber.Ctx) error {
+		return CancelDeletionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
+	})
+}
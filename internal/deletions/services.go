@@ -0,0 +1,207 @@
+package deletions
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/lockouts"
+	"github.com/quessapp/core-go/internal/queues/emails"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	toolkitS3 "github.com/quessapp/toolkit/s3"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// GetGracePeriod returns the configured ACCOUNT_DELETION_GRACE_PERIOD, defaulting to DEFAULT_GRACE_PERIOD.
+func GetGracePeriod(cfg *configs.Conf) time.Duration {
+	if cfg.AccountDeletion.GracePeriod > 0 {
+		return time.Hour * time.Duration(cfg.AccountDeletion.GracePeriod)
+	}
+
+	return DEFAULT_GRACE_PERIOD
+}
+
+// getSignedInAt returns when the token of the request was issued, or a zero time if it is unknown.
+func getSignedInAt(handlerCtx *configs.HandlersCtx) time.Time {
+	token, ok := handlerCtx.C.Locals("user").(*jwt.Token)
+
+	if !ok {
+		return time.Time{}
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	issuedAt, _ := claims["iat"].(float64)
+
+	if issuedAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(issuedAt), 0)
+}
+
+// RequestDeletion schedules the deletion of the account of the authenticated user after the grace period.
+// The user must authenticate again with the password, or sign in again if the account has no password,
+// and with a two-factor code if it is enabled.
+// The account is deactivated right away, and it can be reactivated with CancelDeletion until it is deleted.
+func RequestDeletion(handlerCtx *configs.HandlersCtx, payload *RequestDeletionDTO, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) (*DeletionRequest, error) {
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsNotPendingDeletion(u); err != nil {
+		return nil, err
+	}
+
+	if err := payload.Validate(u); err != nil {
+		return nil, err
+	}
+
+	if u.PasswordNotSet {
+		if err := IsRecentSignIn(getSignedInAt(handlerCtx)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := IsPasswordCorrect(bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(payload.Password))); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.IsTwoFactorEnabled() {
+		if err := twofactor.VerifyCode(handlerCtx, u, payload.Code, twoFactorRepository); err != nil {
+			return nil, err
+		}
+	}
+
+	deleteAt := time.Now().Add(GetGracePeriod(handlerCtx.Cfg))
+
+	if err := usersRepository.ScheduleDeletion(u.ID, deleteAt); err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %s requested the deletion of the account, scheduled to %s", u.Nick, deleteAt)
+
+	go emails.SendEmailAccountDeletionScheduled(handlerCtx, u, deleteAt)
+
+	return &DeletionRequest{DeleteAt: deleteAt}, nil
+}
+
+// CancelDeletion reactivates the account of the authenticated user, cancelling the scheduled deletion.
+func CancelDeletion(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository) error {
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return err
+	}
+
+	if err := IsPendingDeletion(u); err != nil {
+		return err
+	}
+
+	return usersRepository.CancelDeletion(u.ID)
+}
+
+// DeleteAccount deletes an user and everything that belongs to the user: the avatar, the data exports, the received questions and their attachments,
+// the blocks, the reports, the tokens, the identities, etc. See CASCADES.
+// The questions sent to other users are kept, anonymised, since they belong to the users that received them.
+// The role changes of the user are kept anonymised too, for the audit trail.
+// The user document is the last to be deleted, so a failed deletion is retried by the next run of the job.
+// A confirmation is emailed to the user once everything is deleted.
+func DeleteAccount(handlerCtx *configs.HandlersCtx, u *users.User, deletionsRepository *DeletionsRepository, usersRepository *users.UsersRepository) error {
+	if u.AvatarURL != "" && handlerCtx.Cfg.CDN.URI != "" && strings.HasPrefix(u.AvatarURL, handlerCtx.Cfg.CDN.URI) {
+		if err := users.DeleteUserAvatar(handlerCtx, strings.TrimPrefix(u.AvatarURL, handlerCtx.Cfg.CDN.URI)); err != nil {
+			return err
+		}
+	}
+
+	exportFiles, err := deletionsRepository.FindExportFiles(u.ID)
+
+	if err != nil {
+		return err
+	}
+
+	attachmentFiles, err := deletionsRepository.FindAttachmentFiles(u.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range append(exportFiles, attachmentFiles...) {
+		if _, err := toolkitS3.DeleteFile(handlerCtx.S3Client, handlerCtx.Cfg.S3.BucketName, fileName); err != nil {
+			return err
+		}
+	}
+
+	if err := deletionsRepository.DeleteReceivedQuestions(u.ID); err != nil {
+		return err
+	}
+
+	if err := deletionsRepository.AnonymiseSentQuestions(u.ID); err != nil {
+		return err
+	}
+
+	if err := deletionsRepository.AnonymiseRoleChanges(u.ID); err != nil {
+		return err
+	}
+
+	if err := deletionsRepository.DeleteReactions(u.ID); err != nil {
+		return err
+	}
+
+	if err := deletionsRepository.DeleteUserDocuments(u.ID); err != nil {
+		return err
+	}
+
+	if err := deletionsRepository.DeleteSignInAttempts(lockouts.NickKey(u.Nick)); err != nil {
+		return err
+	}
+
+	handlerCtx.Denylist.RevokeUserTokens(u.ID.Hex(), bans.TOKENS_MAX_EXPIRES_IN)
+
+	if err := usersRepository.Delete(u.ID); err != nil {
+		return err
+	}
+
+	log.Printf("User %s deleted", u.Nick)
+
+	return emails.SendEmailAccountDeleted(handlerCtx, u)
+}
+
+// DeleteScheduledAccounts deletes the accounts whose grace period ended.
+// Failures are logged and the account is retried by the next run.
+func DeleteScheduledAccounts(handlerCtx *configs.HandlersCtx, deletionsRepository *DeletionsRepository, usersRepository *users.UsersRepository) {
+	found, err := usersRepository.FindUsersToDelete()
+
+	if err != nil {
+		log.Printf("Error finding accounts to delete: %v", err)
+		return
+	}
+
+	for i := range *found {
+		u := &(*found)[i]
+
+		if err := DeleteAccount(handlerCtx, u, deletionsRepository, usersRepository); err != nil {
+			log.Printf("Error deleting account of user %s: %v", u.Nick, err)
+		}
+	}
+}
+
+// StartDeletionJob deletes the accounts whose grace period ended every JOB_INTERVAL, on the background.
+func StartDeletionJob(AppCtx *configs.AppCtx, deletionsRepository *DeletionsRepository, usersRepository *users.UsersRepository) {
+	handlerCtx := &configs.HandlersCtx{AppCtx: *AppCtx}
+
+	go func() {
+		DeleteScheduledAccounts(handlerCtx, deletionsRepository, usersRepository)
+
+		for range time.Tick(JOB_INTERVAL) {
+			DeleteScheduledAccounts(handlerCtx, deletionsRepository, usersRepository)
+		}
+	}()
+}
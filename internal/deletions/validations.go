@@ -0,0 +1,46 @@
+package deletions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// IsNotPendingDeletion returns error if the user already requested the deletion of the account.
+func IsNotPendingDeletion(u *users.User) error {
+	if u.IsPendingDeletion() {
+		return errors.New(pkgErrors.ACCOUNT_DELETION_ALREADY_REQUESTED)
+	}
+
+	return nil
+}
+
+// IsPendingDeletion returns error if the user did not request the deletion of the account, so there is nothing to reactivate.
+func IsPendingDeletion(u *users.User) error {
+	if !u.IsPendingDeletion() {
+		return errors.New(pkgErrors.ACCOUNT_DELETION_NOT_REQUESTED)
+	}
+
+	return nil
+}
+
+// IsPasswordCorrect checks if the given hash result is nil. If it is, it means that the
+// password is correct, and it returns nil. Otherwise, it returns an error.
+func IsPasswordCorrect(hashResult error) error {
+	if hashResult != nil {
+		return errors.New(pkgErrors.INCORRECT_PASSWORD)
+	}
+
+	return nil
+}
+
+// IsRecentSignIn returns error if the token of the user was issued more than REAUTHENTICATION_MAX_AGE ago.
+func IsRecentSignIn(signedInAt time.Time) error {
+	if time.Since(signedInAt) > REAUTHENTICATION_MAX_AGE {
+		return errors.New(pkgErrors.REAUTHENTICATION_REQUIRED)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package exports
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"html/template"
+	"io"
+)
+
+// indexTemplate is the human-readable summary of the archive, written as index.html.
+// The complete data is on the JSON files.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Quess data export - {{.Profile.Nick}}</title>
+</head>
+<body>
+<h1>{{.Profile.Name}} (@{{.Profile.Nick}})</h1>
+<p>Generated at {{.GeneratedAt.Format "2006-01-02 15:04:05 MST"}}.</p>
+<ul>
+<li><a href="profile.json">profile.json</a>: your account</li>
+<li><a href="questions-sent.json">questions-sent.json</a>: {{len .SentQuestions}} questions sent</li>
+<li><a href="questions-received.json">questions-received.json</a>: {{len .ReceivedQuestions}} questions received</li>
+<li><a href="blocks.json">blocks.json</a>: {{len .Blocks}} blocked users</li>
+<li><a href="reports.json">reports.json</a>: {{len .Reports}} reports sent</li>
+<li><a href="sessions.json">sessions.json</a>: {{len .Sessions}} active sessions</li>
+<li><a href="trusted-ips.json">trusted-ips.json</a>: {{len .TrustedIPs.IPs}} trusted IPs and {{len .TrustedIPs.Locations}} trusted locations</li>
+</ul>
+<h2>Questions received</h2>
+{{range .ReceivedQuestions}}
+<article>
+<p><strong>{{.Content}}</strong></p>
+{{if .Reply}}<p>{{.Reply}}</p>{{end}}
+<small>{{.CreatedAt.Format "2006-01-02 15:04"}}{{if .IsAnonymous}} - anonymous{{end}}</small>
+</article>
+{{else}}
+<p>No questions received.</p>
+{{end}}
+<h2>Questions sent</h2>
+{{range .SentQuestions}}
+<article>
+<p><strong>{{.Content}}</strong></p>
+{{if .Reply}}<p>{{.Reply}}</p>{{end}}
+<small>{{.CreatedAt.Format "2006-01-02 15:04"}}{{if .IsAnonymous}} - anonymous{{end}}</small>
+</article>
+{{else}}
+<p>No questions sent.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// WriteArchive writes the archive as a ZIP with a JSON file for each kind of data and an index.html summary.
+func WriteArchive(w io.Writer, archive *Archive) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", archive.Profile},
+		{"questions-sent.json", archive.SentQuestions},
+		{"questions-received.json", archive.ReceivedQuestions},
+		{"blocks.json", archive.Blocks},
+		{"reports.json", archive.Reports},
+		{"sessions.json", archive.Sessions},
+		{"trusted-ips.json", archive.TrustedIPs},
+	}
+
+	for _, file := range files {
+		f, err := zw.Create(file.name)
+
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("index.html")
+
+	if err != nil {
+		return err
+	}
+
+	if err := indexTemplate.Execute(f, archive); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,82 @@
+package exports
+
+import (
+	"time"
+
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/core-go/internal/reports"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// Statuses of the exports.
+const (
+	STATUS_PENDING    = "pending"
+	STATUS_PROCESSING = "processing"
+	STATUS_READY      = "ready"
+	STATUS_FAILED     = "failed"
+	// STATUS_EXPIRED is set once the file is deleted from the storage.
+	STATUS_EXPIRED = "expired"
+)
+
+const (
+	// REQUEST_INTERVAL is how long an user must wait between export requests.
+	REQUEST_INTERVAL = time.Hour * 24
+	// DOWNLOAD_EXPIRES_IN is how long the download link and the file are kept. Signed S3 links can't last longer than 7 days.
+	DOWNLOAD_EXPIRES_IN = time.Hour * 24 * 7
+	// JOB_INTERVAL is how often pending exports are processed and expired files are deleted.
+	JOB_INTERVAL = time.Minute
+	// PROCESSING_TIMEOUT is how long an export can be processing before another run takes it over,
+	// like when the server stops in the middle of an export.
+	PROCESSING_TIMEOUT = time.Minute * 30
+	// FILES_DIRECTORY is the directory of the storage where the exports are stored.
+	FILES_DIRECTORY = "exports"
+)
+
+// Export is a request of an user for a copy of their data.
+type Export struct {
+	ID     toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID toolkitEntities.ID `json:"-" bson:"userId"`
+	Status string             `json:"status" bson:"status"`
+	// FileName is the key of the ZIP file on the storage, set once it is ready.
+	FileName    string     `json:"-" bson:"fileName,omitempty"`
+	StartedAt   *time.Time `json:"-" bson:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty" bson:"completedAt,omitempty"`
+	// ExpiresAt is when the download link stops working and the file is deleted.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
+}
+
+// Session is a session of an user, without the tokens.
+type Session struct {
+	ID        toolkitEntities.ID `json:"id" bson:"_id"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt"`
+}
+
+// Block is an user blocked by the user of the export.
+type Block struct {
+	ID toolkitEntities.ID `json:"id"`
+	// User is the blocked user, nil if the user deleted the account.
+	User *users.User `json:"user"`
+}
+
+// TrustedIPs are the trusted IPs of an user: the legacy list and the trusted locations.
+type TrustedIPs struct {
+	IPs       []string                           `json:"ips"`
+	Locations []trustedlocations.TrustedLocation `json:"locations"`
+}
+
+// Archive is the data of an user included in an export.
+// The senders of anonymous questions received by the user are never included.
+type Archive struct {
+	Profile           *users.User          `json:"profile"`
+	SentQuestions     []questions.Question `json:"sentQuestions"`
+	ReceivedQuestions []questions.Question `json:"receivedQuestions"`
+	Blocks            []Block              `json:"blocks"`
+	Reports           []reports.Report     `json:"reports"`
+	Sessions          []Session            `json:"sessions"`
+	TrustedIPs        TrustedIPs           `json:"trustedIps"`
+	GeneratedAt       time.Time            `json:"generatedAt"`
+}
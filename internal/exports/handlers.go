@@ -0,0 +1,36 @@
+package exports
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// RequestExportHandler requests a copy of the data of the authenticated user.
+func RequestExportHandler(handlerCtx *configs.HandlersCtx, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	export, err := RequestExport(handlerCtx, authenticatedUserID, exportsRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusAccepted, export)
+}
+
+// GetExportsHandler gets the exports requested by the authenticated user.
+func GetExportsHandler(handlerCtx *configs.HandlersCtx, exportsRepository *ExportsRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	exports, err := GetExports(handlerCtx, authenticatedUserID, exportsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, exports)
+}
@@ -0,0 +1,229 @@
+package exports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quessapp/core-go/internal/blocks"
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/core-go/internal/reports"
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExportsRepository represents the exports repository.
+// It also reads the data of the users across the collections of the other features.
+type ExportsRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns the exports repository.
+func NewRepository(db *mongo.Database) *ExportsRepository {
+	return &ExportsRepository{db}
+}
+
+// Create inserts a new export.
+func (e *ExportsRepository) Create(export *Export) error {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	_, err := coll.InsertOne(context.Background(), export)
+
+	return err
+}
+
+// FindUserExports finds the exports of an user, the newest first.
+func (e *ExportsRepository) FindUserExports(userID toolkitEntities.ID) (*[]Export, error) {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	found := []Export{}
+
+	if err := find(coll, bson.D{{Key: "userId", Value: userID}}, &found, opts); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindLastUserExport finds the newest export of an user.
+func (e *ExportsRepository) FindLastUserExport(userID toolkitEntities.ID) *Export {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	var foundExport Export
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	coll.FindOne(context.Background(), bson.D{{Key: "userId", Value: userID}}, opts).Decode(&foundExport)
+
+	return &foundExport
+}
+
+// ClaimPendingExport marks the oldest pending export as processing and returns it, so no other run processes it.
+// Exports processing for longer than PROCESSING_TIMEOUT are claimed again.
+// It returns an export with a zero ID if there is nothing to process.
+func (e *ExportsRepository) ClaimPendingExport() *Export {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	now := time.Now()
+
+	filter := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "status", Value: STATUS_PENDING}},
+			bson.D{
+				{Key: "status", Value: STATUS_PROCESSING},
+				{Key: "startedAt", Value: bson.D{{Key: "$lt", Value: now.Add(-PROCESSING_TIMEOUT)}}},
+			},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: STATUS_PROCESSING},
+		{Key: "startedAt", Value: now},
+	}}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetReturnDocument(options.After)
+
+	var claimedExport Export
+
+	coll.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&claimedExport)
+
+	return &claimedExport
+}
+
+// MarkReady marks an export as ready to be downloaded until expiresAt.
+func (e *ExportsRepository) MarkReady(id toolkitEntities.ID, fileName string, expiresAt time.Time) error {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: STATUS_READY},
+		{Key: "fileName", Value: fileName},
+		{Key: "completedAt", Value: time.Now()},
+		{Key: "expiresAt", Value: expiresAt},
+	}}}
+
+	_, err := coll.UpdateOne(context.Background(), bson.D{{Key: "_id", Value: id}}, update)
+
+	return err
+}
+
+// UpdateStatus sets the status of an export.
+func (e *ExportsRepository) UpdateStatus(id toolkitEntities.ID, status string) error {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: status}}}}
+
+	_, err := coll.UpdateOne(context.Background(), bson.D{{Key: "_id", Value: id}}, update)
+
+	return err
+}
+
+// FindExpiredExports finds the ready exports whose download link expired. Their files must be deleted.
+func (e *ExportsRepository) FindExpiredExports() (*[]Export, error) {
+	coll := e.db.Collection(pkgConstants.DATA_EXPORTS)
+
+	filter := bson.D{
+		{Key: "status", Value: STATUS_READY},
+		{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: time.Now()}}},
+	}
+
+	found := []Export{}
+
+	if err := find(coll, filter, &found); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindSentQuestions finds the questions sent by an user, the newest first.
+func (e *ExportsRepository) FindSentQuestions(userID toolkitEntities.ID) (*[]questions.Question, error) {
+	coll := e.db.Collection(toolkitConstants.QUESTIONS)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	found := []questions.Question{}
+
+	if err := find(coll, bson.D{{Key: "sentBy", Value: userID}}, &found, opts); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindReceivedQuestions finds the questions sent to an user, the newest first.
+func (e *ExportsRepository) FindReceivedQuestions(userID toolkitEntities.ID) (*[]questions.Question, error) {
+	coll := e.db.Collection(toolkitConstants.QUESTIONS)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	found := []questions.Question{}
+
+	if err := find(coll, bson.D{{Key: "sendTo", Value: userID}}, &found, opts); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindBlocks finds the users blocked by an user.
+func (e *ExportsRepository) FindBlocks(userID toolkitEntities.ID) (*[]blocks.BlockedUser, error) {
+	coll := e.db.Collection(toolkitConstants.BLOCKS)
+
+	found := []blocks.BlockedUser{}
+
+	if err := find(coll, bson.D{{Key: "blockedBy", Value: userID}}, &found); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindSentReports finds the reports sent by an user, the newest first.
+func (e *ExportsRepository) FindSentReports(userID toolkitEntities.ID) (*[]reports.Report, error) {
+	coll := e.db.Collection(toolkitConstants.REPORTS)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	found := []reports.Report{}
+
+	if err := find(coll, bson.D{{Key: "sentBy", Value: userID}}, &found, opts); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// FindSessions finds the sessions of an user that did not expire.
+func (e *ExportsRepository) FindSessions(userID toolkitEntities.ID) (*[]Session, error) {
+	coll := e.db.Collection(toolkitConstants.TOKENS)
+
+	filter := bson.D{
+		{Key: "createdBy", Value: userID},
+		{Key: "type", Value: "Bearer"},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	found := []Session{}
+
+	if err := find(coll, filter, &found, opts); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// find finds all the documents of a collection that match the filter and decodes them into results.
+func find(coll *mongo.Collection, filter bson.D, results any, opts ...*options.FindOptions) error {
+	cursor, err := coll.Find(context.Background(), filter, opts...)
+
+	if err != nil {
+		return err
+	}
+
+	return cursor.All(context.Background(), results)
+}
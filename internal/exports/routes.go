@@ -0,0 +1,23 @@
+package exports
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the personal data exports.
+// It takes in an AppCtx, an ExportsRepository and a UsersRepository.
+// The routes require the JWT of a signed in user, so a personal access token can't request a copy of the data.
+func LoadRoutes(AppCtx *configs.AppCtx, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository) {
+	g := AppCtx.App.Group("/users/me/exports", middlewares.JWTMiddleware(AppCtx))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetExportsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, exportsRepository)
+	})
+	g.Post("/", func(c *fiber.Ctx) error {
+		return RequestExportHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, exportsRepository, usersRepository)
+	})
+}
@@ -0,0 +1,265 @@
+package exports
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/queues/emails"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	toolkitS3 "github.com/quessapp/toolkit/s3"
+
+	"github.com/aws/aws-sdk-go/aws"
+	AWS_S3 "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// RequestExport requests a copy of the data of the authenticated user.
+// The export is prepared on the background by the export job, which emails a download link once it is ready.
+func RequestExport(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository) (*Export, error) {
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := CanRequestExport(exportsRepository.FindLastUserExport(u.ID)); err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		ID:        toolkitEntities.NewID(),
+		UserID:    u.ID,
+		Status:    STATUS_PENDING,
+		CreatedAt: time.Now(),
+	}
+
+	if err := exportsRepository.Create(export); err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %s requested a copy of the data", u.Nick)
+
+	return export, nil
+}
+
+// GetExports gets the exports requested by the authenticated user, the newest first.
+func GetExports(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, exportsRepository *ExportsRepository) (*[]Export, error) {
+	return exportsRepository.FindUserExports(authenticatedUserID)
+}
+
+// BuildArchive collects the data of an user.
+// The other users are identified by their basic infos, and the senders of anonymous questions received by the user are hidden.
+// Sessions are listed without their tokens.
+func BuildArchive(u *users.User, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*Archive, error) {
+	foundUsers := map[toolkitEntities.ID]*users.User{}
+
+	// findBasicInfos finds the basic infos of other users, or nil if they deleted their accounts.
+	findBasicInfos := func(id any) *users.User {
+		userID, ok := id.(toolkitEntities.ID)
+
+		if !ok {
+			return nil
+		}
+
+		if found, ok := foundUsers[userID]; ok {
+			return found
+		}
+
+		var basicInfos *users.User
+
+		if found := usersRepository.FindUserByID(userID); users.UserExists(found) == nil {
+			basicInfos = found.GetBasicInfos()
+		}
+
+		foundUsers[userID] = basicInfos
+
+		return basicInfos
+	}
+
+	sent, err := exportsRepository.FindSentQuestions(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range *sent {
+		(*sent)[i].SendTo = findBasicInfos((*sent)[i].SendTo)
+	}
+
+	received, err := exportsRepository.FindReceivedQuestions(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, q := range *received {
+		if !q.IsAnonymous {
+			q.SentBy = findBasicInfos(q.SentBy)
+		}
+
+		(*received)[i] = *q.MapAnonymousFields()
+	}
+
+	foundBlocks, err := exportsRepository.FindBlocks(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []Block{}
+
+	for _, b := range *foundBlocks {
+		blocks = append(blocks, Block{ID: b.ID, User: findBasicInfos(b.UserToBlock)})
+	}
+
+	reports, err := exportsRepository.FindSentReports(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := exportsRepository.FindSessions(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := trustedLocationsRepository.FindUserLocations(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trustedIPs := TrustedIPs{IPs: u.TrustedIPs, Locations: *locations}
+
+	if trustedIPs.IPs == nil {
+		trustedIPs.IPs = []string{}
+	}
+
+	return &Archive{
+		Profile:           u,
+		SentQuestions:     *sent,
+		ReceivedQuestions: *received,
+		Blocks:            blocks,
+		Reports:           *reports,
+		Sessions:          *sessions,
+		TrustedIPs:        trustedIPs,
+		GeneratedAt:       time.Now(),
+	}, nil
+}
+
+// GetFileName returns the key of the ZIP file of an export on the storage.
+func GetFileName(export *Export) string {
+	return fmt.Sprintf("%s/%s/%s.zip", FILES_DIRECTORY, export.UserID.Hex(), export.ID.Hex())
+}
+
+// getDownloadURL returns a signed link to download a file of the storage, which expires after DOWNLOAD_EXPIRES_IN.
+func getDownloadURL(handlerCtx *configs.HandlersCtx, fileName string) (string, error) {
+	req, _ := handlerCtx.S3Client.GetObjectRequest(&AWS_S3.GetObjectInput{
+		Bucket: aws.String(handlerCtx.Cfg.S3.BucketName),
+		Key:    aws.String(fileName),
+	})
+
+	return req.Presign(DOWNLOAD_EXPIRES_IN)
+}
+
+// ProcessExport prepares an export: it collects the data of the user, uploads the ZIP to the storage
+// as a private file and emails a signed download link to the user.
+// The export is marked as failed if the user no longer exists.
+func ProcessExport(handlerCtx *configs.HandlersCtx, export *Export, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
+	u := usersRepository.FindUserByID(export.UserID)
+
+	if err := users.UserExists(u); err != nil {
+		exportsRepository.UpdateStatus(export.ID, STATUS_FAILED)
+		return err
+	}
+
+	archive, err := BuildArchive(u, exportsRepository, usersRepository, trustedLocationsRepository)
+
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := WriteArchive(buf, archive); err != nil {
+		return err
+	}
+
+	fileName := GetFileName(export)
+
+	if _, err := toolkitS3.UploadFile(handlerCtx.S3Client, handlerCtx.Cfg.S3.BucketName, fileName, bytes.NewReader(buf.Bytes()), aws.String(AWS_S3.ObjectCannedACLPrivate)); err != nil {
+		return err
+	}
+
+	downloadURL, err := getDownloadURL(handlerCtx, fileName)
+
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(DOWNLOAD_EXPIRES_IN)
+
+	if err := exportsRepository.MarkReady(export.ID, fileName, expiresAt); err != nil {
+		return err
+	}
+
+	log.Printf("Export %s of user %s is ready", export.ID.Hex(), u.Nick)
+
+	return emails.SendEmailDataExportReady(handlerCtx, u, downloadURL, expiresAt)
+}
+
+// ProcessPendingExports prepares the pending exports, one at a time, until there are none left.
+// Failures are logged and the export is marked as failed, so the user can request it again.
+func ProcessPendingExports(handlerCtx *configs.HandlersCtx, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) {
+	for {
+		export := exportsRepository.ClaimPendingExport()
+
+		if toolkitEntities.IsZeroID(export.ID) {
+			return
+		}
+
+		if err := ProcessExport(handlerCtx, export, exportsRepository, usersRepository, trustedLocationsRepository); err != nil {
+			log.Printf("Error processing export %s: %v", export.ID.Hex(), err)
+			exportsRepository.UpdateStatus(export.ID, STATUS_FAILED)
+		}
+	}
+}
+
+// DeleteExpiredExports deletes the files of the exports whose download link expired.
+// Failures are logged and the file is retried by the next run.
+func DeleteExpiredExports(handlerCtx *configs.HandlersCtx, exportsRepository *ExportsRepository) {
+	expired, err := exportsRepository.FindExpiredExports()
+
+	if err != nil {
+		log.Printf("Error finding expired exports: %v", err)
+		return
+	}
+
+	for _, export := range *expired {
+		if _, err := toolkitS3.DeleteFile(handlerCtx.S3Client, handlerCtx.Cfg.S3.BucketName, export.FileName); err != nil {
+			log.Printf("Error deleting file of export %s: %v", export.ID.Hex(), err)
+			continue
+		}
+
+		if err := exportsRepository.UpdateStatus(export.ID, STATUS_EXPIRED); err != nil {
+			log.Printf("Error expiring export %s: %v", export.ID.Hex(), err)
+		}
+	}
+}
+
+// StartExportJob prepares the pending exports and deletes the expired ones every JOB_INTERVAL, on the background.
+func StartExportJob(AppCtx *configs.AppCtx, exportsRepository *ExportsRepository, usersRepository *users.UsersRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) {
+	handlerCtx := &configs.HandlersCtx{AppCtx: *AppCtx}
+
+	go func() {
+		for range time.Tick(JOB_INTERVAL) {
+			ProcessPendingExports(handlerCtx, exportsRepository, usersRepository, trustedLocationsRepository)
+			DeleteExpiredExports(handlerCtx, exportsRepository)
+		}
+	}()
+}
@@ -0,0 +1,27 @@
+package exports
+
+import (
+	"errors"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// CanRequestExport returns error if the last export of the user is still being prepared,
+// or if it was requested less than REQUEST_INTERVAL ago. Failed exports can be requested again right away.
+func CanRequestExport(lastExport *Export) error {
+	if toolkitEntities.IsZeroID(lastExport.ID) {
+		return nil
+	}
+
+	if lastExport.Status == STATUS_PENDING || lastExport.Status == STATUS_PROCESSING {
+		return errors.New(pkgErrors.DATA_EXPORT_IN_PROGRESS)
+	}
+
+	if lastExport.Status != STATUS_FAILED && time.Since(lastExport.CreatedAt) < REQUEST_INTERVAL {
+		return errors.New(pkgErrors.DATA_EXPORT_REQUESTED_RECENTLY)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/quessapp/core-go/internal/auth"
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/pagination"
 	"github.com/quessapp/core-go/tests/mocks"
 )
 
@@ -49,7 +50,7 @@ func Run(handlerCtx *configs.HandlersCtx, authRepository *auth.AuthRepository, q
 
 	fakeQuestion := mocks.NewQuestionMock()
 
-	if err = questionsRepository.Create(&questions.CreateQuestionDTO{
+	if _, err = questionsRepository.Create(&questions.CreateQuestionDTO{
 		Content:     fakeQuestion.Content,
 		SendTo:      secondUser.ID,
 		SentBy:      firstUser.ID,
@@ -59,11 +60,15 @@ func Run(handlerCtx *configs.HandlersCtx, authRepository *auth.AuthRepository, q
 		return err
 	}
 
-	var page int64 = 1
-	var sort string = "desc"
 	var filter string = "sent"
 
-	q, err := questionsRepository.GetAll(&page, &sort, &filter, firstUser.ID)
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("questions:"+filter, firstUser.ID.Hex()), "", "", pagination.SORT_DESC, "")
+
+	if err != nil {
+		return err
+	}
+
+	q, err := questionsRepository.GetAll(params, &filter, firstUser.ID)
 
 	if err != nil {
 		log.Fatalf("Error when listing questions created by user %s for health check: %s \n", firstUser.ID, err)
@@ -0,0 +1,29 @@
+package identities
+
+import (
+	"github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// CallbackDTO is DTO for payload for callback handlers.
+// Code and State are the query parameters the provider added to the redirect URL.
+type CallbackDTO struct {
+	Code  string
+	State string
+	// If true, the IP will be trusted after the sign-in. It is ignored on links.
+	TrustIP bool
+}
+
+// Validate is a method of CallbackDTO that validates the fields of the struct.
+// The Code and State fields are required.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d CallbackDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Code, validation.Required.Error(errors.CODE_REQUIRED)),
+		validation.Field(&d.State, validation.Required.Error(errors.OIDC_STATE_REQUIRED)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
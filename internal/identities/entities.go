@@ -0,0 +1,46 @@
+package identities
+
+import (
+	"time"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// STATE_EXPIRES_IN is how long the user has to complete the sign-in on the provider.
+const STATE_EXPIRES_IN = time.Minute * 10
+
+// Identity is a model for an OpenID Connect provider account linked to an user.
+type Identity struct {
+	ID       toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID   toolkitEntities.ID `json:"-" bson:"userId"`
+	Provider string             `json:"provider" bson:"provider"`
+	// Subject is the "sub" claim, the identifier of the account on the provider.
+	Subject string `json:"-" bson:"subject"`
+	// Email is the email of the provider account when it was linked. It is only informative.
+	Email     string     `json:"email,omitempty" bson:"email"`
+	CreatedAt *time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// State is a model for a pending authorization request. It is consumed on the callback.
+type State struct {
+	ID toolkitEntities.ID `bson:"_id"`
+	// State is a SHA-256 hash of the state sent to the provider.
+	State        string `bson:"state"`
+	Nonce        string `bson:"nonce"`
+	CodeVerifier string `bson:"codeVerifier"`
+	Provider     string `bson:"provider"`
+	// LinkTo is the user that started the request to link an identity. It is nil for sign-in requests.
+	LinkTo    *toolkitEntities.ID `bson:"linkTo"`
+	ExpiresAt time.Time           `bson:"expiresAt"`
+}
+
+// Authorization is a model for the data returned when an user starts a sign-in or a link.
+type Authorization struct {
+	// URL is the provider authorization URL the user must be redirected to.
+	URL string `json:"authorizationUrl"`
+}
+
+// Providers is a model for the configured providers.
+type Providers struct {
+	Providers []string `json:"providers"`
+}
@@ -0,0 +1,101 @@
+package identities
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/auth"
+	"github.com/quessapp/core-go/internal/bans"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/core-go/pkg/oidc"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// GetProvidersHandler returns the names of the configured sign-in providers.
+func GetProvidersHandler(handlerCtx *configs.HandlersCtx, registry *oidc.Registry) error {
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, GetProviders(handlerCtx, registry))
+}
+
+// AuthorizeHandler starts a social sign-in and returns the provider authorization URL the user must be redirected to.
+func AuthorizeHandler(handlerCtx *configs.HandlersCtx, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) error {
+	authorization, err := Authorize(handlerCtx, handlerCtx.C.Params("provider"), nil, registry, identitiesRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, authorization)
+}
+
+// SignInHandler completes a social sign-in. It parses the request body into a CallbackDTO,
+// with the code and state the provider sent to the redirect URL, and returns a JSON response with the authenticated user data.
+func SignInHandler(handlerCtx *configs.HandlersCtx, registry *oidc.Registry, identitiesRepository *IdentitiesRepository, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) error {
+	payload := CallbackDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	u, err := SignIn(handlerCtx, handlerCtx.C.Params("provider"), &payload, registry, identitiesRepository, authRepository, usersRepository, bansRepository, trustedLocationsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, u)
+}
+
+// GetIdentitiesHandler returns the identities linked to the authenticated user.
+func GetIdentitiesHandler(handlerCtx *configs.HandlersCtx, identitiesRepository *IdentitiesRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	identities, err := GetIdentities(handlerCtx, authenticatedUserID, identitiesRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, identities)
+}
+
+// AuthorizeLinkHandler starts a link of a provider account to the authenticated user and returns the provider authorization URL.
+func AuthorizeLinkHandler(handlerCtx *configs.HandlersCtx, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	authorization, err := Authorize(handlerCtx, handlerCtx.C.Params("provider"), &authenticatedUserID, registry, identitiesRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, authorization)
+}
+
+// LinkHandler completes a link of a provider account to the authenticated user.
+func LinkHandler(handlerCtx *configs.HandlersCtx, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+	payload := CallbackDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	if err := Link(handlerCtx, handlerCtx.C.Params("provider"), &payload, authenticatedUserID, registry, identitiesRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
+}
+
+// UnlinkHandler unlinks the identity of a provider from the authenticated user.
+func UnlinkHandler(handlerCtx *configs.HandlersCtx, identitiesRepository *IdentitiesRepository, usersRepository *users.UsersRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := Unlink(handlerCtx, handlerCtx.C.Params("provider"), authenticatedUserID, identitiesRepository, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
@@ -0,0 +1,182 @@
+package identities
+
+import (
+	"context"
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IdentitiesRepository represents identities repository.
+type IdentitiesRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns identities repository.
+func NewRepository(db *mongo.Database) *IdentitiesRepository {
+	return &IdentitiesRepository{db}
+}
+
+// CreateState stores a pending authorization request.
+func (i *IdentitiesRepository) CreateState(state *State) error {
+	coll := i.db.Collection(pkgConstants.OIDC_STATES)
+
+	state.ID = toolkitEntities.NewID()
+
+	_, err := coll.InsertOne(context.Background(), state)
+
+	return err
+}
+
+// ConsumeState finds and deletes a pending authorization request by its hashed state and provider.
+// The find and the delete are a single operation, so a state can't be used twice. Expired states are not returned.
+func (i *IdentitiesRepository) ConsumeState(hashedState, provider string) *State {
+	coll := i.db.Collection(pkgConstants.OIDC_STATES)
+
+	filter := bson.D{
+		{Key: "state", Value: hashedState},
+		{Key: "provider", Value: provider},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	var foundState State
+
+	coll.FindOneAndDelete(context.Background(), filter).Decode(&foundState)
+
+	return &foundState
+}
+
+// FindIdentity finds the identity of a provider account.
+func (i *IdentitiesRepository) FindIdentity(provider, subject string) *Identity {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	filter := bson.D{
+		{Key: "provider", Value: provider},
+		{Key: "subject", Value: subject},
+	}
+
+	var foundIdentity Identity
+
+	coll.FindOne(context.Background(), filter).Decode(&foundIdentity)
+
+	return &foundIdentity
+}
+
+// FindUserIdentity finds the identity of the given provider linked to an user.
+func (i *IdentitiesRepository) FindUserIdentity(userID toolkitEntities.ID, provider string) *Identity {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "provider", Value: provider},
+	}
+
+	var foundIdentity Identity
+
+	coll.FindOne(context.Background(), filter).Decode(&foundIdentity)
+
+	return &foundIdentity
+}
+
+// FindUserIdentities finds all identities linked to an user.
+func (i *IdentitiesRepository) FindUserIdentities(userID toolkitEntities.ID) (*[]Identity, error) {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	filter := bson.D{{Key: "userId", Value: userID}}
+
+	cursor, err := coll.Find(context.Background(), filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	identities := []Identity{}
+
+	if err := cursor.All(context.Background(), &identities); err != nil {
+		return nil, err
+	}
+
+	return &identities, nil
+}
+
+// CountUserIdentities counts the identities linked to an user.
+func (i *IdentitiesRepository) CountUserIdentities(userID toolkitEntities.ID) int64 {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	filter := bson.D{{Key: "userId", Value: userID}}
+
+	count, _ := coll.CountDocuments(context.Background(), filter)
+
+	return count
+}
+
+// CreateIndexes creates the unique indexes of the identities, so a provider account is linked to a single user
+// and an user has a single account of each provider. It does nothing if the indexes already exist.
+func (i *IdentitiesRepository) CreateIndexes() error {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "provider", Value: 1},
+				{Key: "subject", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "provider", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err := coll.Indexes().CreateMany(context.Background(), indexes)
+
+	return err
+}
+
+// Link links a provider account to an user.
+// It returns false if the provider account or another account of the provider of the user was linked first, by a concurrent request for example.
+func (i *IdentitiesRepository) Link(userID toolkitEntities.ID, provider, subject, email string) (bool, error) {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	now := time.Now()
+
+	identity := Identity{
+		ID:        toolkitEntities.NewID(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: &now,
+	}
+
+	_, err := coll.InsertOne(context.Background(), identity)
+
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Unlink deletes the identity of the given provider linked to an user.
+func (i *IdentitiesRepository) Unlink(userID toolkitEntities.ID, provider string) error {
+	coll := i.db.Collection(pkgConstants.IDENTITIES)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "provider", Value: provider},
+	}
+
+	_, err := coll.DeleteOne(context.Background(), filter)
+
+	return err
+}
@@ -0,0 +1,44 @@
+package identities
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/auth"
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/middlewares"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/oidc"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the social sign-in and the linked identities APIs.
+// Sign-in routes are public, identities routes require authentication.
+func LoadRoutes(AppCtx *configs.AppCtx, registry *oidc.Registry, identitiesRepository *IdentitiesRepository, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) {
+	signIn := AppCtx.App.Group("/auth/oidc")
+
+	signIn.Get("/providers", func(c *fiber.Ctx) error {
+		return GetProvidersHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, registry)
+	})
+	signIn.Get("/:provider/authorize", func(c *fiber.Ctx) error {
+		return AuthorizeHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, registry, identitiesRepository)
+	})
+	signIn.Post("/:provider/callback", func(c *fiber.Ctx) error {
+		return SignInHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, registry, identitiesRepository, authRepository, usersRepository, bansRepository, trustedLocationsRepository)
+	})
+
+	g := AppCtx.App.Group("/identities", middlewares.JWTMiddleware(AppCtx))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetIdentitiesHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, identitiesRepository)
+	})
+	g.Get("/:provider/authorize", func(c *fiber.Ctx) error {
+		return AuthorizeLinkHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, registry, identitiesRepository)
+	})
+	g.Post("/:provider/callback", func(c *fiber.Ctx) error {
+		return LinkHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, registry, identitiesRepository)
+	})
+	g.Delete("/:provider", func(c *fiber.Ctx) error {
+		return UnlinkHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, identitiesRepository, usersRepository)
+	})
+}
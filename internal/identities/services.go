@@ -0,0 +1,348 @@
+package identities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/auth"
+	"github.com/quessapp/core-go/internal/bans"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/oidc"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/regexes"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// NICK_MAX_ATTEMPTS is how many nicks are tried when creating an account for a new identity.
+	NICK_MAX_ATTEMPTS = 5
+	// DEFAULT_LOCALE is used for new accounts when the request has no supported Accept-Language.
+	DEFAULT_LOCALE = "en-US"
+)
+
+// hashState hashes the state before storing it, so a database leak does not expose pending requests.
+func hashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// getProvider returns the provider with the given name from the registry.
+func getProvider(providerName string, registry *oidc.Registry) (*oidc.Provider, error) {
+	provider, err := registry.Get(providerName)
+
+	if err != nil {
+		return nil, errors.New(pkgErrors.OIDC_PROVIDER_NOT_FOUND)
+	}
+
+	return provider, nil
+}
+
+// GetProviders returns the names of the configured providers.
+func GetProviders(handlerCtx *configs.HandlersCtx, registry *oidc.Registry) *Providers {
+	return &Providers{Providers: registry.Names()}
+}
+
+// Authorize starts the authorization code flow with PKCE. It stores the state, nonce and code verifier,
+// and returns the provider authorization URL. If linkTo is not nil, the flow links the identity to that user instead of signing in.
+func Authorize(handlerCtx *configs.HandlersCtx, providerName string, linkTo *toolkitEntities.ID, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) (*Authorization, error) {
+	provider, err := getProvider(providerName, registry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := oidc.GenerateRandomValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := oidc.GenerateRandomValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, err := oidc.GenerateRandomValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	URL, err := provider.AuthCodeURL(state, nonce, oidc.CodeChallenge(codeVerifier))
+
+	if err != nil {
+		log.Printf("fail to build authorization URL of provider %s: %s", providerName, err)
+		return nil, errors.New(pkgErrors.OIDC_AUTHENTICATION_FAILED)
+	}
+
+	if err := identitiesRepository.CreateState(&State{
+		State:        hashState(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Provider:     providerName,
+		LinkTo:       linkTo,
+		ExpiresAt:    time.Now().Add(STATE_EXPIRES_IN),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Authorization{URL: URL}, nil
+}
+
+// authenticate consumes the state, exchanges the code and verifies the ID token.
+// The state must belong to the same flow: linkTo must be the user that started a link, or nil for sign-in.
+func authenticate(payload *CallbackDTO, providerName string, linkTo *toolkitEntities.ID, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) (*oidc.IDTokenClaims, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	provider, err := getProvider(providerName, registry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := identitiesRepository.ConsumeState(hashState(payload.State), providerName)
+
+	if err := StateExists(s); err != nil {
+		return nil, err
+	}
+
+	isSameFlow := (s.LinkTo == nil && linkTo == nil) || (s.LinkTo != nil && linkTo != nil && *s.LinkTo == *linkTo)
+
+	if !isSameFlow {
+		return nil, errors.New(pkgErrors.OIDC_STATE_INVALID)
+	}
+
+	tokens, err := provider.Exchange(payload.Code, s.CodeVerifier)
+
+	if err != nil {
+		log.Printf("fail to exchange code with provider %s: %s", providerName, err)
+		return nil, errors.New(pkgErrors.OIDC_AUTHENTICATION_FAILED)
+	}
+
+	claims, err := provider.VerifyIDToken(tokens.IDToken, s.Nonce)
+
+	if err != nil {
+		log.Printf("fail to verify id token of provider %s: %s", providerName, err)
+		return nil, errors.New(pkgErrors.OIDC_AUTHENTICATION_FAILED)
+	}
+
+	return claims, nil
+}
+
+// SignIn completes a sign-in started by Authorize. If the identity is linked, the user is signed in with auth.AuthenticateUser.
+// Otherwise a new account is created, as long as the provider verified the email and no account uses it yet.
+func SignIn(handlerCtx *configs.HandlersCtx, providerName string, payload *CallbackDTO, registry *oidc.Registry, identitiesRepository *IdentitiesRepository, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository, trustedLocationsRepository *trustedlocations.TrustedLocationsRepository) (*users.ResponseWithUser, error) {
+	claims, err := authenticate(payload, providerName, nil, registry, identitiesRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity := identitiesRepository.FindIdentity(providerName, claims.Subject)
+
+	isLinked := !toolkitEntities.IsZeroID(identity.ID)
+
+	if isLinked {
+		u := usersRepository.FindUserByID(identity.UserID)
+
+		if err := users.UserExists(u); err != nil {
+			return nil, err
+		}
+
+		return auth.AuthenticateUser(handlerCtx, u, payload.TrustIP, authRepository, bansRepository, trustedLocationsRepository)
+	}
+
+	if err := IsEmailVerified(claims.Email, claims.EmailVerified); err != nil {
+		return nil, err
+	}
+
+	if err := IsLinkedToExistingAccount(usersRepository.IsEmailInUse(claims.Email)); err != nil {
+		return nil, err
+	}
+
+	u, err := createUser(handlerCtx, claims, authRepository, usersRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := identitiesRepository.Link(u.ID, providerName, claims.Subject, claims.Email)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// a concurrent sign-in linked the provider account to another new account first
+	if err := WasLinked(linked); err != nil {
+		if err := usersRepository.Delete(u.ID); err != nil {
+			log.Printf("Error deleting user %v created by a concurrent sign-in: %v", u.ID, err)
+		}
+
+		return nil, err
+	}
+
+	return auth.AuthenticateUser(handlerCtx, u, payload.TrustIP, authRepository, bansRepository, trustedLocationsRepository)
+}
+
+// createUser creates the account of a new identity. The account has a random password that is never returned,
+// so the user is flagged with PasswordNotSet until a password is defined on reset-password.
+// The email is already verified by the provider.
+func createUser(handlerCtx *configs.HandlersCtx, claims *oidc.IDTokenClaims, authRepository *auth.AuthRepository, usersRepository *users.UsersRepository) (*users.User, error) {
+	nick, err := generateNick(claims, handlerCtx.Nicks, usersRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := oidc.GenerateRandomValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(claims.Name)
+
+	if len(name) < 3 || len(name) > 50 {
+		name = nick
+	}
+
+	u, err := authRepository.SignUp(&auth.SignUpUserDTO{
+		Email:    claims.Email,
+		Password: string(hashedPassword),
+		Nick:     nick,
+		Name:     name,
+		Locale:   getLocale(handlerCtx),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usersRepository.VerifyEmail(u.ID, claims.Email); err != nil {
+		return nil, err
+	}
+
+	if err := usersRepository.SetPasswordNotSet(u.ID); err != nil {
+		return nil, err
+	}
+
+	u.IsVerified = true
+	u.PasswordNotSet = true
+
+	return u, nil
+}
+
+// generateNick generates an unused nick from the preferred username or the email of the provider account.
+// A random suffix is added when the nick is already in use, and "user" is used when the nick is not allowed by the nicks policy.
+func generateNick(claims *oidc.IDTokenClaims, nicksPolicy *nicks.Policy, usersRepository *users.UsersRepository) (string, error) {
+	u := users.User{Nick: claims.PreferredUsername}
+
+	if u.Nick == "" {
+		u.Nick = strings.Split(claims.Email, "@")[0]
+	}
+
+	u.Format()
+
+	if runes := []rune(u.Nick); len(runes) > 40 {
+		u.Nick = string(runes[:40])
+	}
+
+	if utf8.RuneCountInString(u.Nick) < 3 || nicksPolicy.Validate(u.Nick) != nil {
+		u.Nick = "user"
+	}
+
+	nick := u.Nick
+
+	for i := 0; i < NICK_MAX_ATTEMPTS; i++ {
+		if !usersRepository.IsNickInUse(nick, toolkitEntities.ID{}) {
+			return nick, nil
+		}
+
+		nick = fmt.Sprintf("%s%d", u.Nick, rand.Intn(100000))
+	}
+
+	return "", errors.New(pkgErrors.NICK_IN_USE)
+}
+
+// getLocale returns the first supported locale of the Accept-Language header, or DEFAULT_LOCALE.
+func getLocale(handlerCtx *configs.HandlersCtx) string {
+	locale := regexp.MustCompile(regexes.LOCALES).FindString(handlerCtx.C.Get("Accept-Language"))
+
+	if locale == "" {
+		return DEFAULT_LOCALE
+	}
+
+	return locale
+}
+
+// Link completes a link started by Authorize, linking the provider account to the authenticated user.
+func Link(handlerCtx *configs.HandlersCtx, providerName string, payload *CallbackDTO, authenticatedUserID toolkitEntities.ID, registry *oidc.Registry, identitiesRepository *IdentitiesRepository) error {
+	claims, err := authenticate(payload, providerName, &authenticatedUserID, registry, identitiesRepository)
+
+	if err != nil {
+		return err
+	}
+
+	identity := identitiesRepository.FindIdentity(providerName, claims.Subject)
+	userIdentity := identitiesRepository.FindUserIdentity(authenticatedUserID, providerName)
+
+	if err := CanLink(identity, userIdentity, authenticatedUserID); err != nil {
+		return err
+	}
+
+	linked, err := identitiesRepository.Link(authenticatedUserID, providerName, claims.Subject, claims.Email)
+
+	if err != nil {
+		return err
+	}
+
+	return WasLinked(linked)
+}
+
+// GetIdentities returns the identities linked to the authenticated user.
+func GetIdentities(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, identitiesRepository *IdentitiesRepository) (*[]Identity, error) {
+	return identitiesRepository.FindUserIdentities(authenticatedUserID)
+}
+
+// Unlink unlinks the identity of the given provider from the authenticated user.
+// It is not allowed when the identity is the only way the user can sign in.
+func Unlink(handlerCtx *configs.HandlersCtx, providerName string, authenticatedUserID toolkitEntities.ID, identitiesRepository *IdentitiesRepository, usersRepository *users.UsersRepository) error {
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return err
+	}
+
+	if err := IdentityExists(identitiesRepository.FindUserIdentity(authenticatedUserID, providerName)); err != nil {
+		return err
+	}
+
+	if err := CanUnlink(u.PasswordNotSet, identitiesRepository.CountUserIdentities(authenticatedUserID)); err != nil {
+		return err
+	}
+
+	return identitiesRepository.Unlink(authenticatedUserID, providerName)
+}
@@ -0,0 +1,78 @@
+package identities
+
+import (
+	"errors"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// StateExists returns error if the state was not found, already used, expired or belongs to another flow.
+func StateExists(s *State) error {
+	if toolkitEntities.IsZeroID(s.ID) {
+		return errors.New(pkgErrors.OIDC_STATE_INVALID)
+	}
+
+	return nil
+}
+
+// IdentityExists returns error if the identity was not found.
+func IdentityExists(i *Identity) error {
+	if toolkitEntities.IsZeroID(i.ID) {
+		return errors.New(pkgErrors.IDENTITY_NOT_FOUND)
+	}
+
+	return nil
+}
+
+// IsEmailVerified returns error if the provider did not verify the email of the account.
+func IsEmailVerified(email string, isVerified bool) error {
+	if email == "" || !isVerified {
+		return errors.New(pkgErrors.OIDC_EMAIL_NOT_VERIFIED)
+	}
+
+	return nil
+}
+
+// IsLinkedToExistingAccount returns error if an account with the provider email already exists.
+// Identities are never linked automatically by email, the user must sign in and link it.
+func IsLinkedToExistingAccount(isEmailInUse bool) error {
+	if isEmailInUse {
+		return errors.New(pkgErrors.IDENTITY_NOT_LINKED)
+	}
+
+	return nil
+}
+
+// CanLink returns error if the identity is already linked, to the user or to another user,
+// or if the user already has an identity of the same provider.
+func CanLink(i *Identity, userIdentity *Identity, userID toolkitEntities.ID) error {
+	if !toolkitEntities.IsZeroID(i.ID) && i.UserID != userID {
+		return errors.New(pkgErrors.IDENTITY_LINKED_TO_ANOTHER_USER)
+	}
+
+	if !toolkitEntities.IsZeroID(i.ID) || !toolkitEntities.IsZeroID(userIdentity.ID) {
+		return errors.New(pkgErrors.IDENTITY_ALREADY_LINKED)
+	}
+
+	return nil
+}
+
+// WasLinked returns error if the identity was not linked, since a concurrent request may have linked the provider account
+// or another account of the provider first.
+func WasLinked(linked bool) error {
+	if !linked {
+		return errors.New(pkgErrors.IDENTITY_ALREADY_LINKED)
+	}
+
+	return nil
+}
+
+// CanUnlink returns error if the identity is the only way the user can sign in.
+func CanUnlink(passwordNotSet bool, identitiesCount int64) error {
+	if passwordNotSet && identitiesCount <= 1 {
+		return errors.New(pkgErrors.CANT_UNLINK_LAST_SIGN_IN_METHOD)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package lockouts
+
+import (
+	"time"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// FAILURES_WINDOW is how long failures are remembered. The counter restarts when there is no failure in this window.
+const FAILURES_WINDOW = time.Hour * 24
+
+// Policy defines when a key is blocked after failed sign-in attempts.
+// After BackoffThreshold failures each new failure blocks the key for BaseDelay, doubling up to MaxDelay.
+// After LockoutThreshold failures the key is locked for LockoutDuration.
+type Policy struct {
+	BackoffThreshold int
+	LockoutThreshold int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutDuration  time.Duration
+}
+
+var (
+	// ACCOUNT_POLICY applies to the failures of a nick.
+	ACCOUNT_POLICY = Policy{
+		BackoffThreshold: 3,
+		LockoutThreshold: 10,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute * 5,
+		LockoutDuration:  time.Minute * 30,
+	}
+	// IP_POLICY applies to the failures of an IP, for all nicks. It is looser because many users can share an IP.
+	IP_POLICY = Policy{
+		BackoffThreshold: 20,
+		LockoutThreshold: 100,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute * 5,
+		LockoutDuration:  time.Hour,
+	}
+)
+
+// Attempts is a model for the failed sign-in attempts of a key, a nick or an IP.
+type Attempts struct {
+	ID            toolkitEntities.ID `json:"-" bson:"_id"`
+	Key           string             `json:"-" bson:"key"`
+	Failures      int                `json:"failures" bson:"failures"`
+	LastFailureAt *time.Time         `json:"lastFailureAt" bson:"lastFailureAt"`
+	ExpiresAt     *time.Time         `json:"-" bson:"expiresAt"`
+}
+
+// Status is a model for the lockout status of an account, returned to admins.
+type Status struct {
+	Failures     int        `json:"failures"`
+	IsLocked     bool       `json:"isLocked"`
+	BlockedUntil *time.Time `json:"blockedUntil,omitempty"`
+}
+
+// BlockDuration returns how long a key with the given number of failures is blocked after its last failure.
+func (p Policy) BlockDuration(failures int) time.Duration {
+	if failures >= p.LockoutThreshold {
+		return p.LockoutDuration
+	}
+
+	if failures < p.BackoffThreshold {
+		return 0
+	}
+
+	delay := p.BaseDelay
+
+	for i := p.BackoffThreshold; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
+
+// BlockedUntil returns until when the key is blocked, or nil if it is not blocked at the given time.
+func (a Attempts) BlockedUntil(p Policy, now time.Time) *time.Time {
+	if a.LastFailureAt == nil {
+		return nil
+	}
+
+	blockedUntil := a.LastFailureAt.Add(p.BlockDuration(a.Failures))
+
+	if !now.Before(blockedUntil) {
+		return nil
+	}
+
+	return &blockedUntil
+}
+
+// NickKey returns the key of the failures of a nick. Nicks are used instead of user IDs,
+// so unknown nicks are tracked exactly like existing accounts.
+func NickKey(nick string) string {
+	return "nick:" + nick
+}
+
+// IPKey returns the key of the failures of an IP.
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,43 @@
+package lockouts
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// GetStatusHandler returns the lockout status of the user with the given ID.
+func GetStatusHandler(handlerCtx *configs.HandlersCtx, lockoutsRepository *LockoutsRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	status, err := GetStatus(handlerCtx, id, lockoutsRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, status)
+}
+
+// UnlockHandler resets the failed sign-in attempts of the user with the given ID.
+func UnlockHandler(handlerCtx *configs.HandlersCtx, lockoutsRepository *LockoutsRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	if err := Unlock(handlerCtx, id, lockoutsRepository, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
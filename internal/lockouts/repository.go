@@ -0,0 +1,92 @@
+package lockouts
+
+import (
+	"context"
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LockoutsRepository represents lockouts repository.
+type LockoutsRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns lockouts repository.
+func NewRepository(db *mongo.Database) *LockoutsRepository {
+	return &LockoutsRepository{db}
+}
+
+// FindAttempts finds the failed attempts of a key. Expired attempts are not returned.
+func (l *LockoutsRepository) FindAttempts(key string) *Attempts {
+	coll := l.db.Collection(pkgConstants.SIGN_IN_ATTEMPTS)
+
+	filter := bson.D{
+		{Key: "key", Value: key},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	var foundAttempts Attempts
+
+	coll.FindOne(context.Background(), filter).Decode(&foundAttempts)
+
+	return &foundAttempts
+}
+
+// RegisterFailure increments the failures of a key and returns the updated attempts.
+// If the previous failures are expired, the counter restarts from one.
+func (l *LockoutsRepository) RegisterFailure(key string) (*Attempts, error) {
+	coll := l.db.Collection(pkgConstants.SIGN_IN_ATTEMPTS)
+
+	now := time.Now()
+	expiresAt := now.Add(FAILURES_WINDOW)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var attempts Attempts
+
+	filter := bson.D{
+		{Key: "key", Value: key},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "failures", Value: 1}}},
+		{Key: "$set", Value: bson.D{
+			{Key: "lastFailureAt", Value: now},
+			{Key: "expiresAt", Value: expiresAt},
+		}},
+	}
+
+	err := coll.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&attempts)
+
+	if err != mongo.ErrNoDocuments {
+		return &attempts, err
+	}
+
+	filter = bson.D{{Key: "key", Value: key}}
+	update = bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "failures", Value: 1},
+			{Key: "lastFailureAt", Value: now},
+			{Key: "expiresAt", Value: expiresAt},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "_id", Value: toolkitEntities.NewID()}}},
+	}
+
+	err = coll.FindOneAndUpdate(context.Background(), filter, update, opts.SetUpsert(true)).Decode(&attempts)
+
+	return &attempts, err
+}
+
+// Reset deletes the failed attempts of a key.
+func (l *LockoutsRepository) Reset(key string) error {
+	coll := l.db.Collection(pkgConstants.SIGN_IN_ATTEMPTS)
+
+	_, err := coll.DeleteMany(context.Background(), bson.D{{Key: "key", Value: key}})
+
+	return err
+}
@@ -0,0 +1,23 @@
+package lockouts
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the admin routes for the account lockouts.
+// It takes in an AppCtx, a LockoutsRepository, and a UsersRepository.
+// Only signed in users whose role grants users.PERMISSION_USERS_UNLOCK can use them.
+func LoadRoutes(AppCtx *configs.AppCtx, lockoutsRepository *LockoutsRepository, usersRepository *users.UsersRepository) {
+	g := AppCtx.App.Group("/admin/users/:id/lockout", middlewares.JWTMiddleware(AppCtx), middlewares.PolicyMiddleware(AppCtx, users.PERMISSION_USERS_UNLOCK))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetStatusHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, lockoutsRepository, usersRepository)
+	})
+	g.Delete("/", func(c *fiber.Ctx) error {
+		return UnlockHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, lockoutsRepository, usersRepository)
+	})
+}
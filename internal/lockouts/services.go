@@ -0,0 +1,82 @@
+package lockouts
+
+import (
+	"log"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/queues/emails"
+	"github.com/quessapp/core-go/internal/users"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// IsAllowed returns error if the nick or the IP is blocked by failed sign-in attempts.
+// Unknown nicks are tracked like existing ones, so the result does not tell if an account exists.
+func IsAllowed(nick, ip string, lockoutsRepository *LockoutsRepository) error {
+	now := time.Now()
+
+	if err := IsNotBlocked(lockoutsRepository.FindAttempts(NickKey(nick)).BlockedUntil(ACCOUNT_POLICY, now)); err != nil {
+		return err
+	}
+
+	return IsNotBlocked(lockoutsRepository.FindAttempts(IPKey(ip)).BlockedUntil(IP_POLICY, now))
+}
+
+// RegisterFailure registers a failed sign-in attempt for the nick and the IP.
+// When the nick reaches the lockout threshold and belongs to an user, the user is notified by email.
+func RegisterFailure(handlerCtx *configs.HandlersCtx, nick, ip string, u *users.User, lockoutsRepository *LockoutsRepository) {
+	attempts, err := lockoutsRepository.RegisterFailure(NickKey(nick))
+
+	if err != nil {
+		log.Printf("Error registering failed sign-in attempt for nick %s: %v", nick, err)
+	} else if attempts.Failures == ACCOUNT_POLICY.LockoutThreshold && !toolkitEntities.IsZeroID(u.ID) {
+		log.Printf("Account %s locked after %d failed sign-in attempts", u.Nick, attempts.Failures)
+
+		go emails.SendEmailAccountLocked(handlerCtx, u)
+	}
+
+	if _, err := lockoutsRepository.RegisterFailure(IPKey(ip)); err != nil {
+		log.Printf("Error registering failed sign-in attempt for IP %s: %v", ip, err)
+	}
+}
+
+// RegisterSuccess resets the failures of the nick after a successful sign-in.
+// The IP failures are kept, so signing in to an own account can't be used to keep spraying passwords.
+func RegisterSuccess(nick string, lockoutsRepository *LockoutsRepository) {
+	if err := lockoutsRepository.Reset(NickKey(nick)); err != nil {
+		log.Printf("Error resetting failed sign-in attempts for nick %s: %v", nick, err)
+	}
+}
+
+// GetStatus returns the lockout status of an user.
+func GetStatus(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, lockoutsRepository *LockoutsRepository, usersRepository *users.UsersRepository) (*Status, error) {
+	u := usersRepository.FindUserByID(userID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	attempts := lockoutsRepository.FindAttempts(NickKey(u.Nick))
+	blockedUntil := attempts.BlockedUntil(ACCOUNT_POLICY, time.Now())
+
+	status := &Status{
+		Failures:     attempts.Failures,
+		IsLocked:     blockedUntil != nil && attempts.Failures >= ACCOUNT_POLICY.LockoutThreshold,
+		BlockedUntil: blockedUntil,
+	}
+
+	return status, nil
+}
+
+// Unlock resets the failed sign-in attempts of an user, so the user can sign in right away.
+func Unlock(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, lockoutsRepository *LockoutsRepository, usersRepository *users.UsersRepository) error {
+	u := usersRepository.FindUserByID(userID)
+
+	if err := users.UserExists(u); err != nil {
+		return err
+	}
+
+	log.Printf("Unlocking account %s", u.Nick)
+
+	return lockoutsRepository.Reset(NickKey(u.Nick))
+}
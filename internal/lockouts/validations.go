@@ -0,0 +1,17 @@
+package lockouts
+
+import (
+	"errors"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// IsNotBlocked returns error if the key is blocked, see Attempts.BlockedUntil.
+func IsNotBlocked(blockedUntil *time.Time) error {
+	if blockedUntil != nil {
+		return errors.New(pkgErrors.TOO_MANY_SIGN_IN_ATTEMPTS)
+	}
+
+	return nil
+}
@@ -4,16 +4,43 @@ import (
 	"net/http"
 
 	"github.com/quessapp/core-go/configs"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/i18n"
 	"github.com/quessapp/toolkit/responses"
 
 	"github.com/gofiber/fiber/v2"
 	jwtware "github.com/gofiber/jwt/v3"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Types of the user JWTs, on the "typ" claim.
+const (
+	TOKEN_TYPE_ACCESS  = "access"
+	TOKEN_TYPE_REFRESH = "refresh"
 )
 
 // JWTMiddleware applies JWT middleware for specifics routes.
-func JWTMiddleware(app *fiber.App, cfg *configs.Conf) func(*fiber.Ctx) error {
+// Tokens are verified by the keyring of the app, see keyring.Keyring,
+// and checked against the denylist on every request, so revoked tokens are rejected before they expire.
+// Only access tokens are accepted: refresh tokens live much longer and are only meant to create new sessions.
+func JWTMiddleware(AppCtx *configs.AppCtx) func(*fiber.Ctx) error {
 	return jwtware.New(jwtware.Config{
-		SigningKey: []byte(cfg.JWT.Secret),
+		KeyFunc: AppCtx.Keyring.Keyfunc,
+		SuccessHandler: func(c *fiber.Ctx) error {
+			token, _ := c.Locals("user").(*jwt.Token)
+			claims, _ := token.Claims.(jwt.MapClaims)
+			handlerCtx := configs.HandlersCtx{C: c}
+
+			if tokenType, _ := claims["typ"].(string); tokenType != TOKEN_TYPE_ACCESS {
+				return responses.ParseUnsuccesfull(c, http.StatusUnauthorized, i18n.Translate(&handlerCtx, pkgErrors.ACCESS_TOKEN_REQUIRED))
+			}
+
+			if AppCtx.Denylist.IsClaimsRevoked(claims) {
+				return responses.ParseUnsuccesfull(c, http.StatusUnauthorized, i18n.Translate(&handlerCtx, pkgErrors.TOKEN_REVOKED))
+			}
+
+			return c.Next()
+		},
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			return responses.ParseUnsuccesfull(c, http.StatusForbidden, err.Error())
 		},
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/toolkit/responses"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// PolicyMiddleware protects routes that require permissions, like the admin routes.
+// It must run after JWTMiddleware, and it allows only users whose role grants all the given permissions, see users.ROLE_PERMISSIONS.
+// The role is read on every request, so role changes apply right away.
+func PolicyMiddleware(AppCtx *configs.AppCtx, permissions ...string) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		userID := ""
+
+		if token, ok := c.Locals("user").(*jwt.Token); ok && token.Valid {
+			claims, _ := token.Claims.(jwt.MapClaims)
+			userID, _ = claims["id"].(string)
+		}
+
+		if AppCtx.Policy == nil || userID == "" || !AppCtx.Policy.HasPermissions(userID, permissions...) {
+			handlerCtx := configs.HandlersCtx{C: c}
+
+			return responses.ParseUnsuccesfull(c, http.StatusForbidden, i18n.Translate(&handlerCtx, pkgErrors.ADMIN_NOT_AUTHORIZED))
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/quessapp/core-go/configs"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/toolkit/responses"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Scopes that can be granted to personal access tokens. The JWTs of signed in users have every scope.
+const (
+	SCOPE_QUESTIONS_READ  = "questions:read"
+	SCOPE_QUESTIONS_REPLY = "questions:reply"
+	SCOPE_PROFILE_READ    = "profile:read"
+)
+
+// SCOPES are all the scopes that can be granted to personal access tokens.
+var SCOPES = []string{SCOPE_QUESTIONS_READ, SCOPE_QUESTIONS_REPLY, SCOPE_PROFILE_READ}
+
+// AuthMiddleware authenticates requests with the JWT of a signed in user, like JWTMiddleware,
+// or with a personal access token that was granted all the given scopes.
+// Routes that declare no scopes can't be used with personal access tokens.
+// The owner of a personal access token is stored like the one of a JWT, so users.GetUserByToken works for both.
+func AuthMiddleware(AppCtx *configs.AppCtx, scopes ...string) func(*fiber.Ctx) error {
+	jwtMiddleware := JWTMiddleware(AppCtx)
+
+	return func(c *fiber.Ctx) error {
+		token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+
+		if AppCtx.PersonalTokens == nil || !AppCtx.PersonalTokens.IsPersonalToken(token) {
+			return jwtMiddleware(c)
+		}
+
+		handlerCtx := configs.HandlersCtx{C: c}
+
+		userID, grantedScopes, err := AppCtx.PersonalTokens.Verify(token, c.IP())
+
+		if err != nil {
+			return responses.ParseUnsuccesfull(c, http.StatusUnauthorized, i18n.Translate(&handlerCtx, err.Error()))
+		}
+
+		if !HasScopes(grantedScopes, scopes) {
+			return responses.ParseUnsuccesfull(c, http.StatusForbidden, i18n.Translate(&handlerCtx, pkgErrors.INSUFFICIENT_SCOPE))
+		}
+
+		c.Locals("user", &jwt.Token{
+			Valid:  true,
+			Claims: jwt.MapClaims{"id": userID, "scopes": grantedScopes},
+		})
+
+		return c.Next()
+	}
+}
+
+// HasScopes returns true if all the required scopes were granted. It is false when no scope is required,
+// so routes must declare the scopes they accept.
+func HasScopes(grantedScopes []string, requiredScopes []string) bool {
+	if len(requiredScopes) == 0 {
+		return false
+	}
+
+	for _, required := range requiredScopes {
+		isGranted := false
+
+		for _, granted := range grantedScopes {
+			if granted == required {
+				isGranted = true
+				break
+			}
+		}
+
+		if !isGranted {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package personaltokens
+
+import (
+	"errors"
+	"time"
+
+	"github.com/quessapp/core-go/internal/middlewares"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// CreatePersonalTokenDTO is DTO for payload for create personal token handler.
+type CreatePersonalTokenDTO struct {
+	Name string
+	// Scopes are the scopes granted to the token, see middlewares.SCOPES.
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// checkIfExpiresAtIsAllowed returns error if the given time is not in the future or is after MAX_EXPIRES_IN.
+func checkIfExpiresAtIsAllowed(value any) error {
+	expiresAt, _ := value.(*time.Time)
+
+	if expiresAt != nil && (!expiresAt.After(time.Now()) || expiresAt.After(time.Now().Add(MAX_EXPIRES_IN))) {
+		return errors.New(pkgErrors.PERSONAL_TOKEN_EXPIRES_AT_INVALID)
+	}
+
+	return nil
+}
+
+// checkIfScopesAreAllowed returns error if any of the given scopes is not one of middlewares.SCOPES.
+func checkIfScopesAreAllowed(value any) error {
+	scopes, _ := value.([]string)
+
+	for _, scope := range scopes {
+		allowed := false
+
+		for _, s := range middlewares.SCOPES {
+			if scope == s {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return errors.New(pkgErrors.PERSONAL_TOKEN_SCOPE_INVALID)
+		}
+	}
+
+	return nil
+}
+
+// Validate is a method of CreatePersonalTokenDTO that validates the fields of the struct.
+// The Name field is required and must have at most NAME_MAX_LENGTH characters.
+// The Scopes field is required and every scope must be one of middlewares.SCOPES.
+// The ExpiresAt field is required and must be in the future, up to MAX_EXPIRES_IN.
+func (d CreatePersonalTokenDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Name, validation.Required.Error(pkgErrors.PERSONAL_TOKEN_NAME_REQUIRED), validation.Length(1, NAME_MAX_LENGTH).Error(pkgErrors.PERSONAL_TOKEN_NAME_LENGTH)),
+		validation.Field(&d.Scopes, validation.Required.Error(pkgErrors.PERSONAL_TOKEN_SCOPES_REQUIRED), validation.By(checkIfScopesAreAllowed)),
+		validation.Field(&d.ExpiresAt, validation.Required.Error(pkgErrors.PERSONAL_TOKEN_EXPIRES_AT_INVALID), validation.By(checkIfExpiresAtIsAllowed)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
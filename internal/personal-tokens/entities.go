@@ -0,0 +1,51 @@
+package personaltokens
+
+import (
+	"time"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// TOKEN_PREFIX is the prefix of personal access tokens. It tells them apart from JWTs, and helps secret scanners to find leaked tokens.
+const TOKEN_PREFIX = "quess_pat_"
+
+// TOKEN_SIZE is the size, in bytes, of the random part of personal access tokens.
+const TOKEN_SIZE = 32
+
+// HINT_LENGTH is how many characters of the random part of a token are kept, so users can tell their tokens apart.
+const HINT_LENGTH = 4
+
+// MAX_TOKENS_PER_USER is how many personal access tokens an user can have.
+const MAX_TOKENS_PER_USER = 20
+
+// MAX_EXPIRES_IN is the longest lifetime of a personal access token.
+const MAX_EXPIRES_IN = time.Hour * 24 * 365
+
+// NAME_MAX_LENGTH is the maximum length of the name of a personal access token.
+const NAME_MAX_LENGTH = 100
+
+// LAST_USED_UPDATE_INTERVAL is how often the last use of a token is stored, so busy integrations don't write on every request.
+const LAST_USED_UPDATE_INTERVAL = time.Minute
+
+// PersonalToken is a model for a personal access token, used by bots and integrations to call the API on behalf of an user.
+// The token itself is never stored, only its SHA-256 hash.
+type PersonalToken struct {
+	ID     toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID toolkitEntities.ID `json:"-" bson:"userId"`
+	Name   string             `json:"name" bson:"name"`
+	Scopes []string           `json:"scopes" bson:"scopes"`
+	// Hint is the prefix and the last characters of the token, like quess_pat_...a1b2.
+	Hint       string     `json:"hint" bson:"hint"`
+	TokenHash  string     `json:"-" bson:"tokenHash"`
+	LastUsedAt *time.Time `json:"lastUsedAt" bson:"lastUsedAt"`
+	LastUsedIP string     `json:"lastUsedIp,omitempty" bson:"lastUsedIp,omitempty"`
+	ExpiresAt  time.Time  `json:"expiresAt" bson:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt" bson:"createdAt"`
+}
+
+// CreatedPersonalToken is a model for a personal access token that was just created.
+// It is the only time the token is returned.
+type CreatedPersonalToken struct {
+	*PersonalToken
+	Token string `json:"token"`
+}
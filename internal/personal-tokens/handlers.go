@@ -0,0 +1,58 @@
+package personaltokens
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// GetPersonalTokensHandler returns the personal access tokens of the authenticated user.
+func GetPersonalTokensHandler(handlerCtx *configs.HandlersCtx, personalTokensRepository *PersonalTokensRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	tokens, err := GetPersonalTokens(handlerCtx, authenticatedUserID, personalTokensRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, tokens)
+}
+
+// CreatePersonalTokenHandler creates a personal access token for the authenticated user.
+func CreatePersonalTokenHandler(handlerCtx *configs.HandlersCtx, personalTokensRepository *PersonalTokensRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+	payload := CreatePersonalTokenDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	t, err := CreatePersonalToken(handlerCtx, &payload, authenticatedUserID, personalTokensRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, t)
+}
+
+// RevokePersonalTokenHandler revokes a personal access token of the authenticated user.
+func RevokePersonalTokenHandler(handlerCtx *configs.HandlersCtx, personalTokensRepository *PersonalTokensRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	if err := RevokePersonalToken(handlerCtx, id, authenticatedUserID, personalTokensRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
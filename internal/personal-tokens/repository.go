@@ -0,0 +1,144 @@
+package personaltokens
+
+import (
+	"context"
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PersonalTokensRepository represents personal tokens repository.
+type PersonalTokensRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns personal tokens repository.
+func NewRepository(db *mongo.Database) *PersonalTokensRepository {
+	return &PersonalTokensRepository{db}
+}
+
+// Create inserts a new personal token.
+func (p *PersonalTokensRepository) Create(t *PersonalToken) error {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	_, err := coll.InsertOne(context.Background(), t)
+
+	return err
+}
+
+// FindUserTokens finds the personal tokens of an user that did not expire, the newest first.
+func (p *PersonalTokensRepository) FindUserTokens(userID toolkitEntities.ID) (*[]PersonalToken, error) {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []PersonalToken{}
+
+	if err := cursor.All(context.Background(), &tokens); err != nil {
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+// FindUserToken finds a personal token of an user by its ID.
+func (p *PersonalTokensRepository) FindUserToken(userID, id toolkitEntities.ID) *PersonalToken {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "userId", Value: userID},
+	}
+
+	var foundToken PersonalToken
+
+	coll.FindOne(context.Background(), filter).Decode(&foundToken)
+
+	return &foundToken
+}
+
+// FindTokenByHash finds a personal token by the SHA-256 hash of the token.
+func (p *PersonalTokensRepository) FindTokenByHash(tokenHash string) *PersonalToken {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{{Key: "tokenHash", Value: tokenHash}}
+
+	var foundToken PersonalToken
+
+	coll.FindOne(context.Background(), filter).Decode(&foundToken)
+
+	return &foundToken
+}
+
+// CountUserTokens counts the personal tokens of an user that did not expire.
+func (p *PersonalTokensRepository) CountUserTokens(userID toolkitEntities.ID) int64 {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	count, _ := coll.CountDocuments(context.Background(), filter)
+
+	return count
+}
+
+// UpdateLastUsed stores the last use of a personal token.
+func (p *PersonalTokensRepository) UpdateLastUsed(id toolkitEntities.ID, ip string) error {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "lastUsedAt", Value: time.Now()},
+			{Key: "lastUsedIp", Value: ip},
+		}},
+	}
+
+	_, err := coll.UpdateByID(context.Background(), id, update)
+
+	return err
+}
+
+// Delete deletes a personal token of an user.
+func (p *PersonalTokensRepository) Delete(userID, id toolkitEntities.ID) error {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "userId", Value: userID},
+	}
+
+	_, err := coll.DeleteOne(context.Background(), filter)
+
+	return err
+}
+
+// DeleteExpiredTokens deletes the personal tokens of an user that expired.
+func (p *PersonalTokensRepository) DeleteExpiredTokens(userID toolkitEntities.ID) error {
+	coll := p.db.Collection(pkgConstants.PERSONAL_TOKENS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: time.Now()}}},
+	}
+
+	_, err := coll.DeleteMany(context.Background(), filter)
+
+	return err
+}
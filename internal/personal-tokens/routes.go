@@ -0,0 +1,25 @@
+package personaltokens
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the personal access tokens API.
+// It takes in an AppCtx and a PersonalTokensRepository.
+// The routes require the JWT of a signed in user, so a personal access token can't be used to create others.
+func LoadRoutes(AppCtx *configs.AppCtx, personalTokensRepository *PersonalTokensRepository) {
+	g := AppCtx.App.Group("/personal-tokens", middlewares.JWTMiddleware(AppCtx))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetPersonalTokensHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, personalTokensRepository)
+	})
+	g.Post("/", func(c *fiber.Ctx) error {
+		return CreatePersonalTokenHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, personalTokensRepository)
+	})
+	g.Delete("/:id", func(c *fiber.Ctx) error {
+		return RevokePersonalTokenHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, personalTokensRepository)
+	})
+}
@@ -0,0 +1,147 @@
+package personaltokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// Verifier verifies personal access tokens on the requests of bots and integrations.
+// It implements configs.PersonalTokenVerifier, so it can be used by middlewares.AuthMiddleware.
+type Verifier struct {
+	personalTokensRepository *PersonalTokensRepository
+	bansRepository           *bans.BansRepository
+}
+
+// NewVerifier returns a personal access tokens verifier.
+func NewVerifier(personalTokensRepository *PersonalTokensRepository, bansRepository *bans.BansRepository) *Verifier {
+	return &Verifier{personalTokensRepository, bansRepository}
+}
+
+// IsPersonalToken returns true if the token has the personal access tokens prefix.
+func (v *Verifier) IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, TOKEN_PREFIX)
+}
+
+// Verify returns the ID of the owner and the scopes of a personal access token.
+// Expired tokens and tokens of banned users are not valid. The last use of the token is stored, see LAST_USED_UPDATE_INTERVAL.
+func (v *Verifier) Verify(token, ip string) (string, []string, error) {
+	t := v.personalTokensRepository.FindTokenByHash(hashToken(token))
+
+	if err := IsPersonalTokenValid(t); err != nil {
+		return "", nil, err
+	}
+
+	if err := bans.IsNotBanned(v.bansRepository.FindActiveBan(t.UserID)); err != nil {
+		return "", nil, err
+	}
+
+	if t.LastUsedAt == nil || time.Since(*t.LastUsedAt) > LAST_USED_UPDATE_INTERVAL || t.LastUsedIP != ip {
+		if err := v.personalTokensRepository.UpdateLastUsed(t.ID, ip); err != nil {
+			log.Printf("Error updating last use of personal token %v: %v", t.ID, err)
+		}
+	}
+
+	return t.UserID.Hex(), t.Scopes, nil
+}
+
+// CreatePersonalToken creates a personal access token for the authenticated user.
+// The token is returned only once, it can't be retrieved later.
+func CreatePersonalToken(handlerCtx *configs.HandlersCtx, payload *CreatePersonalTokenDTO, authenticatedUserID toolkitEntities.ID, personalTokensRepository *PersonalTokensRepository) (*CreatedPersonalToken, error) {
+	payload.Name = strings.TrimSpace(payload.Name)
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := IsBelowTokensLimit(personalTokensRepository.CountUserTokens(authenticatedUserID)); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := &PersonalToken{
+		ID:        toolkitEntities.NewID(),
+		UserID:    authenticatedUserID,
+		Name:      payload.Name,
+		Scopes:    uniqueScopes(payload.Scopes),
+		Hint:      TOKEN_PREFIX + "..." + token[len(token)-HINT_LENGTH:],
+		TokenHash: hashToken(token),
+		ExpiresAt: *payload.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	if err := personalTokensRepository.Create(t); err != nil {
+		return nil, err
+	}
+
+	data := &CreatedPersonalToken{
+		PersonalToken: t,
+		Token:         token,
+	}
+
+	return data, nil
+}
+
+// GetPersonalTokens returns the personal access tokens of the authenticated user. Expired tokens are deleted.
+func GetPersonalTokens(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, personalTokensRepository *PersonalTokensRepository) (*[]PersonalToken, error) {
+	if err := personalTokensRepository.DeleteExpiredTokens(authenticatedUserID); err != nil {
+		log.Printf("Error deleting expired personal tokens of user %v: %v", authenticatedUserID, err)
+	}
+
+	return personalTokensRepository.FindUserTokens(authenticatedUserID)
+}
+
+// RevokePersonalToken deletes a personal access token of the authenticated user. It stops working immediately.
+func RevokePersonalToken(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, personalTokensRepository *PersonalTokensRepository) error {
+	if err := PersonalTokenExists(personalTokensRepository.FindUserToken(authenticatedUserID, id)); err != nil {
+		return err
+	}
+
+	return personalTokensRepository.Delete(authenticatedUserID, id)
+}
+
+// generateToken generates a random personal access token with the TOKEN_PREFIX.
+func generateToken() (string, error) {
+	b := make([]byte, TOKEN_SIZE)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken hashes a personal access token with SHA-256. The token has enough entropy, so it does not need a slow hash.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// uniqueScopes removes the repeated scopes.
+func uniqueScopes(scopes []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,36 @@
+package personaltokens
+
+import (
+	"errors"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// PersonalTokenExists returns error if the personal token does not exist.
+func PersonalTokenExists(t *PersonalToken) error {
+	if toolkitEntities.IsZeroID(t.ID) {
+		return errors.New(pkgErrors.PERSONAL_TOKEN_NOT_FOUND)
+	}
+
+	return nil
+}
+
+// IsPersonalTokenValid returns error if the personal token does not exist or expired.
+func IsPersonalTokenValid(t *PersonalToken) error {
+	if toolkitEntities.IsZeroID(t.ID) || !t.ExpiresAt.After(time.Now()) {
+		return errors.New(pkgErrors.PERSONAL_TOKEN_INVALID)
+	}
+
+	return nil
+}
+
+// IsBelowTokensLimit returns error if the user already has MAX_TOKENS_PER_USER personal tokens.
+func IsBelowTokensLimit(count int64) error {
+	if count >= MAX_TOKENS_PER_USER {
+		return errors.New(pkgErrors.PERSONAL_TOKENS_LIMIT_REACHED)
+	}
+
+	return nil
+}
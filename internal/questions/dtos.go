@@ -18,30 +18,195 @@ type CreateQuestionDTO struct {
 	SentBy      toolkitEntities.ID
 	IsAnonymous bool
 	CreatedAt   time.Time
+
+	// PollOptions are the options of a new poll, the content is the prompt.
+	PollOptions []string
+	// PollQuestionID is a poll question of the sender, to send the same poll to another user.
+	// The prompt and the options are the ones of the poll, see PollStats.
+	PollQuestionID toolkitEntities.ID
+	// Poll is set from PollOptions or PollQuestionID before the question is created.
+	Poll *Poll `json:"-" form:"-"`
+	// Language is set from the locale of the receiver before the question is created, see search.Language.
+	Language string `json:"-" form:"-"`
+}
+
+// SearchQuestionsDTO is DTO for the query of search questions handler.
+type SearchQuestionsDTO struct {
+	Query string
+	// In are the boxes searched, see SEARCH_BOXES. All of them are searched when it is empty.
+	In []string
+	// IsAnonymous is "true" or "false" to only find anonymous or not anonymous questions, or empty for both.
+	IsAnonymous string
+	// From and To are the first and the last days of the questions found, in SEARCH_DATE_LAYOUT. Both are optional.
+	From string
+	To   string
+
+	// Language is set from the locale of the authenticated user, see search.Language.
+	Language string `json:"-" form:"-"`
 }
 
 // ReplyQuestionDTO is DTO for payload for reply question handler.
 type ReplyQuestionDTO struct {
 	ID      toolkitEntities.ID
 	Content string
+	// Choice is the index of the option chosen on poll questions. The content is then an optional comment.
+	Choice *int
+	// Comment is set from the content on poll questions, whose reply is then the fallback of the choice, see Poll.FallbackReply.
+	Comment string `json:"-" form:"-"`
+
+	// Attachments are set from the uploaded files after they are stored, see UploadAttachments.
+	Attachments []Attachment `json:"-" form:"-"`
+}
+
+// SaveReplyDraftDTO is DTO for payload for save reply draft handler.
+type SaveReplyDraftDTO struct {
+	ID      toolkitEntities.ID
+	Content string
+	// Choice is the index of the option chosen on poll questions, see ReplyQuestionDTO.
+	Choice *int
+	// PublishAt is when the reply is published. The draft is only saved when it is nil.
+	PublishAt *time.Time
 }
 
 // EditQuestionReplyDTO is DTO for payload for edit reply question handler.
 type EditQuestionReplyDTO struct {
-	ID                  toolkitEntities.ID
-	Content             string
-	OldContent          string
-	OldContentCreatedAt time.Time
+	ID      toolkitEntities.ID
+	Content string
+	// OldContent and OldContentCreatedAt are the original reply, set on the first edit only, see EditReply.
+	OldContent          string    `json:"-" form:"-"`
+	OldContentCreatedAt time.Time `json:"-" form:"-"`
+}
+
+// CreateFollowUpDTO is DTO for payload for create follow-up handler.
+type CreateFollowUpDTO struct {
+	QuestionID toolkitEntities.ID
+	Content    string
+}
+
+// ReplyFollowUpDTO is DTO for payload for reply follow-up handler.
+type ReplyFollowUpDTO struct {
+	ID      toolkitEntities.ID
+	Content string
+}
+
+// Validate is a method of CreateFollowUpDTO that validates the fields of the struct.
+// The Content field is required and must have a length between 1 and FOLLOW_UP_MAX_LENGTH characters.
+func (d CreateFollowUpDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Content, validation.Required.Error(errors.CONTENT_REQUIRED), validation.Length(1, FOLLOW_UP_MAX_LENGTH).Error(errors.CONTENT_LENGTH)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of ReplyFollowUpDTO that validates the fields of the struct.
+// The Content field is required and must have a length between 1 and FOLLOW_UP_MAX_LENGTH characters.
+func (d ReplyFollowUpDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Content, validation.Required.Error(errors.CONTENT_REQUIRED), validation.Length(1, FOLLOW_UP_MAX_LENGTH).Error(errors.CONTENT_LENGTH)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// ReactQuestionDTO is DTO for payload for react question handler.
+type ReactQuestionDTO struct {
+	ID   toolkitEntities.ID
+	Type string
+}
+
+// Validate is a method of ReactQuestionDTO that validates the fields of the struct.
+// The Type field is required and must be one of REACTIONS.
+func (d ReactQuestionDTO) Validate() error {
+	reactions := make([]any, len(REACTIONS))
+
+	for i, reaction := range REACTIONS {
+		reactions[i] = reaction
+	}
+
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Type, validation.Required.Error(errors.REACTION_TYPE_REQUIRED), validation.In(reactions...).Error(errors.REACTION_TYPE_INVALID)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of SaveReplyDraftDTO that validates the fields of the struct.
+// The Content and Choice fields follow the rules of the replies, see ReplyQuestionDTO.Validate, since scheduled replies are
+// published as they are. PublishAt must be in the future, up to DRAFT_MAX_SCHEDULE.
+func (d SaveReplyDraftDTO) Validate() error {
+	reply := ReplyQuestionDTO{Content: d.Content, Choice: d.Choice}
+
+	if err := reply.Validate(); err != nil {
+		return err
+	}
+
+	return IsPublishAtInvalid(d.PublishAt, time.Now())
+}
+
+// Validate is a method of SearchQuestionsDTO that validates the fields of the struct.
+// The Query field is required and must have a length between SEARCH_QUERY_MIN_LENGTH and SEARCH_QUERY_MAX_LENGTH characters.
+// The In field must only have SEARCH_BOXES, IsAnonymous must be "true" or "false" and the days must be in SEARCH_DATE_LAYOUT,
+// with From not after To.
+func (d SearchQuestionsDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Query, validation.Required.Error(errors.SEARCH_QUERY_REQUIRED), validation.Length(SEARCH_QUERY_MIN_LENGTH, SEARCH_QUERY_MAX_LENGTH).Error(errors.SEARCH_QUERY_LENGTH)),
+		validation.Field(&d.In, validation.By(checkIfSearchBoxesAreValid)),
+		validation.Field(&d.IsAnonymous, validation.In("true", "false").Error(errors.SEARCH_ANONYMOUS_INVALID)),
+		validation.Field(&d.From, validation.Date(SEARCH_DATE_LAYOUT).Error(errors.SEARCH_DATE_INVALID)),
+		validation.Field(&d.To, validation.Date(SEARCH_DATE_LAYOUT).Error(errors.SEARCH_DATE_INVALID)),
+	)
+
+	if err := validations.GetValidationError(validationResult); err != nil {
+		return err
+	}
+
+	from, to := d.GetPeriod()
+
+	return IsSearchPeriodInvalid(from, to)
+}
+
+// GetIsAnonymous returns the anonymity of the questions to find, or nil to find all of them.
+func (d SearchQuestionsDTO) GetIsAnonymous() *bool {
+	if d.IsAnonymous == "" {
+		return nil
+	}
+
+	isAnonymous := d.IsAnonymous == "true"
+
+	return &isAnonymous
+}
+
+// GetPeriod returns the start of the From day and the end of the To day, or nil for the days not given.
+// The days must be valid, see Validate.
+func (d SearchQuestionsDTO) GetPeriod() (from *time.Time, to *time.Time) {
+	if day, err := time.Parse(SEARCH_DATE_LAYOUT, d.From); err == nil {
+		from = &day
+	}
+
+	if day, err := time.Parse(SEARCH_DATE_LAYOUT, d.To); err == nil {
+		end := day.AddDate(0, 0, 1)
+		to = &end
+	}
+
+	return from, to
 }
 
 // Validate is a method of ReplyQuestionDTO that validates the fields of the struct.
 // The method uses the validation package to validate the Content field.
-// The Content field is required and must have a length between 1 and 250 characters.
+// The Content field is required, unless a choice of poll is given, and must have a length between 1 and 250 characters.
 // The method then returns the validation error, if any, using the validations.GetValidationError method.
 // If there are no validation errors, the method returns nil.
 func (d ReplyQuestionDTO) Validate() error {
+	contentRules := []validation.Rule{validation.Length(1, 250).Error(errors.CONTENT_LENGTH)}
+
+	// the comment on the choice of polls is optional
+	if d.Choice == nil {
+		contentRules = append(contentRules, validation.Required.Error(errors.CONTENT_REQUIRED))
+	}
+
 	validationResult := validation.ValidateStruct(&d,
-		validation.Field(&d.Content, validation.Required.Error(errors.CONTENT_REQUIRED), validation.Length(1, 250).Error(errors.CONTENT_LENGTH)),
+		validation.Field(&d.Content, contentRules...),
 	)
 
 	return validations.GetValidationError(validationResult)
@@ -64,12 +229,21 @@ func (d EditQuestionReplyDTO) Validate() error {
 // The method uses the validation package to validate the Content and SendTo fields.
 // The Content field is required and must have a length between 1 and 250 characters.
 // The SendTo field is required and must have a length between 3 and 50 characters.
+// Polls have between POLL_MIN_OPTIONS and POLL_MAX_OPTIONS options, see checkIfPollOptionsAreValid. The content of a poll sent again is optional.
 // The method then returns the validation error, if any, using the validations.GetValidationError method.
 // If there are no validation errors, the method returns nil.
 func (d CreateQuestionDTO) Validate() error {
+	contentRules := []validation.Rule{validation.Length(1, 250).Error(errors.CONTENT_LENGTH)}
+
+	// the prompt of a poll sent again is the one of the poll
+	if toolkitEntities.IsZeroID(d.PollQuestionID) {
+		contentRules = append(contentRules, validation.Required.Error(errors.CONTENT_REQUIRED))
+	}
+
 	validationResult := validation.ValidateStruct(&d,
-		validation.Field(&d.Content, validation.Required.Error(errors.CONTENT_REQUIRED), validation.Length(1, 250).Error(errors.CONTENT_LENGTH)),
+		validation.Field(&d.Content, contentRules...),
 		validation.Field(&d.SendTo, validation.Required.Error(errors.SEND_TO_REQUIRED), validation.Length(3, 50).Error(errors.SEND_TO_LENGTH)),
+		validation.Field(&d.PollOptions, validation.Length(POLL_MIN_OPTIONS, POLL_MAX_OPTIONS).Error(errors.POLL_OPTIONS_LENGTH), validation.By(checkIfPollOptionsAreValid)),
 	)
 
 	return validations.GetValidationError(validationResult)
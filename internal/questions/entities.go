@@ -1,11 +1,109 @@
 package questions
 
 import (
+	"strings"
 	"time"
 
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/pagination"
+	"github.com/quessapp/core-go/pkg/search"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
 
+// Kinds of reactions to answered questions, see REACTION_EMOJIS.
+const (
+	REACTION_HEART = "heart"
+	REACTION_LAUGH = "laugh"
+	REACTION_WOW   = "wow"
+	REACTION_SAD   = "sad"
+	REACTION_FIRE  = "fire"
+	REACTION_CLAP  = "clap"
+)
+
+// REACTIONS are all the kinds of reactions, in the order they are shown.
+var REACTIONS = []string{REACTION_HEART, REACTION_LAUGH, REACTION_WOW, REACTION_SAD, REACTION_FIRE, REACTION_CLAP}
+
+// REACTION_EMOJIS are the emojis shown for each kind of reaction.
+var REACTION_EMOJIS = map[string]string{
+	REACTION_HEART: "❤️",
+	REACTION_LAUGH: "😂",
+	REACTION_WOW:   "😮",
+	REACTION_SAD:   "😢",
+	REACTION_FIRE:  "🔥",
+	REACTION_CLAP:  "👏",
+}
+
+const (
+	// FOLLOW_UPS_MAX_DEPTH is how many follow-ups the sender can post on a question.
+	FOLLOW_UPS_MAX_DEPTH = 5
+	// FOLLOW_UP_MAX_LENGTH is the maximum number of characters of follow-ups and of their replies.
+	FOLLOW_UP_MAX_LENGTH = 250
+)
+
+const (
+	// REPLY_MAX_ATTACHMENTS is how many images and audio clips can be attached to a reply. The size of the replies with all
+	// their attachments must stay under the body limit of the server, 4MB.
+	REPLY_MAX_ATTACHMENTS = 3
+	// REPLY_MAX_AUDIO_ATTACHMENTS is how many of the attachments of a reply can be audio clips.
+	REPLY_MAX_AUDIO_ATTACHMENTS = 1
+	// ATTACHMENT_MAX_SIZE is the maximum size of each attachment, in bytes, the same as avatars.
+	ATTACHMENT_MAX_SIZE = 1024 * 1024
+	// ATTACHMENT_MAX_DURATION is the maximum duration of audio clips.
+	ATTACHMENT_MAX_DURATION = time.Minute
+	// ATTACHMENTS_DIRECTORY is where the attachments are stored, with the avatars.
+	ATTACHMENTS_DIRECTORY = "replies"
+)
+
+const (
+	// POLL_MIN_OPTIONS and POLL_MAX_OPTIONS are how many options polls have.
+	POLL_MIN_OPTIONS = 2
+	POLL_MAX_OPTIONS = 6
+	// POLL_OPTION_MAX_LENGTH is the maximum number of characters of each option.
+	POLL_OPTION_MAX_LENGTH = 50
+	// POLL_REPLY_MAX_LENGTH is the maximum number of characters of the fallback reply of polls, the limit of the replies
+	// on the clients that don't know polls, see Poll.FallbackReply.
+	POLL_REPLY_MAX_LENGTH = 250
+	// POLL_REPLY_SEPARATOR separates the chosen option from the comment on the fallback reply.
+	POLL_REPLY_SEPARATOR = "\n\n"
+)
+
+const (
+	// DRAFT_MAX_SCHEDULE is how far in the future replies can be scheduled.
+	DRAFT_MAX_SCHEDULE = time.Hour * 24 * 30
+	// DRAFTS_JOB_INTERVAL is how often the scheduled replies that are due are published, see StartScheduledRepliesJob.
+	DRAFTS_JOB_INTERVAL = time.Minute
+	// DRAFT_CLAIM_TIMEOUT is how long a scheduled reply can be claimed by a run of the job before another run takes it over,
+	// in case the server stopped while publishing it.
+	DRAFT_CLAIM_TIMEOUT = time.Minute * 5
+)
+
+// REPLY_EDITS_LIMITS is how many times users can edit each reply, by plan, see users.User.GetPlan.
+var REPLY_EDITS_LIMITS = map[string]int{
+	users.PLAN_FREE: 5,
+	users.PLAN_PRO:  20,
+}
+
+// Boxes of questions searched by the search, see SearchQuestionsDTO.
+const (
+	SEARCH_BOX_RECEIVED = "received"
+	SEARCH_BOX_SENT     = "sent"
+	SEARCH_BOX_REPLIED  = "replied"
+)
+
+// SEARCH_BOXES are all the boxes, searched when none is given.
+var SEARCH_BOXES = []string{SEARCH_BOX_RECEIVED, SEARCH_BOX_SENT, SEARCH_BOX_REPLIED}
+
+const (
+	// SEARCH_QUERY_MIN_LENGTH and SEARCH_QUERY_MAX_LENGTH are the lengths of the search queries.
+	SEARCH_QUERY_MIN_LENGTH = 2
+	SEARCH_QUERY_MAX_LENGTH = 100
+	// SEARCH_DATE_LAYOUT is the layout of the dates of the search filters.
+	SEARCH_DATE_LAYOUT = "2006-01-02"
+	// SEARCH_INDEX_NAME is the name of the text index of the questions, see CreateSearchIndex.
+	SEARCH_INDEX_NAME = "questions_search"
+)
+
 // ReplyHistory is a model for each reply in app.
 type ReplyHistory struct {
 	ID        toolkitEntities.ID `json:"id" bson:"_id"`
@@ -21,6 +119,7 @@ type Question struct {
 	// SendTo represents the user that will receive the question. Type must be Entities.ID, nil ou Entities.User
 	SendTo any `json:"sendTo,omitempty" bson:"sendTo"`
 	// SentBy represents the user who sent the question. Type must be Entities.ID, nil ou Entities.User
+	// It is nil on the questions of users that deleted their accounts.
 	SentBy any `json:"sentBy,omitempty" bson:"sentBy"`
 	// Reply is replied content. Type must be Entities.Reply or nil.
 	Reply any `json:"repliedContent,omitempty"`
@@ -31,15 +130,257 @@ type Question struct {
 
 	// RepliesHistory is the historic of how many times an user updated the question reply.
 	RepliesHistory []ReplyHistory `json:"repliesHistory,omitempty" bson:"repliesHistory"`
+	// IsEdited is set when the reply was edited, at EditedAt. The history is only shown by the history endpoint, see ReplyEditHistory.
+	IsEdited bool       `json:"isEdited,omitempty" bson:"isEdited,omitempty"`
+	EditedAt *time.Time `json:"editedAt,omitempty" bson:"editedAt,omitempty"`
+	// IsOriginalReplyMissing is set on the replies edited before the history kept the original reply, whose history only
+	// has the edited versions, see IsLegacyReplyHistory.
+	IsOriginalReplyMissing bool `json:"-" bson:"isOriginalReplyMissing,omitempty"`
 
 	CreatedAt time.Time  `json:"createdAt" bson:"createdAt,omitempty"`
 	RepliedAt *time.Time `json:"repliedAt,omitempty" bson:"repliedAt"`
+
+	// Reactions is how many users reacted with each kind of reaction, see REACTIONS.
+	Reactions map[string]int64 `json:"reactions,omitempty" bson:"reactions,omitempty"`
+	// MyReactions are the kinds of reactions of the authenticated user. It is not stored.
+	MyReactions []string `json:"myReactions,omitempty" bson:"-"`
+	// FollowUps is the thread of follow-ups of the question, oldest first. It is not stored, see FollowUp.
+	FollowUps []FollowUp `json:"followUps,omitempty" bson:"-"`
+	// Attachments are the images and audio clips attached to the reply.
+	Attachments []Attachment `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	// Poll is set on poll questions, the content is the prompt of the poll. It is nil on the other questions.
+	Poll *Poll `json:"poll,omitempty" bson:"poll,omitempty"`
+	// Language is the language of the text index for the question, from the locale of the receiver, see search.Language.
+	// Questions created before it are indexed in english until cmd/languages is run.
+	Language string `json:"-" bson:"language,omitempty"`
+	// Draft is the reply saved by the receiver and not published yet. It is only shown to the receiver, see DraftedQuestion.
+	Draft *ReplyDraft `json:"-" bson:"draft,omitempty"`
+}
+
+// ReplyDraft is a model for the reply to a question saved by the receiver before it is published.
+// Drafts with PublishAt are scheduled replies, published by the job when their time comes, see PublishScheduledReplies.
+// Drafts have no attachments: they are uploaded when replies are published by the receiver.
+type ReplyDraft struct {
+	Content string `json:"content" bson:"content"`
+	// Choice is the index of the option chosen on poll questions, see ReplyQuestionDTO.
+	Choice    *int       `json:"choice,omitempty" bson:"choice,omitempty"`
+	PublishAt *time.Time `json:"publishAt,omitempty" bson:"publishAt,omitempty"`
+	UpdatedAt time.Time  `json:"updatedAt" bson:"updatedAt"`
+	// ClaimedAt is set when a run of the job claimed the scheduled reply to publish it, see ClaimScheduledReply.
+	ClaimedAt *time.Time `json:"-" bson:"claimedAt,omitempty"`
+}
+
+// DraftedQuestion is a question received by the authenticated user with the draft of its reply.
+type DraftedQuestion struct {
+	Question
+	Draft *ReplyDraft `json:"draft"`
+}
+
+// PaginatedDrafts is a model for paginated drafted questions in app.
+type PaginatedDrafts struct {
+	Questions []DraftedQuestion `json:"questions"`
+	pagination.Page
+}
+
+// Poll is a model for the options of a poll question and the choice of the receiver.
+// Clients that don't know polls still show the prompt as the content, and the choice as the reply, see Poll.FallbackReply.
+type Poll struct {
+	// ID is shared by the questions of a poll sent to several users, see PollStats. It is not shown,
+	// so the polls of anonymous senders can't be linked.
+	ID      toolkitEntities.ID `json:"-" bson:"id"`
+	Options []string           `json:"options" bson:"options"`
+	// Choice is the index of the option chosen by the receiver, nil until the question is replied.
+	Choice *int `json:"choice,omitempty" bson:"choice,omitempty"`
+	// Comment is the optional comment of the receiver on the choice.
+	Comment string `json:"comment,omitempty" bson:"comment,omitempty"`
+}
+
+// PollStats are the results of a poll, counted on all the questions of the poll.
+type PollStats struct {
+	Prompt  string            `json:"prompt"`
+	Options []PollOptionStats `json:"options"`
+	// Sent is how many users received the poll, and Answered is how many of them chose an option.
+	Sent     int64 `json:"sent"`
+	Answered int64 `json:"answered"`
+}
+
+// PollOptionStats is how many receivers of a poll chose an option.
+type PollOptionStats struct {
+	Option string `json:"option"`
+	Votes  int64  `json:"votes"`
+}
+
+// FallbackContent returns the prompt followed by the options, for the emails and the clients that don't know polls.
+func (p Poll) FallbackContent(prompt string) string {
+	return prompt + " (" + strings.Join(p.Options, " / ") + ")"
+}
+
+// FallbackReply returns the reply shown by the clients that don't know polls: the chosen option, followed by the comment.
+func (p Poll) FallbackReply(choice int, comment string) string {
+	if comment == "" {
+		return p.Options[choice]
+	}
+
+	return p.Options[choice] + POLL_REPLY_SEPARATOR + comment
+}
+
+// Attachment is a model for each image or audio clip attached to a reply. Its metadata is read from the uploaded bytes, see media.Probe.
+type Attachment struct {
+	ID toolkitEntities.ID `json:"id" bson:"_id"`
+	// Kind is media.KIND_IMAGE or media.KIND_AUDIO.
+	Kind     string `json:"kind" bson:"kind"`
+	MimeType string `json:"mimeType" bson:"mimeType"`
+	// Size is the size of the file, in bytes.
+	Size int64 `json:"size" bson:"size"`
+	// Width and Height are the dimensions of images, in pixels.
+	Width  int `json:"width,omitempty" bson:"width,omitempty"`
+	Height int `json:"height,omitempty" bson:"height,omitempty"`
+	// Duration is the duration of audio clips, in milliseconds.
+	Duration int64  `json:"duration,omitempty" bson:"duration,omitempty"`
+	URL      string `json:"url" bson:"url"`
+	// FileName is the name of the file in the storage, used to delete it.
+	FileName string `json:"-" bson:"fileName"`
+}
+
+// FollowUp is a model for each follow-up posted by the sender of a question, and the reply of the receiver to it.
+// Follow-ups have no sender: it is the sender of the question, with the same anonymity.
+type FollowUp struct {
+	ID         toolkitEntities.ID `json:"id" bson:"_id"`
+	QuestionID toolkitEntities.ID `json:"questionId" bson:"questionId"`
+	// Depth is the position of the follow-up on the thread, from 1. It is unique on each thread, so concurrent
+	// follow-ups can't go past the limits of the thread, see QuestionsRepository.CreateFollowUpsIndex.
+	Depth   int    `json:"-" bson:"depth,omitempty"`
+	Content string `json:"content" bson:"content"`
+	// Reply is empty until the receiver replies.
+	Reply string `json:"reply,omitempty" bson:"reply,omitempty"`
+	// IsHiddenByReceiver is set on the follow-ups hidden by the receiver, along with the ones after them.
+	IsHiddenByReceiver bool       `json:"-" bson:"isHiddenByReceiver"`
+	CreatedAt          time.Time  `json:"createdAt" bson:"createdAt"`
+	RepliedAt          *time.Time `json:"repliedAt,omitempty" bson:"repliedAt,omitempty"`
+}
+
+// Reaction is a model for each reaction of an user to an answered question.
+type Reaction struct {
+	// ID is made of the question, the user and the kind, see NewReactionID. Since it is unique,
+	// users react once with each kind, even on concurrent requests.
+	ID         string             `json:"-" bson:"_id"`
+	QuestionID toolkitEntities.ID `json:"questionId" bson:"questionId"`
+	UserID     toolkitEntities.ID `json:"-" bson:"userId"`
+	Type       string             `json:"type" bson:"type"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// ReactionKind is a kind of reaction and its emoji.
+type ReactionKind struct {
+	Type  string `json:"type"`
+	Emoji string `json:"emoji"`
+}
+
+// QuestionReactions are the reactions of a question after a reaction is toggled.
+type QuestionReactions struct {
+	Reactions   map[string]int64 `json:"reactions"`
+	MyReactions []string         `json:"myReactions"`
+}
+
+// NewReactionID returns the ID of the reaction of an user to a question with the given kind.
+func NewReactionID(questionID, userID toolkitEntities.ID, kind string) string {
+	return questionID.Hex() + ":" + userID.Hex() + ":" + kind
+}
+
+// InboxPausedError is returned when the receiver paused the inbox, with the message of the receiver for the sender.
+// Error returns the translation key, so it can be handled like the other validation errors.
+type InboxPausedError struct {
+	Message string `json:"pausedMessage,omitempty"`
+}
+
+func (e *InboxPausedError) Error() string {
+	return pkgErrors.INBOX_PAUSED
+}
+
+// ReplyEditHistory is the edit history of a reply, every version of the reply, oldest first.
+type ReplyEditHistory struct {
+	History []ReplyHistory `json:"history"`
+	// IsOriginalMissing is true for replies edited before the history kept the original reply, see Question.IsOriginalReplyMissing.
+	IsOriginalMissing bool `json:"isOriginalMissing,omitempty"`
+	// Edits is how many times the reply was edited. EditsLimit is only shown to the receiver, from the plan.
+	Edits      int  `json:"edits"`
+	EditsLimit *int `json:"editsLimit,omitempty"`
 }
 
 // PaginatedQuestions is a model for paginated questions in app.
 type PaginatedQuestions struct {
-	Questions  *[]Question `json:"questions"`
-	TotalCount int64       `json:"totalCount"`
+	Questions *[]Question `json:"questions"`
+	pagination.Page
+}
+
+// SearchResult is a question found by the search, with the snippets of its content and reply that matched the query.
+type SearchResult struct {
+	Question
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// SearchHighlights are the snippets of a question that matched the query. They are nil when nothing matched,
+// like when only the options of a poll did.
+type SearchHighlights struct {
+	Content *search.Snippet `json:"content,omitempty"`
+	Reply   *search.Snippet `json:"reply,omitempty"`
+}
+
+// PaginatedSearchResults is a model for paginated search results in app.
+type PaginatedSearchResults struct {
+	Results []SearchResult `json:"results"`
+	pagination.Page
+}
+
+// IsLegacyReplyHistory returns true if the reply was edited before the history kept the original reply, and its history
+// was not rebuilt yet, see cmd/replies. Each of these edits added the content of the question and the new reply to the
+// history, without setting IsEdited.
+func (q Question) IsLegacyReplyHistory() bool {
+	return len(q.RepliesHistory) > 0 && !q.IsEdited
+}
+
+// GetReplyVersions returns the versions of the reply in the history, oldest first. Legacy histories only have the edited
+// versions, without the contents of the question, see IsLegacyReplyHistory.
+func (q Question) GetReplyVersions() []ReplyHistory {
+	if !q.IsLegacyReplyHistory() {
+		if q.RepliesHistory == nil {
+			return []ReplyHistory{}
+		}
+
+		return q.RepliesHistory
+	}
+
+	versions := []ReplyHistory{}
+
+	for i := 1; i < len(q.RepliesHistory); i += 2 {
+		versions = append(versions, q.RepliesHistory[i])
+	}
+
+	return versions
+}
+
+// HasOriginalReply returns true if the history of the reply starts with the original reply, see IsOriginalReplyMissing.
+func (q Question) HasOriginalReply() bool {
+	return !q.IsOriginalReplyMissing && !q.IsLegacyReplyHistory()
+}
+
+// CountReplyEdits returns how many times the reply was edited. The history has the original reply too, added on the first
+// edit, except for the replies edited before that, see HasOriginalReply.
+func (q Question) CountReplyEdits() int {
+	versions := q.GetReplyVersions()
+
+	if len(versions) == 0 || !q.HasOriginalReply() {
+		return len(versions)
+	}
+
+	return len(versions) - 1
+}
+
+// GetSentByID returns the ID of the user who sent the question, or a zero ID if the sender deleted the account.
+func (q Question) GetSentByID() toolkitEntities.ID {
+	id, _ := q.SentBy.(toolkitEntities.ID)
+
+	return id
 }
 
 // MapAnonymousFields maps question in order to hide who sent the question, if the questions is anonymous. Otherwise, just returns the whole data.
@@ -54,6 +395,13 @@ func (q Question) MapAnonymousFields() *Question {
 			IsReplied:      q.IsReplied,
 			RepliedAt:      q.RepliedAt,
 			RepliesHistory: q.RepliesHistory,
+			IsEdited:       q.IsEdited,
+			EditedAt:       q.EditedAt,
+			Reactions:      q.Reactions,
+			MyReactions:    q.MyReactions,
+			FollowUps:      q.FollowUps,
+			Attachments:    q.Attachments,
+			Poll:           q.Poll,
 		}
 	}
 	return &q
@@ -5,11 +5,14 @@ import (
 	"github.com/quessapp/core-go/internal/blocks"
 	"github.com/quessapp/core-go/internal/users"
 	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/core-go/pkg/pagination"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/quessapp/toolkit/responses"
 
+	"errors"
+	"mime/multipart"
 	"net/http"
-	"strconv"
+	"strings"
 )
 
 // CreateQuestionHandler creates a new question using the provided payload.
@@ -27,30 +30,46 @@ func CreateQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository
 	payload.SentBy = authenticatedUserID
 
 	if err := CreateQuestion(handlerCtx, &payload, authenticatedUserID, questionsRepository, usersRepository, blocksRepository); err != nil {
-		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+		return parseUnsuccessfulQuestion(handlerCtx, err)
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
 
+// parseUnsuccessfulQuestion parses an unsuccessful response like responses.ParseUnsuccesfull.
+// When the inbox of the receiver is paused, the message of the receiver is returned in the data.
+func parseUnsuccessfulQuestion(handlerCtx *configs.HandlersCtx, err error) error {
+	var pausedErr *InboxPausedError
+
+	if !errors.As(err, &pausedErr) {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	handlerCtx.C.Status(http.StatusBadRequest)
+
+	return handlerCtx.C.JSON(&toolkitEntities.Response{
+		Ok:      false,
+		Error:   true,
+		Message: i18n.Translate(handlerCtx, pausedErr.Error()),
+		Data:    pausedErr,
+	})
+}
+
 // GetAllQuestionsHandler retrieves all questions based on the provided filters and returns them as a paginated list.
 // It takes three parameters, a HandlerCtx, a UsersRepository, and a QuestionsRepository.
 // It returns an error if the retrieval is unsuccessful.
 func GetAllQuestionsHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) error {
 	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
 
-	p, err := strconv.Atoi(handlerCtx.C.Query("page"))
-
-	page := int64(p)
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("questions:"+handlerCtx.C.Query("filter"), authenticatedUserID.Hex()), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	sort := handlerCtx.C.Query("sort")
 	filter := handlerCtx.C.Query("filter")
 
-	questions, err := GetAllQuestions(handlerCtx, &page, &sort, &filter, authenticatedUserID, usersRepository, questionsRepository)
+	questions, err := GetAllQuestions(handlerCtx, params, &filter, authenticatedUserID, usersRepository, questionsRepository)
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
@@ -59,6 +78,70 @@ func GetAllQuestionsHandler(handlerCtx *configs.HandlersCtx, usersRepository *us
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, questions)
 }
 
+// SearchQuestionsHandler retrieves a page of the questions of the authenticated user that match the "q" query.
+// The "in" query is a comma separated list of the boxes to search, see SEARCH_BOXES, and the "anonymous", "from" and "to"
+// queries filter the results, see SearchQuestionsDTO. It returns an error if the search is unsuccessful.
+func SearchQuestionsHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("questions:search", authenticatedUserID.Hex()), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	payload := SearchQuestionsDTO{
+		Query:       strings.TrimSpace(handlerCtx.C.Query("q")),
+		IsAnonymous: handlerCtx.C.Query("anonymous"),
+		From:        handlerCtx.C.Query("from"),
+		To:          handlerCtx.C.Query("to"),
+	}
+
+	if in := handlerCtx.C.Query("in"); in != "" {
+		payload.In = strings.Split(in, ",")
+	}
+
+	results, err := SearchQuestions(handlerCtx, &payload, params, authenticatedUserID, usersRepository, questionsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, results)
+}
+
+// GetUserAnswersHandler retrieves a page of the answered questions of the user with the nick of the "nick" param.
+// It takes four parameters, a HandlerCtx, a UsersRepository, a QuestionsRepository and a BlocksRepository.
+// It returns an error if the retrieval is unsuccessful.
+func GetUserAnswersHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	return getUserAnswers(handlerCtx, authenticatedUserID, usersRepository, questionsRepository, blocksRepository)
+}
+
+// GetPublicUserAnswersHandler is GetUserAnswersHandler for visitors that are not signed in, like web profile pages.
+// Only the answers of users with the public answers visibility are shown.
+func GetPublicUserAnswersHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) error {
+	return getUserAnswers(handlerCtx, toolkitEntities.ID{}, usersRepository, questionsRepository, blocksRepository)
+}
+
+// getUserAnswers parses the nick and the pagination params and returns the answers of the user, see GetUserAnswers.
+func getUserAnswers(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) error {
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("answers", handlerCtx.C.Params("nick")), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	answers, err := GetUserAnswers(handlerCtx, handlerCtx.C.Params("nick"), params, authenticatedUserID, usersRepository, questionsRepository, blocksRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, answers)
+}
+
 // FindQuestionByIDHandler retrieves a question based on the provided ID and returns it.
 // It takes three parameters, a HandlerCtx, a UsersRepository, and a QuestionsRepository.
 // It returns an error if the retrieval is unsuccessful.
@@ -119,9 +202,10 @@ func HideQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *Q
 }
 
 // ReplyQuestionHandler handles the request to reply to a question with the given ID.
-// It requires a HandlersCtx object and a QuestionsRepository object as input parameters.
+// It requires a HandlersCtx object, a QuestionsRepository object and a UsersRepository object as input parameters.
+// Images and audio clips can be attached to the reply as "attachments" files of a multipart request.
 // It returns an error if the request payload cannot be parsed, if the ID cannot be parsed, or if the question cannot be replied to.
-func ReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+func ReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
 	payload := ReplyQuestionDTO{}
 
 	if err := handlerCtx.C.BodyParser(&payload); err != nil {
@@ -138,17 +222,165 @@ func ReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *
 
 	payload.ID = id
 
-	if err := ReplyQuestion(handlerCtx, &payload, authenticatedUserID, questionsRepository); err != nil {
+	// the attachments are only sent on multipart requests
+	files := []*multipart.FileHeader{}
+
+	if form, err := handlerCtx.C.MultipartForm(); err == nil {
+		files = form.File["attachments"]
+	}
+
+	if err := ReplyQuestion(handlerCtx, &payload, files, authenticatedUserID, questionsRepository, usersRepository); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
 
+// SaveReplyDraftHandler saves the draft of the reply to the question with the given ID, or schedules the reply when the
+// payload has a publishAt. It takes two parameters, a HandlerCtx and a QuestionsRepository.
+// It returns an error if the draft can't be saved.
+func SaveReplyDraftHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	payload := SaveReplyDraftDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	payload.ID = id
+
+	draft, err := SaveReplyDraft(&payload, authenticatedUserID, questionsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, draft)
+}
+
+// GetReplyDraftHandler returns the draft of the reply to the question with the given ID.
+// It takes two parameters, a HandlerCtx and a QuestionsRepository.
+func GetReplyDraftHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	draft, err := GetReplyDraft(id, authenticatedUserID, questionsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, draft)
+}
+
+// DeleteReplyDraftHandler discards the draft of the reply to the question with the given ID, cancelling the scheduled reply.
+// It takes two parameters, a HandlerCtx and a QuestionsRepository.
+func DeleteReplyDraftHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := DeleteReplyDraft(id, authenticatedUserID, questionsRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
+
+// GetReplyDraftsHandler retrieves a page of the questions received by the authenticated user that have a draft, with their drafts.
+// It takes three parameters, a HandlerCtx, a UsersRepository and a QuestionsRepository.
+func GetReplyDraftsHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("questions:drafts", authenticatedUserID.Hex()), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	drafts, err := GetReplyDrafts(params, authenticatedUserID, usersRepository, questionsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, drafts)
+}
+
+// GetPollStatsHandler returns the results of the poll of the question with the given ID, counted on all the users that received the poll.
+// It takes two parameters, a HandlerCtx and a QuestionsRepository.
+func GetPollStatsHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	stats, err := GetPollStats(handlerCtx, id, authenticatedUserID, questionsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, stats)
+}
+
+// ListReactionsHandler returns all the kinds of reactions and their emojis.
+func ListReactionsHandler(handlerCtx *configs.HandlersCtx) error {
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, ListReactions())
+}
+
+// ReactQuestionHandler toggles the reaction of the authenticated user to the question with the given ID.
+// It takes four parameters, a HandlerCtx, a QuestionsRepository, a UsersRepository and a BlocksRepository.
+// It returns the reactions of the question, or an error if the request payload or the ID cannot be parsed, or if the reaction cannot be toggled.
+func ReactQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, blocksRepository *blocks.BlocksRepository) error {
+	payload := ReactQuestionDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	payload.ID = id
+
+	reactions, err := ToggleReaction(handlerCtx, &payload, authenticatedUserID, questionsRepository, usersRepository, blocksRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, reactions)
+}
+
 // EditReplyQuestionHandler handles the request to edit a reply to a question with the given ID.
-// It requires a HandlersCtx object and a QuestionsRepository object as input parameters.
+// It requires a HandlersCtx object, a QuestionsRepository object and a UsersRepository object as input parameters.
 // It returns an error if the request payload cannot be parsed, if the ID cannot be parsed, or if the reply cannot be edited.
-func EditReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+func EditReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
 	payload := EditQuestionReplyDTO{}
 
 	if err := handlerCtx.C.BodyParser(&payload); err != nil {
@@ -165,13 +397,34 @@ func EditReplyQuestionHandler(handlerCtx *configs.HandlersCtx, questionsReposito
 
 	payload.ID = id
 
-	if err := EditQuestionReply(handlerCtx, &payload, authenticatedUserID, questionsRepository); err != nil {
+	if err := EditQuestionReply(handlerCtx, &payload, authenticatedUserID, questionsRepository, usersRepository); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
 
+// GetReplyHistoryHandler returns the edit history of the reply to the question with the given ID.
+// It takes four parameters, a HandlerCtx, a UsersRepository, a QuestionsRepository and a BlocksRepository.
+// It returns an error if the history can't be seen.
+func GetReplyHistoryHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	history, err := GetReplyHistory(id, authenticatedUserID, usersRepository, questionsRepository, blocksRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, history)
+}
+
 // RemoveQuestionReplyHandler handles the request to remove a reply to a question with the given ID.
 // It requires a HandlersCtx object and a QuestionsRepository object as input parameters.
 // It returns an error if the ID cannot be parsed or if the reply cannot be removed.
@@ -190,3 +443,95 @@ func RemoveQuestionReplyHandler(handlerCtx *configs.HandlersCtx, questionsReposi
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
 }
+
+// CreateFollowUpHandler handles the request of the sender to post a follow-up on the question with the given ID.
+// It takes four parameters, a HandlerCtx, a QuestionsRepository, a UsersRepository and a BlocksRepository.
+// It returns the created follow-up, or an error if the request payload or the ID cannot be parsed, or if the follow-up cannot be created.
+func CreateFollowUpHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, blocksRepository *blocks.BlocksRepository) error {
+	payload := CreateFollowUpDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	payload.QuestionID = id
+
+	followUp, err := CreateFollowUp(handlerCtx, &payload, authenticatedUserID, questionsRepository, usersRepository, blocksRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, followUp)
+}
+
+// ReplyFollowUpHandler handles the request of the receiver to reply to the follow-up with the given ID.
+// It takes three parameters, a HandlerCtx, a QuestionsRepository and a UsersRepository.
+// It returns an error if the request payload or the ID cannot be parsed, or if the follow-up cannot be replied to.
+func ReplyFollowUpHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
+	payload := ReplyFollowUpDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	payload.ID = id
+
+	if err := ReplyFollowUp(handlerCtx, &payload, authenticatedUserID, questionsRepository, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
+}
+
+// HideFollowUpHandler handles the request of the receiver to hide the follow-up with the given ID, and the ones after it.
+// It returns an error if the ID cannot be parsed or if the follow-up cannot be hidden.
+func HideFollowUpHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := HideFollowUp(handlerCtx, id, authenticatedUserID, questionsRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
+
+// DeleteFollowUpHandler handles the request of the sender to delete the follow-up with the given ID, and the ones after it.
+// It returns an error if the ID cannot be parsed or if the follow-up cannot be deleted.
+func DeleteFollowUpHandler(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := DeleteFollowUp(handlerCtx, id, authenticatedUserID, questionsRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
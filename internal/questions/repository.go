@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	"github.com/quessapp/core-go/pkg/pagination"
+	"github.com/quessapp/core-go/pkg/search"
 	collections "github.com/quessapp/toolkit/constants"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 
@@ -23,8 +26,9 @@ func NewRepository(db *mongo.Database) *QuestionsRepository {
 }
 
 // Create creates a new question in the database with the given payload.
-// It returns an error if the insertion operation fails.
-func (q QuestionsRepository) Create(payload *CreateQuestionDTO) error {
+// It returns false if the question is a poll already sent to the user by a concurrent request, see CreatePollsIndex,
+// and an error if the insertion operation fails.
+func (q QuestionsRepository) Create(payload *CreateQuestionDTO) (bool, error) {
 	coll := q.db.Collection(collections.QUESTIONS)
 
 	payload.ID = toolkitEntities.NewID()
@@ -41,11 +45,17 @@ func (q QuestionsRepository) Create(payload *CreateQuestionDTO) error {
 		Reply:          nil,
 		RepliedAt:      repliedAt,
 		RepliesHistory: []ReplyHistory{},
+		Poll:           payload.Poll,
+		Language:       payload.Language,
 	}
 
 	_, err := coll.InsertOne(context.Background(), question)
 
-	return err
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
 }
 
 // FindQuestionByID finds a question in the database by its ID.
@@ -63,17 +73,13 @@ func (q QuestionsRepository) FindQuestionByID(ID toolkitEntities.ID) *Question {
 }
 
 // GetAll returns a paginated list of questions from the questions collection. It takes
-// a page number (int64), a sort (string), a filter (string) and an authenticatedUserID (toolkitEntities.ID)
+// the pagination params, a filter (string) and an authenticatedUserID (toolkitEntities.ID)
 // as arguments and returns a pointer to a PaginatedQuestions struct and an error. The function retrieves
-// the corresponding documents from the questions collection based on the filter (sent, replied or all),
-// the sort (asc or desc) and the page number, and returns them as a list of Question structs, along with
-// the total number of documents found in the collection that match the given filter. The function also
+// the corresponding documents from the questions collection based on the filter (sent, replied or all)
+// and the cursor of the params, and returns them as a list of Question structs, along with the cursors
+// of the page and, when asked, the total number of documents that match the given filter. The function also
 // returns an error if the database query fails.
-func (q QuestionsRepository) GetAll(page *int64, sort, filter *string, authenticatedUserID toolkitEntities.ID) (*PaginatedQuestions, error) {
-	var LIMIT int64 = 30
-
-	coll := q.db.Collection(collections.QUESTIONS)
-
+func (q QuestionsRepository) GetAll(params *pagination.Params, filter *string, authenticatedUserID toolkitEntities.ID) (*PaginatedQuestions, error) {
 	findFilterOptions := bson.D{
 		{Key: "sendTo", Value: authenticatedUserID},
 		{Key: "isReplied", Value: false},
@@ -90,29 +96,210 @@ func (q QuestionsRepository) GetAll(page *int64, sort, filter *string, authentic
 
 	if *filter == "replied" {
 		findFilterOptions = bson.D{
+			{Key: "sendTo", Value: authenticatedUserID},
 			{Key: "isReplied", Value: true},
 			{Key: "isHiddenByReceiver", Value: false},
 		}
 	}
 
-	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	return q.paginate(findFilterOptions, params)
+}
 
-	if *sort == "desc" {
-		findOptions = options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+// GetReplied returns a paginated list of the replied questions received by the given user, that the user did not hide.
+// It is the public timeline of answers of the user, see GetAll for the pagination.
+func (q QuestionsRepository) GetReplied(userID toolkitEntities.ID, params *pagination.Params) (*PaginatedQuestions, error) {
+	findFilterOptions := bson.D{
+		{Key: "sendTo", Value: userID},
+		{Key: "isReplied", Value: true},
+		{Key: "isHiddenByReceiver", Value: false},
 	}
 
-	findOptions.SetSkip((*page - 1) * LIMIT)
-	findOptions.SetLimit(LIMIT)
-	findOptions.SetProjection(bson.D{{Key: "repliesHistory", Value: 0}})
+	return q.paginate(findFilterOptions, params)
+}
+
+// CreateSearchIndex creates the text index of the questions used by Search, on the content, the reply and the options
+// of polls. The words are stemmed in the language of each question, see Question.Language, and in english for the
+// questions created before it. It does nothing if the index already exists.
+func (q QuestionsRepository) CreateSearchIndex() error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "content", Value: "text"},
+			{Key: "reply", Value: "text"},
+			{Key: "poll.options", Value: "text"},
+		},
+		Options: options.Index().
+			SetName(SEARCH_INDEX_NAME).
+			SetDefaultLanguage(search.DEFAULT_LANGUAGE).
+			SetLanguageOverride("language"),
+	}
+
+	_, err := coll.Indexes().CreateOne(context.Background(), index)
+
+	return err
+}
 
-	countOptions := options.Count()
+// CreateFollowUpsIndex creates the unique index of the depth of the follow-ups on each thread, used by CreateFollowUp.
+// Follow-ups created before it have no depth and are not indexed. It does nothing if the index already exists.
+func (q QuestionsRepository) CreateFollowUpsIndex() error {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
 
-	countOptions.SetSkip((*page - 1) * LIMIT)
-	countOptions.SetLimit(LIMIT)
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "questionId", Value: 1},
+			{Key: "depth", Value: 1},
+		},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.D{{Key: "depth", Value: bson.D{{Key: "$exists", Value: true}}}}),
+	}
+
+	_, err := coll.Indexes().CreateOne(context.Background(), index)
+
+	return err
+}
+
+// CreateDraftsIndex creates the index of the scheduled replies, used by ClaimScheduledReply on every run of the job.
+// Only the questions with a scheduled reply are indexed. It does nothing if the index already exists.
+func (q QuestionsRepository) CreateDraftsIndex() error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "draft.publishAt", Value: 1}},
+		Options: options.Index().
+			SetPartialFilterExpression(bson.D{{Key: "draft.publishAt", Value: bson.D{{Key: "$exists", Value: true}}}}),
+	}
+
+	_, err := coll.Indexes().CreateOne(context.Background(), index)
+
+	return err
+}
+
+// FindReceiversWithoutLanguage finds the IDs of the users that received questions created before the language of the
+// questions, see Question.Language.
+func (q QuestionsRepository) FindReceiversWithoutLanguage() ([]toolkitEntities.ID, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{{Key: "language", Value: bson.D{{Key: "$exists", Value: false}}}}
+
+	values, err := coll.Distinct(context.Background(), "sendTo", filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	receivers := []toolkitEntities.ID{}
+
+	for _, value := range values {
+		// the receivers of legacy questions may not be IDs
+		if id, ok := value.(toolkitEntities.ID); ok {
+			receivers = append(receivers, id)
+		}
+	}
+
+	return receivers, nil
+}
+
+// SetLanguageOfReceived sets the language of the questions received by an user that have none, and returns how many
+// questions were updated.
+func (q QuestionsRepository) SetLanguageOfReceived(userID toolkitEntities.ID, language string) (int64, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "sendTo", Value: userID},
+		{Key: "language", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "language", Value: language}}}}
+
+	result, err := coll.UpdateMany(context.Background(), filter, update)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// CreatePollsIndex creates the unique index of the receivers of each poll, so a poll is sent once to each user even on
+// concurrent requests, see IsPollSentTo. It does nothing if the index already exists.
+func (q QuestionsRepository) CreatePollsIndex() error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "poll.id", Value: 1},
+			{Key: "sendTo", Value: 1},
+		},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.D{{Key: "poll.id", Value: bson.D{{Key: "$exists", Value: true}}}}),
+	}
+
+	_, err := coll.Indexes().CreateOne(context.Background(), index)
+
+	return err
+}
+
+// Search returns a paginated list of the questions of the boxes of the payload that match its query, with its filters.
+// The boxes are the same as the filters of GetAll, except that sent questions are found after they are replied too.
+// The query is in the syntax of the MongoDB text search, stemmed in the language of the payload. Results are sorted
+// like GetAll, not by relevance, so they can be paginated with the same cursors.
+// Questions are indexed in the language of their receivers, so the sent questions are indexed in the language of other
+// users. MongoDB allows a single $text per query, so they are searched in the language of the payload too, and the
+// words of questions sent to users of another language are only found when their stems are the same in both languages.
+func (q QuestionsRepository) Search(payload *SearchQuestionsDTO, authenticatedUserID toolkitEntities.ID, params *pagination.Params) (*PaginatedQuestions, error) {
+	boxes := bson.A{}
+
+	for _, box := range payload.In {
+		switch box {
+		case SEARCH_BOX_RECEIVED:
+			boxes = append(boxes, bson.D{{Key: "sendTo", Value: authenticatedUserID}, {Key: "isReplied", Value: false}, {Key: "isHiddenByReceiver", Value: false}})
+		case SEARCH_BOX_SENT:
+			boxes = append(boxes, bson.D{{Key: "sentBy", Value: authenticatedUserID}, {Key: "isHiddenByReceiver", Value: false}})
+		case SEARCH_BOX_REPLIED:
+			boxes = append(boxes, bson.D{{Key: "sendTo", Value: authenticatedUserID}, {Key: "isReplied", Value: true}, {Key: "isHiddenByReceiver", Value: false}})
+		}
+	}
+
+	findFilterOptions := bson.D{
+		{Key: "$text", Value: bson.D{{Key: "$search", Value: payload.Query}, {Key: "$language", Value: payload.Language}}},
+		// the boxes are in $and, since the cursor of the params is a $or too
+		{Key: "$and", Value: bson.A{bson.D{{Key: "$or", Value: boxes}}}},
+	}
+
+	if isAnonymous := payload.GetIsAnonymous(); isAnonymous != nil {
+		findFilterOptions = append(findFilterOptions, bson.E{Key: "IsAnonymous", Value: *isAnonymous})
+	}
+
+	from, to := payload.GetPeriod()
+	period := bson.D{}
+
+	if from != nil {
+		period = append(period, bson.E{Key: "$gte", Value: *from})
+	}
+
+	if to != nil {
+		period = append(period, bson.E{Key: "$lt", Value: *to})
+	}
+
+	if len(period) > 0 {
+		findFilterOptions = append(findFilterOptions, bson.E{Key: "createdAt", Value: period})
+	}
+
+	return q.paginate(findFilterOptions, params)
+}
+
+// paginate finds a page of the questions that match the filter, without the replies history.
+func (q QuestionsRepository) paginate(findFilterOptions bson.D, params *pagination.Params) (*PaginatedQuestions, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	findOptions := params.FindOptions()
+	findOptions.SetProjection(bson.D{{Key: "repliesHistory", Value: 0}})
 
 	questions := []Question{}
 
-	cursor, err := coll.Find(context.Background(), findFilterOptions, findOptions)
+	cursor, err := coll.Find(context.Background(), params.Filter(findFilterOptions), findOptions)
 
 	if err != nil {
 		return nil, err
@@ -122,15 +309,23 @@ func (q QuestionsRepository) GetAll(page *int64, sort, filter *string, authentic
 		return nil, err
 	}
 
-	totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions, countOptions)
+	questions, page := pagination.NewPage(params, questions, func(q Question) pagination.Position {
+		return pagination.Position{CreatedAt: q.CreatedAt, ID: q.ID}
+	})
 
-	if err != nil {
-		return nil, err
+	if params.WithTotal {
+		totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page.TotalCount = &totalCount
 	}
 
 	result := PaginatedQuestions{
-		TotalCount: totalCount,
-		Questions:  &questions,
+		Questions: &questions,
+		Page:      *page,
 	}
 
 	return &result, nil
@@ -145,9 +340,15 @@ func (q QuestionsRepository) Delete(ID toolkitEntities.ID) error {
 
 	filter := bson.D{{Key: "_id", Value: ID}}
 
-	_, err := coll.DeleteOne(context.Background(), filter)
+	if _, err := coll.DeleteOne(context.Background(), filter); err != nil {
+		return err
+	}
+
+	if err := q.DeleteFollowUps(ID, time.Time{}); err != nil {
+		return err
+	}
 
-	return err
+	return q.DeleteReactions(ID)
 }
 
 // Hide hides a question from the receiver's feed by setting the isHiddenByReceiver
@@ -158,59 +359,169 @@ func (q QuestionsRepository) Hide(ID toolkitEntities.ID) error {
 	coll := q.db.Collection(collections.QUESTIONS)
 	update := bson.D{{Key: "$set", Value: bson.D{{Key: "isHiddenByReceiver", Value: true}}}}
 
-	_, err := coll.UpdateByID(context.Background(), ID, update)
+	if _, err := coll.UpdateByID(context.Background(), ID, update); err != nil {
+		return err
+	}
 
-	return err
+	return q.HideFollowUps(ID, time.Time{})
 }
 
-// Reply replies a question.
-func (q QuestionsRepository) Reply(payload *ReplyQuestionDTO) error {
+// Reply replies a question. The draft of the reply, if any, is discarded.
+// It returns false if the question was already replied, by a concurrent request for example.
+func (q QuestionsRepository) Reply(payload *ReplyQuestionDTO) (bool, error) {
 	coll := q.db.Collection(collections.QUESTIONS)
 
-	filter := bson.D{{Key: "_id", Value: payload.ID}}
-	update := bson.D{
-		{
-			Key: "$set", Value: bson.D{
-				{Key: "isReplied", Value: true},
-				{Key: "reply", Value: payload.Content},
-				{Key: "repliedAt", Value: time.Now()},
-			},
-		},
+	filter := bson.D{
+		{Key: "_id", Value: payload.ID},
+		{Key: "isReplied", Value: false},
+	}
+
+	result, err := coll.UpdateOne(context.Background(), filter, replyUpdate(payload))
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// replyUpdate returns the update that publishes the reply of the payload and discards the draft.
+func replyUpdate(payload *ReplyQuestionDTO) bson.D {
+	set := bson.D{
+		{Key: "isReplied", Value: true},
+		{Key: "reply", Value: payload.Content},
+		{Key: "repliedAt", Value: time.Now()},
+		{Key: "attachments", Value: payload.Attachments},
+	}
+
+	if payload.Choice != nil {
+		set = append(set, bson.E{Key: "poll.choice", Value: *payload.Choice}, bson.E{Key: "poll.comment", Value: payload.Comment})
 	}
 
+	return bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$unset", Value: bson.D{{Key: "draft", Value: ""}}},
+	}
+}
+
+// SaveDraft saves the draft of the reply to a question, replacing the previous one. Since the claim of the job is replaced too,
+// a scheduled reply that is being published is published again with the new draft, see PublishDraft.
+func (q QuestionsRepository) SaveDraft(ID toolkitEntities.ID, draft *ReplyDraft) error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{{Key: "_id", Value: ID}, {Key: "isReplied", Value: false}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "draft", Value: draft}}}}
+
 	_, err := coll.UpdateOne(context.Background(), filter, update)
 
 	return err
 }
 
-// EditReply updates the content of a reply to a question and adds the old content
-// to the repliesHistory field. It takes a pointer to an EditQuestionReplyDTO as
-// argument and returns an error. The function first creates a ReplyHistory slice
-// containing the old and new contents, and then updates the reply and
-// repliesHistory fields in the corresponding document in the questions collection.
+// DeleteDraft discards the draft of the reply to a question, which cancels the scheduled reply.
+func (q QuestionsRepository) DeleteDraft(ID toolkitEntities.ID) error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "draft", Value: ""}}}}
+
+	_, err := coll.UpdateByID(context.Background(), ID, update)
+
+	return err
+}
+
+// GetDrafts returns a paginated list of the questions received by the given user, not replied nor hidden, that have a draft.
+// See GetAll for the pagination.
+func (q QuestionsRepository) GetDrafts(userID toolkitEntities.ID, params *pagination.Params) (*PaginatedQuestions, error) {
+	findFilterOptions := bson.D{
+		{Key: "sendTo", Value: userID},
+		{Key: "isReplied", Value: false},
+		{Key: "isHiddenByReceiver", Value: false},
+		{Key: "draft", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+
+	return q.paginate(findFilterOptions, params)
+}
+
+// ClaimScheduledReply atomically claims the question with the oldest scheduled reply that is due, so concurrent
+// runs of the job, like on several instances of the server, never publish the same reply. Replies claimed for longer than
+// DRAFT_CLAIM_TIMEOUT are claimed again, in case the server stopped while publishing them.
+// It returns a question with a zero ID when no reply is due.
+func (q QuestionsRepository) ClaimScheduledReply() *Question {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	now := time.Now()
+
+	filter := bson.D{
+		{Key: "isReplied", Value: false},
+		{Key: "draft.publishAt", Value: bson.D{{Key: "$lte", Value: now}}},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "draft.claimedAt", Value: bson.D{{Key: "$exists", Value: false}}}},
+			bson.D{{Key: "draft.claimedAt", Value: bson.D{{Key: "$lt", Value: now.Add(-DRAFT_CLAIM_TIMEOUT)}}}},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "draft.claimedAt", Value: now}}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "draft.publishAt", Value: 1}}).
+		SetProjection(bson.D{{Key: "repliesHistory", Value: 0}}).
+		SetReturnDocument(options.After)
+
+	claimedQuestion := Question{}
+
+	coll.FindOneAndUpdate(context.Background(), filter, update, opts).Decode(&claimedQuestion)
+
+	return &claimedQuestion
+}
+
+// PublishDraft publishes the reply of the payload, from the draft claimed at claimedAt. It returns false if the question
+// was replied, or if its draft was changed or discarded, since the draft was claimed.
+func (q QuestionsRepository) PublishDraft(payload *ReplyQuestionDTO, claimedAt time.Time) (bool, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "_id", Value: payload.ID},
+		{Key: "isReplied", Value: false},
+		{Key: "draft.claimedAt", Value: claimedAt},
+	}
+
+	result, err := coll.UpdateOne(context.Background(), filter, replyUpdate(payload))
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// EditReply updates the content of a reply to a question and adds it to the repliesHistory field, marking the reply as edited.
+// It takes a pointer to an EditQuestionReplyDTO as argument and returns an error. On the first edit, the payload has the
+// original reply too, which is added before the new content, so the history has every version of the reply, oldest first.
 // The function returns an error if the update operation fails.
 func (q QuestionsRepository) EditReply(payload *EditQuestionReplyDTO) error {
 	coll := q.db.Collection(collections.QUESTIONS)
 
-	addHistory := []ReplyHistory{
-		// create history for old content
-		{
+	now := time.Now()
+	addHistory := []ReplyHistory{}
+
+	if payload.OldContent != "" {
+		addHistory = append(addHistory, ReplyHistory{
 			ID:        toolkitEntities.NewID(),
 			CreatedAt: payload.OldContentCreatedAt,
 			Content:   payload.OldContent,
-		},
-		{
-			ID:        toolkitEntities.NewID(),
-			CreatedAt: time.Now(),
-			Content:   payload.Content,
-		},
+		})
 	}
 
+	addHistory = append(addHistory, ReplyHistory{
+		ID:        toolkitEntities.NewID(),
+		CreatedAt: now,
+		Content:   payload.Content,
+	})
+
 	filter := bson.D{{Key: "_id", Value: payload.ID}}
 	update := bson.D{
 		{
 			Key: "$set", Value: bson.D{
 				{Key: "reply", Value: payload.Content},
+				{Key: "isEdited", Value: true},
+				{Key: "editedAt", Value: now},
 			},
 		},
 		{
@@ -227,6 +538,58 @@ func (q QuestionsRepository) EditReply(payload *EditQuestionReplyDTO) error {
 	return err
 }
 
+// FindLegacyReplyHistories finds the questions whose reply was edited before the history kept the original reply,
+// see Question.IsLegacyReplyHistory, with only their history.
+func (q QuestionsRepository) FindLegacyReplyHistories() ([]Question, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "repliesHistory.0", Value: bson.D{{Key: "$exists", Value: true}}},
+		{Key: "isEdited", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "repliesHistory", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := []Question{}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// RebuildReplyHistory replaces a legacy history of a reply with its edited versions, see Question.GetReplyVersions, and flags
+// the reply as edited at the last version. Histories that are not legacy anymore are kept.
+func (q QuestionsRepository) RebuildReplyHistory(question *Question) error {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	versions := question.GetReplyVersions()
+	set := bson.D{
+		{Key: "repliesHistory", Value: versions},
+		{Key: "isEdited", Value: true},
+		{Key: "isOriginalReplyMissing", Value: true},
+	}
+
+	if len(versions) > 0 {
+		set = append(set, bson.E{Key: "editedAt", Value: versions[len(versions)-1].CreatedAt})
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: question.ID},
+		{Key: "isEdited", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+
+	_, err := coll.UpdateOne(context.Background(), filter, bson.D{{Key: "$set", Value: set}})
+
+	return err
+}
+
 // RemoveReply removes the reply to a question with the given ID from the Questions collection.
 // It requires a toolkitEntities.ID object as input parameter.
 // It returns an error if the reply cannot be removed from the collection.
@@ -244,9 +607,259 @@ func (q QuestionsRepository) RemoveReply(ID toolkitEntities.ID) error {
 				{Key: "repliesHistory", Value: []ReplyHistory{}},
 			},
 		},
+		// the reactions, the attachments, the choice of polls and the edits were of the removed reply
+		{
+			Key: "$unset", Value: bson.D{
+				{Key: "reactions", Value: ""},
+				{Key: "attachments", Value: ""},
+				{Key: "poll.choice", Value: ""},
+				{Key: "poll.comment", Value: ""},
+				{Key: "isEdited", Value: ""},
+				{Key: "editedAt", Value: ""},
+				{Key: "isOriginalReplyMissing", Value: ""},
+			},
+		},
 	}
 
-	_, err := coll.UpdateOne(context.Background(), filter, update)
+	if _, err := coll.UpdateOne(context.Background(), filter, update); err != nil {
+		return err
+	}
+
+	return q.DeleteReactions(ID)
+}
+
+// ToggleReaction adds the reaction of an user to a question, or removes it if the user already reacted with the same kind.
+// It returns true if the reaction was added. The counter of the question is only changed by the request that actually
+// added or removed the reaction, so it is kept consistent on concurrent requests.
+func (q QuestionsRepository) ToggleReaction(questionID, userID toolkitEntities.ID, kind string) (bool, error) {
+	reactionsColl := q.db.Collection(pkgConstants.REACTIONS)
+	questionsColl := q.db.Collection(collections.QUESTIONS)
+
+	reactionID := NewReactionID(questionID, userID, kind)
+	counter := "reactions." + kind
+
+	deleted, err := reactionsColl.DeleteOne(context.Background(), bson.D{{Key: "_id", Value: reactionID}})
+
+	if err != nil {
+		return false, err
+	}
+
+	if deleted.DeletedCount > 0 {
+		update := bson.D{{Key: "$inc", Value: bson.D{{Key: counter, Value: -1}}}}
+
+		_, err := questionsColl.UpdateByID(context.Background(), questionID, update)
+
+		return false, err
+	}
+
+	_, err = reactionsColl.InsertOne(context.Background(), Reaction{
+		ID:         reactionID,
+		QuestionID: questionID,
+		UserID:     userID,
+		Type:       kind,
+		CreatedAt:  time.Now(),
+	})
+
+	// a concurrent request of the same user added it, and counted it
+	if mongo.IsDuplicateKeyError(err) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: counter, Value: 1}}}}
+
+	_, err = questionsColl.UpdateByID(context.Background(), questionID, update)
+
+	return true, err
+}
+
+// FindUserReactions finds the kinds of reactions of an user to each of the given questions.
+func (q QuestionsRepository) FindUserReactions(userID toolkitEntities.ID, questionIDs []toolkitEntities.ID) (map[toolkitEntities.ID][]string, error) {
+	coll := q.db.Collection(pkgConstants.REACTIONS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "questionId", Value: bson.D{{Key: "$in", Value: questionIDs}}},
+	}
+
+	cursor, err := coll.Find(context.Background(), filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := []Reaction{}
+
+	if err := cursor.All(context.Background(), &reactions); err != nil {
+		return nil, err
+	}
+
+	found := map[toolkitEntities.ID][]string{}
+
+	for _, r := range reactions {
+		found[r.QuestionID] = append(found[r.QuestionID], r.Type)
+	}
+
+	return found, nil
+}
+
+// DeleteReactions deletes the reactions to a question. The counters are not changed, callers remove them along with the question or the reply.
+func (q QuestionsRepository) DeleteReactions(questionID toolkitEntities.ID) error {
+	coll := q.db.Collection(pkgConstants.REACTIONS)
+
+	_, err := coll.DeleteMany(context.Background(), bson.D{{Key: "questionId", Value: questionID}})
+
+	return err
+}
+
+// CreateFollowUp inserts a follow-up of a question.
+func (q QuestionsRepository) CreateFollowUp(followUp *FollowUp) (bool, error) {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	_, err := coll.InsertOne(context.Background(), followUp)
+
+	// a concurrent request created the follow-up with the same depth first
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// FindFollowUpByID finds a follow-up by its ID.
+func (q QuestionsRepository) FindFollowUpByID(ID toolkitEntities.ID) *FollowUp {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	followUp := FollowUp{}
+
+	coll.FindOne(context.Background(), bson.D{{Key: "_id", Value: ID}}).Decode(&followUp)
+
+	return &followUp
+}
+
+// FindThread finds all the follow-ups of a question, including the hidden ones, oldest first.
+func (q QuestionsRepository) FindThread(questionID toolkitEntities.ID) ([]FollowUp, error) {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	filter := bson.D{{Key: "questionId", Value: questionID}}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	thread := []FollowUp{}
+
+	if err := cursor.All(context.Background(), &thread); err != nil {
+		return nil, err
+	}
+
+	return thread, nil
+}
+
+// ReplyFollowUp sets the reply of the receiver to a follow-up. A follow-up is only replied once,
+// so it returns false when the follow-up was already replied.
+func (q QuestionsRepository) ReplyFollowUp(ID toolkitEntities.ID, content string) (bool, error) {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	filter := bson.D{
+		{Key: "_id", Value: ID},
+		{Key: "repliedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "reply", Value: content},
+		{Key: "repliedAt", Value: time.Now()},
+	}}}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// HideFollowUps hides the follow-ups of a question created since the given date, so hiding a follow-up
+// hides the rest of the thread. A zero date hides the whole thread.
+func (q QuestionsRepository) HideFollowUps(questionID toolkitEntities.ID, since time.Time) error {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	filter := bson.D{
+		{Key: "questionId", Value: questionID},
+		{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: since}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "isHiddenByReceiver", Value: true}}}}
+
+	_, err := coll.UpdateMany(context.Background(), filter, update)
+
+	return err
+}
+
+// DeleteFollowUps deletes the follow-ups of a question created since the given date, so deleting a follow-up
+// deletes the rest of the thread. A zero date deletes the whole thread.
+func (q QuestionsRepository) DeleteFollowUps(questionID toolkitEntities.ID, since time.Time) error {
+	coll := q.db.Collection(pkgConstants.FOLLOW_UPS)
+
+	filter := bson.D{
+		{Key: "questionId", Value: questionID},
+		{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: since}}},
+	}
+
+	_, err := coll.DeleteMany(context.Background(), filter)
 
 	return err
 }
+
+// IsPollSentTo returns true if a question of the poll was already sent to the user.
+func (q QuestionsRepository) IsPollSentTo(pollID, userID toolkitEntities.ID) bool {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "poll.id", Value: pollID},
+		{Key: "sendTo", Value: userID},
+	}
+
+	count, _ := coll.CountDocuments(context.Background(), filter)
+
+	return count > 0
+}
+
+// FindPollQuestions finds the questions of a poll sent to all the users, with only their poll.
+func (q QuestionsRepository) FindPollQuestions(pollID toolkitEntities.ID) ([]Question, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{{Key: "poll.id", Value: pollID}}
+	opts := options.Find().SetProjection(bson.D{{Key: "poll", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := []Question{}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// CountReceivedSince counts the questions received by the user since the given time.
+func (q QuestionsRepository) CountReceivedSince(userID toolkitEntities.ID, since time.Time) (int64, error) {
+	coll := q.db.Collection(collections.QUESTIONS)
+
+	filter := bson.D{
+		{Key: "sendTo", Value: userID},
+		{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: since}}},
+	}
+
+	return coll.CountDocuments(context.Background(), filter)
+}
@@ -15,31 +15,83 @@ import (
 // usersRepository is an instance of the UsersRepository struct, which is used to access and modify user data.
 // questionsRepository is an instance of the QuestionsRepository struct, which is used to access and modify question data.
 // blocksRepository is an instance of the BlocksRepository struct, which is used to access and modify blocked user data.
+// Routes that bots and integrations can use declare the scopes their personal access tokens need, see middlewares.AuthMiddleware.
 func LoadRoutes(AppCtx *configs.AppCtx, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) {
-	g := AppCtx.App.Group("/questions", middlewares.JWTMiddleware(AppCtx.App, AppCtx.Cfg))
+	g := AppCtx.App.Group("/questions")
 
-	g.Get("/:id", func(c *fiber.Ctx) error {
+	// registered before "/:id", which would match them
+	g.Get("/reactions", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return ListReactionsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx})
+	})
+	g.Get("/search", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
+		return SearchQuestionsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository)
+	})
+	g.Get("/drafts", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
+		return GetReplyDraftsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository)
+	})
+	g.Get("/polls/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
+		return GetPollStatsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+	g.Get("/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
 		return FindQuestionByIDHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository)
 	})
-	g.Get("/", func(c *fiber.Ctx) error {
+	g.Get("/", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
 		return GetAllQuestionsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository)
 	})
-	g.Post("/", func(c *fiber.Ctx) error {
+	g.Post("/", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return CreateQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository, blocksRepository)
 	})
-	g.Patch("/hide/:id", func(c *fiber.Ctx) error {
+	g.Patch("/hide/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return HideQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
 	})
-	g.Delete("/:id", func(c *fiber.Ctx) error {
+	g.Delete("/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return DeleteQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
 	})
-	g.Patch("/reply/:id", func(c *fiber.Ctx) error {
-		return ReplyQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	g.Patch("/reply/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return ReplyQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository)
 	})
-	g.Delete("/reply/:id", func(c *fiber.Ctx) error {
+	g.Delete("/reply/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
 		return RemoveQuestionReplyHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
 	})
-	g.Patch("/reply/edit/:id", func(c *fiber.Ctx) error {
-		return EditReplyQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	g.Get("/draft/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return GetReplyDraftHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+	g.Put("/draft/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return SaveReplyDraftHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+	g.Delete("/draft/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return DeleteReplyDraftHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+	g.Patch("/react/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return ReactQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository, blocksRepository)
+	})
+	g.Patch("/reply/edit/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return EditReplyQuestionHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository)
 	})
+	g.Get("/reply/history/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
+		return GetReplyHistoryHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository, blocksRepository)
+	})
+	g.Post("/follow-up/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return CreateFollowUpHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository, blocksRepository)
+	})
+	g.Patch("/follow-up/reply/:id", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), func(c *fiber.Ctx) error {
+		return ReplyFollowUpHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository)
+	})
+	g.Patch("/follow-up/hide/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return HideFollowUpHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+	g.Delete("/follow-up/:id", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return DeleteFollowUpHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository)
+	})
+
+	// the timeline of answers is under the profile of the user
+	AppCtx.App.Get("/users/:nick/answers", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), func(c *fiber.Ctx) error {
+		return GetUserAnswersHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository, blocksRepository)
+	})
+
+	if AppCtx.Cfg.Timelines.PublicTimelinesEnabled {
+		AppCtx.App.Get("/public/users/:nick/answers", func(c *fiber.Ctx) error {
+			return GetPublicUserAnswersHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository, questionsRepository, blocksRepository)
+		})
+	}
 }
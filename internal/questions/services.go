@@ -1,16 +1,28 @@
 package questions
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"strings"
 	"time"
 
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/bans"
 	"github.com/quessapp/core-go/internal/blocks"
 	"github.com/quessapp/core-go/internal/queues/emails"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/media"
+	"github.com/quessapp/core-go/pkg/pagination"
+	"github.com/quessapp/core-go/pkg/search"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
+	toolkitS3 "github.com/quessapp/toolkit/s3"
 )
 
 // CreateQuestion creates a new question in the system and sends an email notification to the recipient if enabled.
+// The inbox settings of the recipient are enforced before the posts limit of the sender is used, see checkInbox.
 // It returns an error if any validation checks fail or if there is an issue with creating the question.
 func CreateQuestion(handlerCtx *configs.HandlersCtx, payload *CreateQuestionDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, blocksRepository *blocks.BlocksRepository) error {
 	if err := IsInvalidSendToID(payload); err != nil {
@@ -21,6 +33,10 @@ func CreateQuestion(handlerCtx *configs.HandlersCtx, payload *CreateQuestionDTO,
 		return err
 	}
 
+	if err := setPoll(payload, questionsRepository); err != nil {
+		return err
+	}
+
 	if err := IsSendingQuestionToYourself(payload.SendTo, authenticatedUserID); err != nil {
 		return err
 	}
@@ -41,8 +57,22 @@ func CreateQuestion(handlerCtx *configs.HandlersCtx, payload *CreateQuestionDTO,
 		return err
 	}
 
+	if err := users.IsActive(userToSendQuestion); err != nil {
+		return err
+	}
+
 	userThatIsSendingQuestion := usersRepository.FindUserByID(payload.SentBy)
 
+	if handlerCtx.Cfg.Verification.RestrictUnverifiedUsers {
+		if err := users.IsEmailVerified(userThatIsSendingQuestion); err != nil {
+			return err
+		}
+	}
+
+	if err := checkInbox(userToSendQuestion, userThatIsSendingQuestion, payload.IsAnonymous, questionsRepository); err != nil {
+		return err
+	}
+
 	if err := ReachedPostsLimitToCreateQuestion(userThatIsSendingQuestion); err != nil {
 		return err
 	}
@@ -51,12 +81,27 @@ func CreateQuestion(handlerCtx *configs.HandlersCtx, payload *CreateQuestionDTO,
 		return err
 	}
 
-	if err := questionsRepository.Create(payload); err != nil {
+	payload.Language = search.Language(userToSendQuestion.Locale)
+
+	created, err := questionsRepository.Create(payload)
+
+	if err != nil {
+		return err
+	}
+
+	// only polls are unique for each receiver
+	if err := IsPollAlreadySent(!created); err != nil {
 		return err
 	}
 
 	if userToSendQuestion.EnableAPPEmails {
-		go emails.SendEmailNewQuestionReceived(handlerCtx, payload.Content, payload.IsAnonymous, userToSendQuestion, userThatIsSendingQuestion)
+		content := payload.Content
+
+		if payload.Poll != nil {
+			content = payload.Poll.FallbackContent(content)
+		}
+
+		go emails.SendEmailNewQuestionReceived(handlerCtx, content, payload.IsAnonymous, userToSendQuestion, userThatIsSendingQuestion)
 	}
 
 	if err := users.UpdateLastPublishedAt(userThatIsSendingQuestion, usersRepository); err != nil {
@@ -70,6 +115,75 @@ func CreateQuestion(handlerCtx *configs.HandlersCtx, payload *CreateQuestionDTO,
 	return nil
 }
 
+// checkInbox enforces the inbox settings of the receiver on a new question, see users.Inbox.
+func checkInbox(receiver, sender *users.User, isAnonymous bool, questionsRepository *QuestionsRepository) error {
+	if err := IsInboxPaused(receiver); err != nil {
+		return err
+	}
+
+	if err := IsAnonymousDenied(receiver, isAnonymous); err != nil {
+		return err
+	}
+
+	if err := IsSenderVerifiedForInbox(receiver, sender); err != nil {
+		return err
+	}
+
+	if err := IsSenderOldEnoughForInbox(receiver, sender); err != nil {
+		return err
+	}
+
+	if receiver.GetInbox().DailyLimit == 0 {
+		return nil
+	}
+
+	// the question is refused if the questions received can't be counted, so the limit is never skipped
+	receivedToday, err := questionsRepository.CountReceivedSince(receiver.ID, time.Now().Add(-users.INBOX_DAILY_LIMIT_WINDOW))
+
+	if err != nil {
+		return err
+	}
+
+	return ReachedInboxDailyLimit(receiver, receivedToday)
+}
+
+// setPoll sets the poll of a new question: a new poll with the options of the payload, or the poll of a question of the sender,
+// sent again to another user. The receivers of a poll get it once.
+func setPoll(payload *CreateQuestionDTO, questionsRepository *QuestionsRepository) error {
+	if !toolkitEntities.IsZeroID(payload.PollQuestionID) {
+		pollQuestion := questionsRepository.FindQuestionByID(payload.PollQuestionID)
+
+		if err := PollExists(pollQuestion); err != nil {
+			return err
+		}
+
+		if err := IsPollSender(pollQuestion, payload.SentBy); err != nil {
+			return err
+		}
+
+		if err := IsPollAlreadySent(questionsRepository.IsPollSentTo(pollQuestion.Poll.ID, payload.SendTo)); err != nil {
+			return err
+		}
+
+		payload.Content = pollQuestion.Content
+		payload.Poll = &Poll{ID: pollQuestion.Poll.ID, Options: pollQuestion.Poll.Options}
+
+		return nil
+	}
+
+	if len(payload.PollOptions) > 0 {
+		options := []string{}
+
+		for _, option := range payload.PollOptions {
+			options = append(options, strings.TrimSpace(option))
+		}
+
+		payload.Poll = &Poll{ID: toolkitEntities.NewID(), Options: options}
+	}
+
+	return nil
+}
+
 // FindQuestionByID retrieves a question with the provided ID from the questions repository and returns
 // a Question object and an error. Before returning the question, it is checked if the question exists
 // and if the authenticated user has permission to view the question.
@@ -87,7 +201,30 @@ func FindQuestionByID(handlerCtx *configs.HandlersCtx, id, authenticatedUserID t
 		return nil, err
 	}
 
-	questionOwner := usersRepository.FindUserByID(q.SentBy.(toolkitEntities.ID))
+	if err := flagMyReactions([]*Question{q}, authenticatedUserID, questionsRepository); err != nil {
+		return nil, err
+	}
+
+	thread, err := questionsRepository.FindThread(q.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range thread {
+		if !f.IsHiddenByReceiver {
+			q.FollowUps = append(q.FollowUps, f)
+		}
+	}
+
+	questionOwner := usersRepository.FindUserByID(q.GetSentByID())
+
+	// the sender deleted the account
+	if err := users.UserExists(questionOwner); err != nil {
+		q.SentBy = nil
+
+		return q.MapAnonymousFields(), nil
+	}
 
 	u := users.User{
 		ID:         questionOwner.ID,
@@ -103,53 +240,187 @@ func FindQuestionByID(handlerCtx *configs.HandlersCtx, id, authenticatedUserID t
 }
 
 // GetAllQuestions retrieves a paginated list of questions from the questions repository and returns
-// a PaginatedQuestions object and an error. The pagination, sorting and filtering parameters are optional
-// and have default values. The authenticated user ID is used to determine which questions the user has
+// a PaginatedQuestions object and an error. The filter is optional and has a default value, see pagination.Params
+// for the pagination and sorting. The authenticated user ID is used to determine which questions the user has
 // permission to view. If there are no questions that match the filter, an empty array is returned.
 //
 // For each question, the function checks if the question is owned by an anonymous user. If so, it sets
 // the "SentBy" field to nil. Otherwise, it retrieves the user who owns the question from the users
 // repository and maps the user fields to a new User object, which is assigned to the "SentBy" field of
 // the question. The function then returns a PaginatedQuestions object that contains the list of questions
-// and the total count of questions that match the filter.
-func GetAllQuestions(handlerCtx *configs.HandlersCtx, page *int64, sort, filter *string, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) (*PaginatedQuestions, error) {
-	if *page == 0 {
-		*page = 1
+// and the cursors of the page.
+func GetAllQuestions(handlerCtx *configs.HandlersCtx, params *pagination.Params, filter *string, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) (*PaginatedQuestions, error) {
+	if *filter == "" {
+		*filter = "all"
 	}
 
-	if *sort == "" {
-		*sort = "asc"
+	questions, err := questionsRepository.GetAll(params, filter, authenticatedUserID)
+
+	if err != nil {
+		return nil, err
 	}
 
-	if *filter == "" {
-		*filter = "all"
+	allQuestions := mapSenders(*questions.Questions, authenticatedUserID, usersRepository)
+
+	if err := flagMyReactions(toPointers(*allQuestions), authenticatedUserID, questionsRepository); err != nil {
+		return nil, err
+	}
+
+	result := PaginatedQuestions{
+		Questions: allQuestions,
+		Page:      questions.Page,
+	}
+
+	return &result, nil
+}
+
+// SearchQuestions retrieves a page of the questions received, sent and replied by the authenticated user that match the
+// query of the payload, see QuestionsRepository.Search. The query is stemmed in the language of the locale of the user,
+// including on the sent questions, which are indexed in the language of their receivers.
+// Each result has the snippets of its content and reply around the words that matched, and anonymous questions hide the sender.
+func SearchQuestions(handlerCtx *configs.HandlersCtx, payload *SearchQuestionsDTO, params *pagination.Params, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) (*PaginatedSearchResults, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(payload.In) == 0 {
+		payload.In = SEARCH_BOXES
+	}
+
+	payload.Language = search.Language(usersRepository.FindUserByID(authenticatedUserID).Locale)
+
+	questions, err := questionsRepository.Search(payload, authenticatedUserID, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	foundQuestions := mapSenders(*questions.Questions, authenticatedUserID, usersRepository)
+
+	if err := flagMyReactions(toPointers(*foundQuestions), authenticatedUserID, questionsRepository); err != nil {
+		return nil, err
+	}
+
+	terms := search.Terms(payload.Query, payload.Language)
+	results := []SearchResult{}
+
+	for _, question := range *foundQuestions {
+		reply, _ := question.Reply.(string)
+
+		results = append(results, SearchResult{
+			Question: question,
+			Highlights: SearchHighlights{
+				Content: search.Highlight(question.Content, terms, payload.Language),
+				Reply:   search.Highlight(reply, terms, payload.Language),
+			},
+		})
+	}
+
+	result := PaginatedSearchResults{
+		Results: results,
+		Page:    questions.Page,
+	}
+
+	return &result, nil
+}
+
+// GetUserAnswers retrieves a page of the public timeline of an user: the replied questions that the user did not hide.
+// The user is found by the nick, including the old nicks, see users.FindUserByNick. The authenticatedUserID is zero for
+// visitors that are not signed in. The timeline is not shown when the user and the authenticated user blocked each other,
+// or when the answers visibility chosen by the user does not allow it, see CanSeeAnswers. Anonymous questions hide the sender.
+func GetUserAnswers(handlerCtx *configs.HandlersCtx, nick string, params *pagination.Params, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) (*PaginatedQuestions, error) {
+	owner, err := users.FindUserByNick(handlerCtx, nick, usersRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !toolkitEntities.IsZeroID(authenticatedUserID) {
+		if err := IsBlockedFromAnswers(blocksRepository.IsBlockedBetween(owner.ID, authenticatedUserID)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := CanSeeAnswers(owner, authenticatedUserID); err != nil {
+		return nil, err
 	}
 
-	questions, err := questionsRepository.GetAll(page, sort, filter, authenticatedUserID)
+	questions, err := questionsRepository.GetReplied(owner.ID, params)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if len(*questions.Questions) == 0 {
-		return &PaginatedQuestions{
-			Questions:  &[]Question{},
-			TotalCount: 0,
-		}, nil
+	answers := []Question{}
+
+	for _, q := range *mapSenders(*questions.Questions, authenticatedUserID, usersRepository) {
+		answers = append(answers, *q.MapAnonymousFields())
+	}
+
+	if err := flagMyReactions(toPointers(answers), authenticatedUserID, questionsRepository); err != nil {
+		return nil, err
+	}
+
+	result := PaginatedQuestions{
+		Questions: &answers,
+		Page:      questions.Page,
+	}
+
+	return &result, nil
+}
+
+// flagMyReactions sets the kinds of reactions of the authenticated user on the questions, see Question.MyReactions.
+// Visitors that are not signed in have a zero authenticatedUserID and no reactions.
+func flagMyReactions(questions []*Question, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+	if toolkitEntities.IsZeroID(authenticatedUserID) || len(questions) == 0 {
+		return nil
+	}
+
+	ids := []toolkitEntities.ID{}
+
+	for _, q := range questions {
+		ids = append(ids, q.ID)
+	}
+
+	myReactions, err := questionsRepository.FindUserReactions(authenticatedUserID, ids)
+
+	if err != nil {
+		return err
+	}
+
+	for _, q := range questions {
+		q.MyReactions = myReactions[q.ID]
+	}
+
+	return nil
+}
+
+// toPointers returns pointers to each of the questions, so they can be changed in place.
+func toPointers(questions []Question) []*Question {
+	pointers := []*Question{}
+
+	for i := range questions {
+		pointers = append(pointers, &questions[i])
 	}
 
-	var allQuestions []Question
-	var totalCount int64 = 0
+	return pointers
+}
+
+// mapSenders replaces the sender ID of the questions with the basic infos of the sender, to be shown in the UI.
+// The sender of anonymous questions is only shown to the sender.
+func mapSenders(questions []Question, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository) *[]Question {
+	allQuestions := []Question{}
 
-	for _, q := range *questions.Questions {
-		isQuestionOwner := authenticatedUserID == q.SentBy.(toolkitEntities.ID)
+	for _, q := range questions {
+		sentByID := q.GetSentByID()
+		isQuestionOwner := authenticatedUserID == sentByID
 
 		if q.IsAnonymous && !isQuestionOwner {
 			q.SentBy = nil
 		}
 
 		if !q.IsAnonymous || isQuestionOwner {
-			u := usersRepository.FindUserByID(q.SentBy.(toolkitEntities.ID))
+			u := usersRepository.FindUserByID(sentByID)
 
 			userExists := !u.ID.IsZero()
 
@@ -162,20 +433,16 @@ func GetAllQuestions(handlerCtx *configs.HandlersCtx, page *int64, sort, filter
 					CreatedAt:  u.CreatedAt,
 					IsVerified: u.IsVerified,
 				}
+			} else {
+				// the sender deleted the account, so there is no one to show
+				q.SentBy = nil
 			}
 		}
 
-		totalCount++
-
 		allQuestions = append(allQuestions, q)
 	}
 
-	result := PaginatedQuestions{
-		Questions:  &allQuestions,
-		TotalCount: totalCount,
-	}
-
-	return &result, nil
+	return &allQuestions
 }
 
 // DeleteQuestion retrieves the question with the provided ID from the questions repository and checks if it exists.
@@ -197,6 +464,8 @@ func DeleteQuestion(handlerCtx *configs.HandlersCtx, id, authenticatedUserID too
 		return err
 	}
 
+	DeleteAttachments(handlerCtx, foundQuestion.Attachments)
+
 	// TODO: Delete reports for question
 	// if err := reportsRepository.DeleteReportsForQuestion(id); err != nil {
 	// 	return err
@@ -238,8 +507,9 @@ func HideQuestion(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolk
 // ReplyQuestion is a function that takes in a handler context, a reply question DTO, authenticated user id, and a questions repository as arguments.
 // It validates the reply question DTO, retrieves the question from the questions repository using the id, and checks if the question can be viewed by the authenticated user.
 // It also checks if the question has not already been replied to and if the authenticated user can reply to the question.
-// If all checks pass, it calls the questions repository's Reply function to add the reply to the question.
-func ReplyQuestion(handlerCtx *configs.HandlersCtx, payload *ReplyQuestionDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+// The uploaded files are checked and stored before the reply is added, see ReadAttachments and UploadAttachments.
+// If all checks pass, it calls the questions repository's Reply function to add the reply to the question, and the sender is notified.
+func ReplyQuestion(handlerCtx *configs.HandlersCtx, payload *ReplyQuestionDTO, files []*multipart.FileHeader, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
 	if err := payload.Validate(); err != nil {
 		return err
 	}
@@ -262,78 +532,728 @@ func ReplyQuestion(handlerCtx *configs.HandlersCtx, payload *ReplyQuestionDTO, a
 		return err
 	}
 
-	if err := questionsRepository.Reply(payload); err != nil {
+	if err := IsValidPollChoice(q, payload.Choice); err != nil {
+		return err
+	}
+
+	if err := IsPollCommentTooLong(q, payload.Choice, payload.Content); err != nil {
+		return err
+	}
+
+	if q.Poll != nil {
+		payload.Comment = payload.Content
+		payload.Content = q.Poll.FallbackReply(*payload.Choice, payload.Comment)
+	}
+
+	attachments, contents, err := ReadAttachments(q.ID, files)
+
+	if err != nil {
+		return err
+	}
+
+	if err := UploadAttachments(handlerCtx, attachments, contents); err != nil {
+		return err
+	}
+
+	payload.Attachments = attachments
+
+	replied, err := questionsRepository.Reply(payload)
+
+	if err != nil {
+		DeleteAttachments(handlerCtx, attachments)
+
+		return err
+	}
+
+	// a concurrent request replied first, so the attachments of this reply would never be deleted
+	if err := WasQuestionReplied(replied); err != nil {
+		DeleteAttachments(handlerCtx, attachments)
+
 		return err
 	}
 
+	notifyReplied(handlerCtx, q, payload.Content, usersRepository)
+
 	return nil
 }
 
-// EditQuestionReply is a function that takes in a handler context, an edit question reply DTO, authenticated user id, and a questions repository as arguments.
-// It validates the edit question reply DTO, retrieves the question from the questions repository using the id, and checks if the authenticated user can reply to the question.
-// It also checks if the question has already been replied to, if the authenticated user has not reached the limit for editing the reply, and if the question is not yet replied.
-// If all checks pass, it sets the old content and creation date of the question in the DTO and calls the questions repository's EditReply function to edit the reply.
-func EditQuestionReply(handlerCtx *configs.HandlersCtx, payload *EditQuestionReplyDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+// notifyReplied sends an email to the sender of the question when its reply is published, if the sender enabled the emails.
+func notifyReplied(handlerCtx *configs.HandlersCtx, q *Question, content string, usersRepository *users.UsersRepository) {
+	sender := usersRepository.FindUserByID(q.GetSentByID())
+
+	// the sender may have deleted the account
+	if users.UserExists(sender) == nil && sender.EnableAPPEmails {
+		receiverID, _ := q.SendTo.(toolkitEntities.ID)
+
+		go emails.SendEmailQuestionReplied(handlerCtx, content, usersRepository.FindUserByID(receiverID), sender)
+	}
+}
+
+// SaveReplyDraft saves the draft of the reply to a question received by the authenticated user, replacing the previous one.
+// Drafts with a publishAt are published when their time comes, see PublishScheduledReplies; the sender is only notified then.
+// The draft must be a valid reply, including the choice of polls, since scheduled replies are published as they are.
+func SaveReplyDraft(payload *SaveReplyDraftDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) (*ReplyDraft, error) {
 	if err := payload.Validate(); err != nil {
-		return err
+		return nil, err
 	}
 
 	q := questionsRepository.FindQuestionByID(payload.ID)
 
 	if err := QuestionExists(q); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := CanViewQuestion(q, authenticatedUserID); err != nil {
-		return err
+	if err := CanReply(q, authenticatedUserID); err != nil {
+		return nil, err
 	}
 
-	if err := CanReply(q, authenticatedUserID); err != nil {
-		return err
+	if err := IsAlreadyReplied(q); err != nil {
+		return nil, err
 	}
 
-	if err := ReachedLimitToEditReply(q); err != nil {
-		return err
+	if err := IsValidPollChoice(q, payload.Choice); err != nil {
+		return nil, err
 	}
 
-	if err := IsQuestionNotRepliedYet(q); err != nil {
-		return err
+	if err := IsPollCommentTooLong(q, payload.Choice, payload.Content); err != nil {
+		return nil, err
 	}
 
-	payload.OldContent = q.Content
+	draft := &ReplyDraft{
+		Content:   payload.Content,
+		Choice:    payload.Choice,
+		PublishAt: payload.PublishAt,
+		UpdatedAt: time.Now(),
+	}
 
-	if q.RepliedAt == nil {
-		payload.OldContentCreatedAt = time.Now()
+	if err := questionsRepository.SaveDraft(q.ID, draft); err != nil {
+		return nil, err
 	}
 
-	if err := questionsRepository.EditReply(payload); err != nil {
-		return err
+	return draft, nil
+}
+
+// GetReplyDraft returns the draft of the reply to a question received by the authenticated user.
+func GetReplyDraft(id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) (*ReplyDraft, error) {
+	q := questionsRepository.FindQuestionByID(id)
+
+	if err := QuestionExists(q); err != nil {
+		return nil, err
 	}
 
-	return nil
+	if err := CanReply(q, authenticatedUserID); err != nil {
+		return nil, err
+	}
+
+	if err := DraftExists(q); err != nil {
+		return nil, err
+	}
+
+	return q.Draft, nil
 }
 
-// RemoveQuestionReply is a function that takes in a handler context, a question id, authenticated user id, and a questions repository as arguments.
-// It retrieves the question from the questions repository using the id, and checks if the authenticated user can view the question and if the question has been replied to.
-// If all checks pass, it calls the questions repository's RemoveReply function to remove the reply
-func RemoveQuestionReply(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+// DeleteReplyDraft discards the draft of the reply to a question received by the authenticated user, which cancels the scheduled reply.
+func DeleteReplyDraft(id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
 	q := questionsRepository.FindQuestionByID(id)
 
 	if err := QuestionExists(q); err != nil {
 		return err
 	}
 
-	if err := CanViewQuestion(q, authenticatedUserID); err != nil {
+	if err := CanReply(q, authenticatedUserID); err != nil {
 		return err
 	}
 
-	if err := IsQuestionNotRepliedYet(q); err != nil {
+	if err := DraftExists(q); err != nil {
 		return err
 	}
 
-	if err := questionsRepository.RemoveReply(id); err != nil {
+	return questionsRepository.DeleteDraft(q.ID)
+}
+
+// GetReplyDrafts retrieves a page of the questions received by the authenticated user that have a draft, with their drafts.
+// Anonymous questions hide the sender.
+func GetReplyDrafts(params *pagination.Params, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository) (*PaginatedDrafts, error) {
+	questions, err := questionsRepository.GetDrafts(authenticatedUserID, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	drafted := []DraftedQuestion{}
+
+	for _, question := range *mapSenders(*questions.Questions, authenticatedUserID, usersRepository) {
+		drafted = append(drafted, DraftedQuestion{Question: question, Draft: question.Draft})
+	}
+
+	result := PaginatedDrafts{
+		Questions: drafted,
+		Page:      questions.Page,
+	}
+
+	return &result, nil
+}
+
+// PublishScheduledReply publishes the scheduled reply of a question claimed by the job, and notifies the sender.
+// The replies of receivers that are banned or pending deletion are not published, and are retried after DRAFT_CLAIM_TIMEOUT,
+// so they are published once the ban is lifted or the deletion is cancelled.
+// The reply is not published if the question was replied, or its draft changed, since it was claimed, see PublishDraft.
+func PublishScheduledReply(handlerCtx *configs.HandlersCtx, q *Question, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository) error {
+	receiverID, _ := q.SendTo.(toolkitEntities.ID)
+	receiver := usersRepository.FindUserByID(receiverID)
+
+	if err := users.UserExists(receiver); err != nil {
 		return err
 	}
 
-	return nil
+	if err := users.IsActive(receiver); err != nil {
+		return err
+	}
+
+	if err := bans.IsNotBanned(bansRepository.FindActiveBan(receiver.ID)); err != nil {
+		return err
+	}
+
+	payload := &ReplyQuestionDTO{
+		ID:          q.ID,
+		Content:     q.Draft.Content,
+		Choice:      q.Draft.Choice,
+		Attachments: []Attachment{},
+	}
+
+	if q.Poll != nil {
+		payload.Comment = payload.Content
+		payload.Content = q.Poll.FallbackReply(*payload.Choice, payload.Comment)
+	}
+
+	published, err := questionsRepository.PublishDraft(payload, *q.Draft.ClaimedAt)
+
+	if err != nil {
+		return err
+	}
+
+	if published {
+		notifyReplied(handlerCtx, q, payload.Content, usersRepository)
+	}
+
+	return nil
+}
+
+// PublishScheduledReplies publishes the scheduled replies that are due, one at a time, until there are none left.
+// Failures are logged and the reply is retried after DRAFT_CLAIM_TIMEOUT.
+func PublishScheduledReplies(handlerCtx *configs.HandlersCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository) {
+	for {
+		q := questionsRepository.ClaimScheduledReply()
+
+		if toolkitEntities.IsZeroID(q.ID) {
+			return
+		}
+
+		if err := PublishScheduledReply(handlerCtx, q, questionsRepository, usersRepository, bansRepository); err != nil {
+			log.Printf("Error publishing scheduled reply of question %s: %v", q.ID.Hex(), err)
+		}
+	}
+}
+
+// StartScheduledRepliesJob publishes the scheduled replies that are due every DRAFTS_JOB_INTERVAL, on the background.
+// The scheduled replies are stored with the questions, so the ones that were due while the server was stopped are published
+// by the first run.
+func StartScheduledRepliesJob(AppCtx *configs.AppCtx, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, bansRepository *bans.BansRepository) {
+	handlerCtx := &configs.HandlersCtx{AppCtx: *AppCtx}
+
+	go func() {
+		PublishScheduledReplies(handlerCtx, questionsRepository, usersRepository, bansRepository)
+
+		for range time.Tick(DRAFTS_JOB_INTERVAL) {
+			PublishScheduledReplies(handlerCtx, questionsRepository, usersRepository, bansRepository)
+		}
+	}()
+}
+
+// ReadAttachments reads the files uploaded with the reply to a question and returns their attachments and their contents.
+// The format of the files is sniffed from their bytes, never from their Content-Type, see media.Probe. The files must not be
+// bigger than ATTACHMENT_MAX_SIZE, audio clips must not be longer than ATTACHMENT_MAX_DURATION, and replies have up to
+// REPLY_MAX_ATTACHMENTS attachments. The metadata of images is removed, see media.StripMetadata.
+func ReadAttachments(questionID toolkitEntities.ID, files []*multipart.FileHeader) ([]Attachment, [][]byte, error) {
+	attachments := []Attachment{}
+	contents := [][]byte{}
+
+	for _, file := range files {
+		if err := ReachedAttachmentSizeLimit(file.Size); err != nil {
+			return nil, nil, err
+		}
+
+		f, err := file.Open()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err := io.ReadAll(io.LimitReader(f, ATTACHMENT_MAX_SIZE+1))
+		f.Close()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := ReachedAttachmentSizeLimit(int64(len(content))); err != nil {
+			return nil, nil, err
+		}
+
+		info, err := media.Probe(content)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := IsAudioTooLong(info.Duration); err != nil {
+			return nil, nil, err
+		}
+
+		// the attachments are public, so the location where photos were taken is removed
+		content, err = media.StripMetadata(content)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id := toolkitEntities.NewID()
+
+		attachments = append(attachments, Attachment{
+			ID:       id,
+			Kind:     info.Kind,
+			MimeType: info.MimeType,
+			Size:     int64(len(content)),
+			Width:    info.Width,
+			Height:   info.Height,
+			Duration: info.Duration.Milliseconds(),
+			FileName: fmt.Sprintf("%s/%s/%s.%s", ATTACHMENTS_DIRECTORY, questionID.Hex(), id.Hex(), info.Extension),
+		})
+		contents = append(contents, content)
+	}
+
+	if err := ReachedAttachmentsLimit(attachments); err != nil {
+		return nil, nil, err
+	}
+
+	return attachments, contents, nil
+}
+
+// UploadAttachments stores the attachments on S3 with public-read access, like the avatars, and sets their URLs.
+// If an upload fails, the attachments already stored are deleted.
+func UploadAttachments(handlerCtx *configs.HandlersCtx, attachments []Attachment, contents [][]byte) error {
+	ACL := "public-read"
+
+	for i := range attachments {
+		if _, err := toolkitS3.UploadFile(handlerCtx.S3Client, handlerCtx.Cfg.S3.BucketName, attachments[i].FileName, bytes.NewReader(contents[i]), &ACL); err != nil {
+			DeleteAttachments(handlerCtx, attachments[:i])
+
+			return err
+		}
+
+		attachments[i].URL = fmt.Sprintf("%s%s", handlerCtx.Cfg.CDN.URI, attachments[i].FileName)
+	}
+
+	return nil
+}
+
+// DeleteAttachments deletes the files of the attachments from S3. The reply is already removed,
+// so failures are only logged.
+func DeleteAttachments(handlerCtx *configs.HandlersCtx, attachments []Attachment) {
+	for _, a := range attachments {
+		if _, err := toolkitS3.DeleteFile(handlerCtx.S3Client, handlerCtx.Cfg.S3.BucketName, a.FileName); err != nil {
+			log.Printf("Error deleting attachment %s: %v", a.FileName, err)
+		}
+	}
+}
+
+// EditQuestionReply is a function that takes in a handler context, an edit question reply DTO, authenticated user id, and a questions repository as arguments.
+// It validates the edit question reply DTO, retrieves the question from the questions repository using the id, and checks if the authenticated user can reply to the question.
+// It also checks if the question has already been replied to, if the authenticated user has not reached the limit for editing the reply, and if the question is not yet replied.
+// If all checks pass, it sets the old content and creation date of the question in the DTO and calls the questions repository's EditReply function to edit the reply.
+func EditQuestionReply(handlerCtx *configs.HandlersCtx, payload *EditQuestionReplyDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	q := questionsRepository.FindQuestionByID(payload.ID)
+
+	if err := QuestionExists(q); err != nil {
+		return err
+	}
+
+	if err := CanViewQuestion(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	if err := CanReply(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	if err := ReachedLimitToEditReply(q, usersRepository.FindUserByID(authenticatedUserID).GetPlan()); err != nil {
+		return err
+	}
+
+	if err := IsQuestionNotRepliedYet(q); err != nil {
+		return err
+	}
+
+	if err := IsPollReplyEditable(q); err != nil {
+		return err
+	}
+
+	// legacy histories are rebuilt first, so the new version is added after the edited versions
+	if q.IsLegacyReplyHistory() {
+		if err := questionsRepository.RebuildReplyHistory(q); err != nil {
+			return err
+		}
+	}
+
+	// the original reply is added to the history on the first edit
+	if len(q.RepliesHistory) == 0 {
+		payload.OldContent, _ = q.Reply.(string)
+		payload.OldContentCreatedAt = time.Now()
+
+		if q.RepliedAt != nil {
+			payload.OldContentCreatedAt = *q.RepliedAt
+		}
+	}
+
+	if err := questionsRepository.EditReply(payload); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetReplyHistory returns the edit history of the reply to a question, every version of the reply, oldest first.
+// Who can see it is validated by CanSeeReplyHistory. The receiver also sees how many edits the plan allows.
+func GetReplyHistory(id, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository, questionsRepository *QuestionsRepository, blocksRepository *blocks.BlocksRepository) (*ReplyEditHistory, error) {
+	q := questionsRepository.FindQuestionByID(id)
+
+	if err := QuestionExists(q); err != nil {
+		return nil, err
+	}
+
+	if err := HasReplyHistory(q); err != nil {
+		return nil, err
+	}
+
+	receiverID, _ := q.SendTo.(toolkitEntities.ID)
+	receiver := usersRepository.FindUserByID(receiverID)
+
+	if err := CanSeeReplyHistory(q, receiver, authenticatedUserID, blocksRepository.IsBlockedBetween(receiverID, authenticatedUserID)); err != nil {
+		return nil, err
+	}
+
+	history := ReplyEditHistory{
+		History:           q.GetReplyVersions(),
+		IsOriginalMissing: !q.HasOriginalReply(),
+		Edits:             q.CountReplyEdits(),
+	}
+
+	if receiverID == authenticatedUserID {
+		limit := REPLY_EDITS_LIMITS[receiver.GetPlan()]
+		history.EditsLimit = &limit
+	}
+
+	return &history, nil
+}
+
+// GetPollStats returns the results of the poll of a question, counted on all the users that received the poll.
+// Only the sender of the poll sees them.
+func GetPollStats(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) (*PollStats, error) {
+	q := questionsRepository.FindQuestionByID(id)
+
+	if err := PollExists(q); err != nil {
+		return nil, err
+	}
+
+	if err := IsPollSender(q, authenticatedUserID); err != nil {
+		return nil, err
+	}
+
+	pollQuestions, err := questionsRepository.FindPollQuestions(q.Poll.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &PollStats{Prompt: q.Content, Options: []PollOptionStats{}}
+
+	for _, option := range q.Poll.Options {
+		stats.Options = append(stats.Options, PollOptionStats{Option: option})
+	}
+
+	for _, pollQuestion := range pollQuestions {
+		stats.Sent++
+
+		if pollQuestion.Poll == nil || pollQuestion.Poll.Choice == nil || *pollQuestion.Poll.Choice >= len(stats.Options) {
+			continue
+		}
+
+		stats.Answered++
+		stats.Options[*pollQuestion.Poll.Choice].Votes++
+	}
+
+	return stats, nil
+}
+
+// ListReactions returns all the kinds of reactions and their emojis, in the order they are shown.
+func ListReactions() []ReactionKind {
+	kinds := []ReactionKind{}
+
+	for _, reaction := range REACTIONS {
+		kinds = append(kinds, ReactionKind{Type: reaction, Emoji: REACTION_EMOJIS[reaction]})
+	}
+
+	return kinds
+}
+
+// ToggleReaction adds the reaction of the authenticated user to an answered question, or removes it if the user already reacted with the same kind.
+// Users can react once with each kind of reaction. The answer must be visible to the user, see CanSeeAnswers, and users that blocked
+// the user that answered, or were blocked by them, can't react. It returns the reactions of the question after the change.
+func ToggleReaction(handlerCtx *configs.HandlersCtx, payload *ReactQuestionDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, blocksRepository *blocks.BlocksRepository) (*QuestionReactions, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := questionsRepository.FindQuestionByID(payload.ID)
+
+	if err := QuestionExists(q); err != nil {
+		return nil, err
+	}
+
+	if err := CanReact(q); err != nil {
+		return nil, err
+	}
+
+	receiverID, _ := q.SendTo.(toolkitEntities.ID)
+	receiver := usersRepository.FindUserByID(receiverID)
+
+	if err := users.UserExists(receiver); err != nil {
+		return nil, err
+	}
+
+	if err := users.IsActive(receiver); err != nil {
+		return nil, err
+	}
+
+	if err := IsBlockedFromReacting(blocksRepository.IsBlockedBetween(receiver.ID, authenticatedUserID)); err != nil {
+		return nil, err
+	}
+
+	if err := CanSeeAnswers(receiver, authenticatedUserID); err != nil {
+		return nil, err
+	}
+
+	if _, err := questionsRepository.ToggleReaction(q.ID, authenticatedUserID, payload.Type); err != nil {
+		return nil, err
+	}
+
+	q = questionsRepository.FindQuestionByID(q.ID)
+
+	if err := flagMyReactions([]*Question{q}, authenticatedUserID, questionsRepository); err != nil {
+		return nil, err
+	}
+
+	reactions := &QuestionReactions{
+		Reactions:   map[string]int64{},
+		MyReactions: []string{},
+	}
+
+	if q.Reactions != nil {
+		reactions.Reactions = q.Reactions
+	}
+
+	if q.MyReactions != nil {
+		reactions.MyReactions = q.MyReactions
+	}
+
+	return reactions, nil
+}
+
+// RemoveQuestionReply is a function that takes in a handler context, a question id, authenticated user id, and a questions repository as arguments.
+// It retrieves the question from the questions repository using the id, and checks if the authenticated user can view the question and if the question has been replied to.
+// If all checks pass, it calls the questions repository's RemoveReply function to remove the reply
+func RemoveQuestionReply(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+	q := questionsRepository.FindQuestionByID(id)
+
+	if err := QuestionExists(q); err != nil {
+		return err
+	}
+
+	if err := CanViewQuestion(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	if err := IsQuestionNotRepliedYet(q); err != nil {
+		return err
+	}
+
+	if err := questionsRepository.RemoveReply(id); err != nil {
+		return err
+	}
+
+	DeleteAttachments(handlerCtx, q.Attachments)
+
+	return nil
+}
+
+// CreateFollowUp posts a follow-up of the sender on a replied question, keeping the anonymity of the question.
+// The last follow-up of the thread must be replied before a new one is posted, and threads have up to FOLLOW_UPS_MAX_DEPTH follow-ups,
+// see CanFollowUp. The sender and the receiver must not have blocked each other. The receiver is notified by email if enabled.
+func CreateFollowUp(handlerCtx *configs.HandlersCtx, payload *CreateFollowUpDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository, blocksRepository *blocks.BlocksRepository) (*FollowUp, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := questionsRepository.FindQuestionByID(payload.QuestionID)
+
+	if err := QuestionExists(q); err != nil {
+		return nil, err
+	}
+
+	if err := IsFollowUpSender(q, authenticatedUserID); err != nil {
+		return nil, err
+	}
+
+	receiverID, _ := q.SendTo.(toolkitEntities.ID)
+	receiver := usersRepository.FindUserByID(receiverID)
+
+	if err := users.UserExists(receiver); err != nil {
+		return nil, err
+	}
+
+	if err := users.IsActive(receiver); err != nil {
+		return nil, err
+	}
+
+	if err := IsBlockedFromFollowUp(blocksRepository.IsBlockedBetween(receiver.ID, authenticatedUserID)); err != nil {
+		return nil, err
+	}
+
+	thread, err := questionsRepository.FindThread(q.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CanFollowUp(q, thread); err != nil {
+		return nil, err
+	}
+
+	followUp := &FollowUp{
+		ID:         toolkitEntities.NewID(),
+		QuestionID: q.ID,
+		Depth:      len(thread) + 1,
+		Content:    payload.Content,
+		CreatedAt:  time.Now(),
+	}
+
+	created, err := questionsRepository.CreateFollowUp(followUp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := WasFollowUpCreated(created); err != nil {
+		return nil, err
+	}
+
+	if receiver.EnableAPPEmails {
+		go emails.SendEmailNewFollowUp(handlerCtx, followUp.Content, receiver)
+	}
+
+	return followUp, nil
+}
+
+// ReplyFollowUp replies to a follow-up. Only the receiver of the question can reply, once for each follow-up.
+// The sender of the question is notified by email if enabled.
+func ReplyFollowUp(handlerCtx *configs.HandlersCtx, payload *ReplyFollowUpDTO, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository, usersRepository *users.UsersRepository) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	f := questionsRepository.FindFollowUpByID(payload.ID)
+
+	if err := FollowUpExists(f); err != nil {
+		return err
+	}
+
+	q := questionsRepository.FindQuestionByID(f.QuestionID)
+
+	if err := QuestionExists(q); err != nil {
+		return err
+	}
+
+	if err := CanReply(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	if err := CanReplyFollowUp(f); err != nil {
+		return err
+	}
+
+	replied, err := questionsRepository.ReplyFollowUp(f.ID, payload.Content)
+
+	if err != nil {
+		return err
+	}
+
+	// a concurrent request replied first
+	if err := WasFollowUpReplied(replied); err != nil {
+		return err
+	}
+
+	sender := usersRepository.FindUserByID(q.GetSentByID())
+
+	// the sender may have deleted the account
+	if users.UserExists(sender) == nil && sender.EnableAPPEmails {
+		go emails.SendEmailFollowUpReplied(handlerCtx, payload.Content, usersRepository.FindUserByID(authenticatedUserID), sender)
+	}
+
+	return nil
+}
+
+// HideFollowUp hides a follow-up from the thread, along with the follow-ups after it. Only the receiver of the question can hide follow-ups.
+func HideFollowUp(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+	f := questionsRepository.FindFollowUpByID(id)
+
+	if err := FollowUpExists(f); err != nil {
+		return err
+	}
+
+	q := questionsRepository.FindQuestionByID(f.QuestionID)
+
+	if err := QuestionExists(q); err != nil {
+		return err
+	}
+
+	if err := CanHideQuestion(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	return questionsRepository.HideFollowUps(q.ID, f.CreatedAt)
+}
+
+// DeleteFollowUp deletes a follow-up from the thread, along with the follow-ups after it. Only the sender of the question can delete follow-ups.
+func DeleteFollowUp(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, questionsRepository *QuestionsRepository) error {
+	f := questionsRepository.FindFollowUpByID(id)
+
+	if err := FollowUpExists(f); err != nil {
+		return err
+	}
+
+	q := questionsRepository.FindQuestionByID(f.QuestionID)
+
+	if err := QuestionExists(q); err != nil {
+		return err
+	}
+
+	if err := IsFollowUpSender(q, authenticatedUserID); err != nil {
+		return err
+	}
+
+	return questionsRepository.DeleteFollowUps(q.ID, f.CreatedAt)
 }
@@ -2,9 +2,13 @@ package questions
 
 import (
 	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/quessapp/core-go/internal/users"
 	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/media"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
 
@@ -89,6 +93,15 @@ func IsAlreadyReplied(q *Question) error {
 	return nil
 }
 
+// WasQuestionReplied validates whether the reply to a question was saved, since a concurrent request may have replied first.
+func WasQuestionReplied(replied bool) error {
+	if !replied {
+		return errors.New(pkgErrors.QUESTION_ALREADY_REPLIED)
+	}
+
+	return nil
+}
+
 // CanReply validates whether the authenticated user can reply to the question.
 func CanReply(q *Question, authenticatedUserID toolkitEntities.ID) error {
 	if q.SendTo != authenticatedUserID {
@@ -107,12 +120,412 @@ func IsQuestionNotRepliedYet(q *Question) error {
 	return nil
 }
 
-// ReachedLimitToEditReply validates whether the user has reached their limit to edit the question reply.
-func ReachedLimitToEditReply(q *Question) error {
-	// max is 5 but we add one more because when we edit a reply we add the prev content
-	if len(q.RepliesHistory) >= 6 {
+// ReachedLimitToEditReply validates whether the user has reached the limit of their plan to edit the question reply, see REPLY_EDITS_LIMITS.
+func ReachedLimitToEditReply(q *Question, plan string) error {
+	if q.CountReplyEdits() >= REPLY_EDITS_LIMITS[plan] {
 		return errors.New(pkgErrors.CANT_EDIT_REPLY_REACHED_LIMIT)
 	}
 
 	return nil
 }
+
+// HasReplyHistory validates whether the question was replied, so its reply has a history.
+func HasReplyHistory(q *Question) error {
+	if !q.IsReplied {
+		return errors.New(pkgErrors.REPLY_HISTORY_NOT_REPLIED)
+	}
+
+	return nil
+}
+
+// CanSeeReplyHistory validates whether the viewer can see the edit history of the reply to the question. The receiver and the
+// sender can always see it. The others can only see it if the receiver made the history public and they can see the answers of
+// the receiver, see CanSeeAnswers. isBlocked is whether the receiver and the viewer blocked each other.
+func CanSeeReplyHistory(q *Question, receiver *users.User, viewerID toolkitEntities.ID, isBlocked bool) error {
+	if q.SendTo == viewerID || q.GetSentByID() == viewerID {
+		return nil
+	}
+
+	if q.IsHiddenByReceiver || !receiver.IsReplyHistoryPublic || isBlocked {
+		return errors.New(pkgErrors.REPLY_HISTORY_PRIVATE)
+	}
+
+	return CanSeeAnswers(receiver, viewerID)
+}
+
+// CanSeeAnswers validates whether the viewer can see the timeline of answers of the owner, according to the visibility chosen by the owner.
+// The viewer ID is zero for visitors that are not signed in. Owners can always see their own answers.
+func CanSeeAnswers(owner *users.User, viewerID toolkitEntities.ID) error {
+	if owner.ID == viewerID {
+		return nil
+	}
+
+	switch owner.GetAnswersVisibility() {
+	case users.ANSWERS_VISIBILITY_PRIVATE:
+		return errors.New(pkgErrors.ANSWERS_PRIVATE)
+	case users.ANSWERS_VISIBILITY_USERS:
+		if toolkitEntities.IsZeroID(viewerID) {
+			return errors.New(pkgErrors.ANSWERS_SIGN_IN_REQUIRED)
+		}
+	}
+
+	return nil
+}
+
+// IsBlockedFromAnswers validates whether the viewer and the owner of the answers blocked each other, in either direction.
+func IsBlockedFromAnswers(isBlocked bool) error {
+	if isBlocked {
+		return errors.New(pkgErrors.ANSWERS_BLOCKED)
+	}
+
+	return nil
+}
+
+// CanReact validates whether the question can receive reactions. Only answered questions that are not hidden by the receiver can.
+func CanReact(q *Question) error {
+	if !q.IsReplied || q.IsHiddenByReceiver {
+		return errors.New(pkgErrors.REACTION_NOT_REPLIED)
+	}
+
+	return nil
+}
+
+// IsBlockedFromReacting validates whether the user that reacts and the user that answered blocked each other, in either direction.
+func IsBlockedFromReacting(isBlocked bool) error {
+	if isBlocked {
+		return errors.New(pkgErrors.REACTION_BLOCKED)
+	}
+
+	return nil
+}
+
+// FollowUpExists validates whether a follow-up exists or not based on its ID.
+func FollowUpExists(f *FollowUp) error {
+	if toolkitEntities.IsZeroID(f.ID) {
+		return errors.New(pkgErrors.FOLLOW_UP_NOT_FOUND)
+	}
+
+	return nil
+}
+
+// IsFollowUpSender validates whether the authenticated user sent the question, since only the sender can follow up.
+func IsFollowUpSender(q *Question, authenticatedUserID toolkitEntities.ID) error {
+	if q.GetSentByID() != authenticatedUserID {
+		return errors.New(pkgErrors.FOLLOW_UP_NOT_SENDER)
+	}
+
+	return nil
+}
+
+// CanFollowUp validates whether the thread of the question accepts a new follow-up: the question must be replied and not hidden,
+// the last follow-up must be replied and not hidden, and the thread must have less than FOLLOW_UPS_MAX_DEPTH follow-ups.
+func CanFollowUp(q *Question, thread []FollowUp) error {
+	if !q.IsReplied {
+		return errors.New(pkgErrors.FOLLOW_UP_QUESTION_NOT_REPLIED)
+	}
+
+	if q.IsHiddenByReceiver {
+		return errors.New(pkgErrors.FOLLOW_UP_HIDDEN)
+	}
+
+	if len(thread) >= FOLLOW_UPS_MAX_DEPTH {
+		return errors.New(pkgErrors.FOLLOW_UPS_LIMIT_REACHED)
+	}
+
+	if len(thread) > 0 {
+		last := thread[len(thread)-1]
+
+		if last.IsHiddenByReceiver {
+			return errors.New(pkgErrors.FOLLOW_UP_HIDDEN)
+		}
+
+		if last.RepliedAt == nil {
+			return errors.New(pkgErrors.FOLLOW_UP_PENDING_REPLY)
+		}
+	}
+
+	return nil
+}
+
+// CanReplyFollowUp validates whether the follow-up can be replied: it must not be hidden nor replied.
+func CanReplyFollowUp(f *FollowUp) error {
+	if f.IsHiddenByReceiver {
+		return errors.New(pkgErrors.FOLLOW_UP_HIDDEN)
+	}
+
+	if f.RepliedAt != nil {
+		return errors.New(pkgErrors.FOLLOW_UP_ALREADY_REPLIED)
+	}
+
+	return nil
+}
+
+// IsBlockedFromFollowUp validates whether the sender and the receiver of the question blocked each other, in either direction.
+func IsBlockedFromFollowUp(isBlocked bool) error {
+	if isBlocked {
+		return errors.New(pkgErrors.FOLLOW_UP_BLOCKED)
+	}
+
+	return nil
+}
+
+// WasFollowUpReplied validates whether the reply to a follow-up was saved, since a concurrent request may have replied first.
+func WasFollowUpReplied(replied bool) error {
+	if !replied {
+		return errors.New(pkgErrors.FOLLOW_UP_ALREADY_REPLIED)
+	}
+
+	return nil
+}
+
+// WasFollowUpCreated validates whether the follow-up was saved, since a concurrent request may have posted a follow-up
+// on the thread first, which is pending reply.
+func WasFollowUpCreated(created bool) error {
+	if !created {
+		return errors.New(pkgErrors.FOLLOW_UP_PENDING_REPLY)
+	}
+
+	return nil
+}
+
+// ReachedAttachmentsLimit validates whether a reply has more than REPLY_MAX_ATTACHMENTS attachments,
+// or more than REPLY_MAX_AUDIO_ATTACHMENTS audio clips.
+func ReachedAttachmentsLimit(attachments []Attachment) error {
+	if len(attachments) > REPLY_MAX_ATTACHMENTS {
+		return errors.New(pkgErrors.REPLY_ATTACHMENTS_LIMIT)
+	}
+
+	audios := 0
+
+	for _, a := range attachments {
+		if a.Kind == media.KIND_AUDIO {
+			audios++
+		}
+	}
+
+	if audios > REPLY_MAX_AUDIO_ATTACHMENTS {
+		return errors.New(pkgErrors.REPLY_AUDIO_ATTACHMENTS_LIMIT)
+	}
+
+	return nil
+}
+
+// ReachedAttachmentSizeLimit validates whether an attachment is bigger than ATTACHMENT_MAX_SIZE.
+func ReachedAttachmentSizeLimit(size int64) error {
+	if size > ATTACHMENT_MAX_SIZE {
+		return errors.New(pkgErrors.MAX_FILE_SIZE)
+	}
+
+	return nil
+}
+
+// IsAudioTooLong validates whether an audio clip is longer than ATTACHMENT_MAX_DURATION.
+func IsAudioTooLong(duration time.Duration) error {
+	if duration > ATTACHMENT_MAX_DURATION {
+		return errors.New(pkgErrors.AUDIO_TOO_LONG)
+	}
+
+	return nil
+}
+
+// checkIfPollOptionsAreValid returns error if an option of a poll is blank, longer than POLL_OPTION_MAX_LENGTH
+// or the same as another option. It is a rule of CreateQuestionDTO.
+func checkIfPollOptionsAreValid(value any) error {
+	options, _ := value.([]string)
+	seen := map[string]bool{}
+
+	for _, option := range options {
+		option = strings.ToLower(strings.TrimSpace(option))
+
+		if option == "" || len([]rune(option)) > POLL_OPTION_MAX_LENGTH {
+			return errors.New(pkgErrors.POLL_OPTION_INVALID)
+		}
+
+		if seen[option] {
+			return errors.New(pkgErrors.POLL_OPTIONS_DUPLICATED)
+		}
+
+		seen[option] = true
+	}
+
+	return nil
+}
+
+// PollExists validates whether a poll question of the sender was found, to send the same poll to another user.
+func PollExists(q *Question) error {
+	if toolkitEntities.IsZeroID(q.ID) || q.Poll == nil {
+		return errors.New(pkgErrors.POLL_NOT_FOUND)
+	}
+
+	return nil
+}
+
+// IsPollAlreadySent validates whether the poll was already sent to the user.
+func IsPollAlreadySent(isSent bool) error {
+	if isSent {
+		return errors.New(pkgErrors.POLL_ALREADY_SENT)
+	}
+
+	return nil
+}
+
+// IsValidPollChoice validates the choice of the reply to a question: it is required on poll questions, where it must be the index
+// of an option, and not allowed on the other questions.
+func IsValidPollChoice(q *Question, choice *int) error {
+	if q.Poll == nil {
+		if choice != nil {
+			return errors.New(pkgErrors.QUESTION_NOT_POLL)
+		}
+
+		return nil
+	}
+
+	if choice == nil {
+		return errors.New(pkgErrors.POLL_CHOICE_REQUIRED)
+	}
+
+	if *choice < 0 || *choice >= len(q.Poll.Options) {
+		return errors.New(pkgErrors.POLL_CHOICE_INVALID)
+	}
+
+	return nil
+}
+
+// IsPollCommentTooLong validates whether the fallback reply of the choice and the comment is longer than POLL_REPLY_MAX_LENGTH,
+// so the comment on long options is shorter than the other replies. The choice must be valid, see IsValidPollChoice.
+func IsPollCommentTooLong(q *Question, choice *int, comment string) error {
+	if q.Poll == nil || comment == "" {
+		return nil
+	}
+
+	if utf8.RuneCountInString(q.Poll.FallbackReply(*choice, comment)) > POLL_REPLY_MAX_LENGTH {
+		return errors.New(pkgErrors.POLL_COMMENT_LENGTH)
+	}
+
+	return nil
+}
+
+// IsPollReplyEditable validates whether the reply to a question can be edited. The choice of polls can't be edited,
+// the reply must be removed to choose again.
+func IsPollReplyEditable(q *Question) error {
+	if q.Poll != nil {
+		return errors.New(pkgErrors.POLL_REPLY_NOT_EDITABLE)
+	}
+
+	return nil
+}
+
+// IsPollSender validates whether the user sent the poll question. Only the sender sends a poll again and sees the results
+// of all the receivers.
+func IsPollSender(q *Question, authenticatedUserID toolkitEntities.ID) error {
+	if q.GetSentByID() != authenticatedUserID {
+		return errors.New(pkgErrors.POLL_NOT_AUTHORIZED)
+	}
+
+	return nil
+}
+
+// IsInboxPaused validates whether the receiver paused the inbox. The error has the message of the receiver, see InboxPausedError.
+func IsInboxPaused(receiver *users.User) error {
+	if inbox := receiver.GetInbox(); inbox.IsPaused {
+		return &InboxPausedError{Message: inbox.PausedMessage}
+	}
+
+	return nil
+}
+
+// IsAnonymousDenied validates whether the question is anonymous while the receiver denies anonymous questions.
+func IsAnonymousDenied(receiver *users.User, isAnonymous bool) error {
+	if isAnonymous && receiver.GetInbox().DenyAnonymous {
+		return errors.New(pkgErrors.INBOX_ANONYMOUS_DENIED)
+	}
+
+	return nil
+}
+
+// IsSenderVerifiedForInbox validates whether the sender verified the email, when the receiver requires it.
+func IsSenderVerifiedForInbox(receiver, sender *users.User) error {
+	if receiver.GetInbox().RequireVerifiedEmail && !sender.IsVerified {
+		return errors.New(pkgErrors.INBOX_VERIFIED_EMAIL_REQUIRED)
+	}
+
+	return nil
+}
+
+// IsSenderOldEnoughForInbox validates whether the account of the sender is older than the minimum age required by the receiver.
+func IsSenderOldEnoughForInbox(receiver, sender *users.User) error {
+	minAge := time.Duration(receiver.GetInbox().MinAccountAgeDays) * time.Hour * 24
+
+	if time.Since(sender.GetCreatedAt()) < minAge {
+		return errors.New(pkgErrors.INBOX_ACCOUNT_TOO_NEW)
+	}
+
+	return nil
+}
+
+// ReachedInboxDailyLimit validates whether the receiver already received the daily limit of questions, see users.Inbox.DailyLimit.
+func ReachedInboxDailyLimit(receiver *users.User, receivedToday int64) error {
+	if limit := receiver.GetInbox().DailyLimit; limit > 0 && receivedToday >= int64(limit) {
+		return errors.New(pkgErrors.INBOX_DAILY_LIMIT_REACHED)
+	}
+
+	return nil
+}
+
+// checkIfSearchBoxesAreValid returns error if a box of a search is not one of SEARCH_BOXES. It is a rule of SearchQuestionsDTO.
+func checkIfSearchBoxesAreValid(value any) error {
+	boxes, _ := value.([]string)
+
+	for _, box := range boxes {
+		isValid := false
+
+		for _, valid := range SEARCH_BOXES {
+			if box == valid {
+				isValid = true
+				break
+			}
+		}
+
+		if !isValid {
+			return errors.New(pkgErrors.SEARCH_BOX_INVALID)
+		}
+	}
+
+	return nil
+}
+
+// IsSearchPeriodInvalid validates whether the start of the period of a search is after its end.
+func IsSearchPeriodInvalid(from, to *time.Time) error {
+	if from != nil && to != nil && !from.Before(*to) {
+		return errors.New(pkgErrors.SEARCH_PERIOD_INVALID)
+	}
+
+	return nil
+}
+
+// IsPublishAtInvalid validates whether the time a reply is scheduled for is not in the future, or is after DRAFT_MAX_SCHEDULE.
+// Drafts that are not scheduled have no publishAt.
+func IsPublishAtInvalid(publishAt *time.Time, now time.Time) error {
+	if publishAt == nil {
+		return nil
+	}
+
+	if !publishAt.After(now) {
+		return errors.New(pkgErrors.DRAFT_PUBLISH_AT_PAST)
+	}
+
+	if publishAt.After(now.Add(DRAFT_MAX_SCHEDULE)) {
+		return errors.New(pkgErrors.DRAFT_PUBLISH_AT_TOO_FAR)
+	}
+
+	return nil
+}
+
+// DraftExists validates whether the question has a draft of its reply.
+func DraftExists(q *Question) error {
+	if q.Draft == nil {
+		return errors.New(pkgErrors.DRAFT_NOT_FOUND)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/quessapp/core-go/configs"
 	"github.com/quessapp/core-go/internal/users"
@@ -123,3 +124,195 @@ func SendEmailThanksForReporting(handlerCtx *configs.HandlersCtx, userToSendEmai
 		return
 	}
 }
+
+// SendEmailAccountLocked sends an email to the user whose account was temporarily locked after too many failed sign-in attempts.
+// It takes in the handlers context and the userToSendEmail object which contains the email address of the user.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailAccountLocked(handlerCtx *configs.HandlersCtx, userToSendEmail *users.User) error {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.Translate(handlerCtx, "emails_account_locked_subject"),
+		Body:    i18n.Translate(handlerCtx, "emails_account_locked_body"),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendEmailPasswordlessSignIn sends the passwordless sign-in link and code to the user.
+// It takes in the handlers context, the code, the link and the userToSendEmail object which contains the email address of the user.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailPasswordlessSignIn(handlerCtx *configs.HandlersCtx, code, link string, userToSendEmail *users.User) error {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.Translate(handlerCtx, "emails_passwordless_subject"),
+		Body:    fmt.Sprintf(i18n.Translate(handlerCtx, "emails_passwordless_body"), code) + link,
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendEmailAccountDeletionScheduled sends an email to the user that requested the deletion of the account,
+// with the date it will be deleted. The user can reactivate the account until then.
+// It is translated to the locale of the user. The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailAccountDeletionScheduled(handlerCtx *configs.HandlersCtx, userToSendEmail *users.User, deleteAt time.Time) error {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.TranslateLocale(userToSendEmail.Locale, "emails_account_deletion_scheduled_subject"),
+		Body:    fmt.Sprintf(i18n.TranslateLocale(userToSendEmail.Locale, "emails_account_deletion_scheduled_body"), deleteAt.Format("2006-01-02")),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendEmailAccountDeleted sends an email to the user confirming that the account and its data were deleted.
+// It is sent by the deletion job, outside of requests, so it is translated to the locale of the user.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailAccountDeleted(handlerCtx *configs.HandlersCtx, userToSendEmail *users.User) error {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.TranslateLocale(userToSendEmail.Locale, "emails_account_deleted_subject"),
+		Body:    i18n.TranslateLocale(userToSendEmail.Locale, "emails_account_deleted_body"),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendEmailDataExportReady sends an email to the user with the signed link to download the copy of the data
+// the user requested, and the date the link expires.
+// It is sent by the export job, outside of requests, so it is translated to the locale of the user.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailDataExportReady(handlerCtx *configs.HandlersCtx, userToSendEmail *users.User, downloadURL string, expiresAt time.Time) error {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.TranslateLocale(userToSendEmail.Locale, "emails_data_export_ready_subject"),
+		Body:    fmt.Sprintf(i18n.TranslateLocale(userToSendEmail.Locale, "emails_data_export_ready_body"), expiresAt.Format("2006-01-02"), downloadURL),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SendEmailNewFollowUp sends an email notification to the user that received a question, when the sender posts a follow-up.
+// The sender is never shown, since follow-ups keep the anonymity of the question. The email is translated to the locale of the receiver.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailNewFollowUp(handlerCtx *configs.HandlersCtx, content string, userToSendEmail *users.User) {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: i18n.TranslateLocale(userToSendEmail.Locale, "emails_new_follow_up_subject"),
+		Body:    fmt.Sprintf(`"%v"`, content),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+	}
+}
+
+// SendEmailFollowUpReplied sends an email notification to the sender of a question, when the receiver replies to a follow-up.
+// The email is translated to the locale of the sender.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailFollowUpReplied(handlerCtx *configs.HandlersCtx, content string, userThatReplied *users.User, userToSendEmail *users.User) {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: fmt.Sprintf(i18n.TranslateLocale(userToSendEmail.Locale, "emails_follow_up_replied_subject"), userThatReplied.Nick),
+		Body:    fmt.Sprintf(`"%v" - %v`, content, userThatReplied.Name),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+	}
+}
+
+// SendEmailQuestionReplied sends an email notification to the sender of a question, when the reply of the receiver is published.
+// The email is translated to the locale of the sender.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailQuestionReplied(handlerCtx *configs.HandlersCtx, content string, userThatReplied *users.User, userToSendEmail *users.User) {
+	email := toolkitEntities.Email{
+		To:      userToSendEmail.Email,
+		Subject: fmt.Sprintf(i18n.TranslateLocale(userToSendEmail.Locale, "emails_question_replied_subject"), userThatReplied.Nick),
+		Body:    fmt.Sprintf(`"%v" - %v`, content, userThatReplied.Name),
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+	}
+}
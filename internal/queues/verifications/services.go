@@ -0,0 +1,37 @@
+package verifications
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/queue"
+)
+
+// SendEmailVerification sends an email with the link that the user will use to verify an email address.
+// The address can be the current one, for users that just signed up, or a new one, for users that are changing their email.
+// It does not depend on the users package, so users can send verification emails without an import cycle.
+// The email is encrypted and sent using an AMQP channel and queue.
+func SendEmailVerification(handlerCtx *configs.HandlersCtx, sendToEmail, link string) error {
+	email := toolkitEntities.Email{
+		To:      sendToEmail,
+		Subject: i18n.Translate(handlerCtx, "emails_verify_email_subject"),
+		Body:    i18n.Translate(handlerCtx, "emails_verify_email_body") + link,
+	}
+
+	emailParsed, err := json.Marshal(email)
+
+	if err != nil {
+		log.Printf("fail to marshal %s", err)
+		return err
+	}
+
+	if err := queue.Publish(handlerCtx.MessageQueueCh, handlerCtx.EmailsQueue.Name, handlerCtx.Cfg.Crypto.Key, emailParsed); err != nil {
+		log.Printf("fail to send email to user %s \n", err)
+		return err
+	}
+
+	return nil
+}
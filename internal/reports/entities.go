@@ -3,6 +3,7 @@ package reports
 import (
 	"time"
 
+	"github.com/quessapp/core-go/pkg/pagination"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
 
@@ -18,6 +19,6 @@ type Report struct {
 
 // PaginatedReports is a model for paginated reports in app.
 type PaginatedReports struct {
-	Reports    *[]Report `json:"reports"`
-	TotalCount int64     `json:"totalCount"`
+	Reports *[]Report `json:"reports"`
+	pagination.Page
 }
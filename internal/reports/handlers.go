@@ -2,12 +2,12 @@ package reports
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/quessapp/core-go/configs"
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/internal/users"
 	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/core-go/pkg/pagination"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/quessapp/toolkit/responses"
 )
@@ -64,14 +64,14 @@ func FindReportByIDHandler(handlerCtx *configs.HandlersCtx, reportsRepository *R
 }
 
 // DeleteReportHandler is a function responsible for handling HTTP requests to delete a report.
-// It receives two parameters: handlerCtx and reportsRepository.
+// It receives three parameters: handlerCtx, reportsRepository and usersRepository.
 // handlerCtx is an instance of the HandlersCtx struct, which contains the fiber.Ctx and other context information.
 // reportsRepository is an instance of the ReportsRepository struct, which is used to access and modify report data.
 // It parses the ID parameter from the request context and the authenticated user ID from the token.
 // It calls the DeleteReport function passing the handlerCtx, report ID, authenticated user ID and reportsRepository as parameters.
 // If the DeleteReport function returns an error, it returns a bad request response.
 // Otherwise, it returns a successful response with status code 201.
-func DeleteReportHandler(handlerCtx *configs.HandlersCtx, reportsRepository *ReportsRepository) error {
+func DeleteReportHandler(handlerCtx *configs.HandlersCtx, reportsRepository *ReportsRepository, usersRepository *users.UsersRepository) error {
 	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
 
 	if err != nil {
@@ -80,7 +80,7 @@ func DeleteReportHandler(handlerCtx *configs.HandlersCtx, reportsRepository *Rep
 
 	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
 
-	if err := DeleteReport(handlerCtx, id, authenticatedUserID, reportsRepository); err != nil {
+	if err := DeleteReport(handlerCtx, id, authenticatedUserID, reportsRepository, usersRepository); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
@@ -88,24 +88,20 @@ func DeleteReportHandler(handlerCtx *configs.HandlersCtx, reportsRepository *Rep
 }
 
 // FindAllSentReportsHandler handles the HTTP request to get all sent reports from a given user.
-// It reads the authenticated user ID from the token, parses the "page" query parameter,
+// It reads the authenticated user ID from the token, parses the pagination query parameters,
 // and calls the FindAllSent function passing the necessary parameters to retrieve and sort the reports.
 // If an error occurs during parsing or retrieving the reports, it returns an HTTP response with the error message.
 // Otherwise, it returns an HTTP response with the retrieved reports.
 func FindAllSentReportsHandler(handlerCtx *configs.HandlersCtx, reportsRepository *ReportsRepository, usersRepository *users.UsersRepository, questionsRepository *questions.QuestionsRepository) error {
 	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
 
-	p, err := strconv.Atoi(handlerCtx.C.Query("page"))
-
-	page := int64(p)
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("reports:sent", authenticatedUserID.Hex()), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	sort := handlerCtx.C.Query("sort")
-
-	reports, err := FindAllSent(handlerCtx, &page, &sort, authenticatedUserID, reportsRepository, usersRepository, questionsRepository)
+	reports, err := FindAllSent(handlerCtx, params, authenticatedUserID, reportsRepository, usersRepository, questionsRepository)
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
@@ -4,12 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/quessapp/core-go/pkg/pagination"
 	collections "github.com/quessapp/toolkit/constants"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // ReportsRepository represents reports repository.
@@ -88,35 +88,19 @@ func (r *ReportsRepository) Delete(reportID toolkitEntities.ID) error {
 	return err
 }
 
-// FindAllSentReports retrieves all reports sent by the given user from the reports repository, using pagination and sorting parameters.
-// It returns a PaginatedReports struct, containing a list of Report objects and the total count of reports found.
-// It returns an error if there's a problem with the database operation.
-func (r *ReportsRepository) FindAllSentReports(userID toolkitEntities.ID, page *int64, sort *string) (*PaginatedReports, error) {
-	var LIMIT int64 = 30
-
+// FindAllSentReports retrieves all reports sent by the given user from the reports repository, using the pagination params.
+// It returns a PaginatedReports struct, containing a list of Report objects and the cursors of the page, along with the total count
+// of reports found when asked. It returns an error if there's a problem with the database operation.
+func (r *ReportsRepository) FindAllSentReports(userID toolkitEntities.ID, params *pagination.Params) (*PaginatedReports, error) {
 	coll := r.db.Collection(collections.REPORTS)
 
-	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
-
-	if *sort == "desc" {
-		findOptions = options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
-	}
-
-	findOptions.SetSkip((*page - 1) * LIMIT)
-	findOptions.SetLimit(LIMIT)
-
-	countOptions := options.Count()
-
-	countOptions.SetSkip((*page - 1) * LIMIT)
-	countOptions.SetLimit(LIMIT)
-
 	findFilterOptions := bson.D{
 		{Key: "sentBy", Value: userID},
 	}
 
 	reports := []Report{}
 
-	cursor, err := coll.Find(context.Background(), findFilterOptions, findOptions)
+	cursor, err := coll.Find(context.Background(), params.Filter(findFilterOptions), params.FindOptions())
 
 	if err != nil {
 		return nil, err
@@ -126,15 +110,23 @@ func (r *ReportsRepository) FindAllSentReports(userID toolkitEntities.ID, page *
 		return nil, err
 	}
 
-	totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions, countOptions)
+	reports, page := pagination.NewPage(params, reports, func(r Report) pagination.Position {
+		return pagination.Position{CreatedAt: r.CreatedAt, ID: r.ID}
+	})
 
-	if err != nil {
-		return nil, err
+	if params.WithTotal {
+		totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page.TotalCount = &totalCount
 	}
 
 	result := PaginatedReports{
-		TotalCount: totalCount,
-		Reports:    &reports,
+		Reports: &reports,
+		Page:    *page,
 	}
 
 	return &result, nil
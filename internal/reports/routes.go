@@ -15,7 +15,7 @@ import (
 // usersRepository is the repository for users.
 // reportsRepository is the repository for reports.
 func LoadRoutes(AppCtx *configs.AppCtx, questionsRepository *questions.QuestionsRepository, usersRepository *users.UsersRepository, reportsRepository *ReportsRepository) {
-	g := AppCtx.App.Group("/reports", middlewares.JWTMiddleware(AppCtx.App, AppCtx.Cfg))
+	g := AppCtx.App.Group("/reports", middlewares.JWTMiddleware(AppCtx))
 
 	g.Post("/send", func(c *fiber.Ctx) error {
 		return CreateReportHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, questionsRepository, usersRepository, reportsRepository)
@@ -30,6 +30,6 @@ func LoadRoutes(AppCtx *configs.AppCtx, questionsRepository *questions.Questions
 		return ListAllKindReasonsOfReportsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx})
 	})
 	g.Delete("/:id", func(c *fiber.Ctx) error {
-		return DeleteReportHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, reportsRepository)
+		return DeleteReportHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, reportsRepository, usersRepository)
 	})
 }
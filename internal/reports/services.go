@@ -7,6 +7,7 @@ import (
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/internal/queues/emails"
 	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/pagination"
 	pkgReports "github.com/quessapp/core-go/pkg/reports"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 )
@@ -51,7 +52,7 @@ func CreateReport(handlerCtx *configs.HandlersCtx, payload *CreateReportDTO, aut
 			return err
 		}
 
-		if err := IsReportingYourself(authenticatedUserID, q.SentBy.(toolkitEntities.ID)); err != nil {
+		if err := IsReportingYourself(authenticatedUserID, q.GetSentByID()); err != nil {
 			return err
 		}
 	}
@@ -67,6 +68,7 @@ func CreateReport(handlerCtx *configs.HandlersCtx, payload *CreateReportDTO, aut
 }
 
 // FindReportByID retrieves a report with the given ID and verifies whether the authenticated user is authorized to view it.
+// Users whose role grants users.PERMISSION_REPORTS_REVIEW can view any report.
 // If the report is about a user or a question, this function will also fetch additional data
 // about the reported user/question to be displayed in the UI
 // It takes four parameters: handlerCtx, reportID, authenticatedUserID, and reportsRepository.
@@ -86,8 +88,11 @@ func FindReportByID(handlerCtx *configs.HandlersCtx, reportID, authenticatedUser
 		return nil, err
 	}
 
-	if err := CanViewReport(r, authenticatedUserID); err != nil {
-		return nil, err
+	// staff that review reports can view the reports sent by any user
+	if !usersRepository.FindUserByID(authenticatedUserID).HasPermissions(users.PERMISSION_REPORTS_REVIEW) {
+		if err := CanViewReport(r, authenticatedUserID); err != nil {
+			return nil, err
+		}
 	}
 
 	// if the user reported an user
@@ -106,7 +111,7 @@ func FindReportByID(handlerCtx *configs.HandlersCtx, reportID, authenticatedUser
 
 	if r.Type == "question" {
 		q := questionsRepository.FindQuestionByID(r.SendTo.(toolkitEntities.ID))
-		u := usersRepository.FindUserByID(q.SentBy.(toolkitEntities.ID))
+		u := usersRepository.FindUserByID(q.GetSentByID())
 
 		// if the user reported a question
 		// we would like to show who sent the reported question
@@ -129,34 +134,19 @@ func FindReportByID(handlerCtx *configs.HandlersCtx, reportID, authenticatedUser
 }
 
 // FindAllSent returns a paginated list of reports sent by the authenticated user, sorted by a specified field and order.
-// If the page or sort parameters are nil, they will be set to their default values.
+// The page size, order and cursor are given by the pagination params.
 // The reports are retrieved from the provided ReportsRepository, and the user and question data is retrieved from their respective repositories.
 // If a report refers to a user, its SendTo field will be replaced with the user's data, to be shown in the UI.
 // If a report refers to a question, its SendTo field will be replaced with the question's data, along with the user who sent it, to be shown in the UI.
 // The resulting paginated list of reports is returned, along with an error if one occurs during the retrieval process.
-func FindAllSent(handlerCtx *configs.HandlersCtx, page *int64, sort *string, authenticatedUserID toolkitEntities.ID, reportsRepository *ReportsRepository, usersRepository *users.UsersRepository, questionsRepository *questions.QuestionsRepository) (*PaginatedReports, error) {
-	if *page == 0 {
-		*page = 1
-	}
-
-	if *sort == "" {
-		*sort = "asc"
-	}
-
-	reports, err := reportsRepository.FindAllSentReports(authenticatedUserID, page, sort)
+func FindAllSent(handlerCtx *configs.HandlersCtx, params *pagination.Params, authenticatedUserID toolkitEntities.ID, reportsRepository *ReportsRepository, usersRepository *users.UsersRepository, questionsRepository *questions.QuestionsRepository) (*PaginatedReports, error) {
+	reports, err := reportsRepository.FindAllSentReports(authenticatedUserID, params)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if len(*reports.Reports) == 0 {
-		return &PaginatedReports{
-			Reports:    &[]Report{},
-			TotalCount: 0,
-		}, nil
-	}
-
-	var allReports []Report
+	allReports := []Report{}
 
 	for _, r := range *reports.Reports {
 		if r.Type == "user" {
@@ -189,7 +179,7 @@ func FindAllSent(handlerCtx *configs.HandlersCtx, page *int64, sort *string, aut
 			}
 
 			// get sender question data
-			u := usersRepository.FindUserByID(q.SentBy.(toolkitEntities.ID))
+			u := usersRepository.FindUserByID(q.GetSentByID())
 
 			// if the user reported a question
 			// we would like to show who sent the reported question
@@ -212,8 +202,8 @@ func FindAllSent(handlerCtx *configs.HandlersCtx, page *int64, sort *string, aut
 	}
 
 	result := PaginatedReports{
-		Reports:    &allReports,
-		TotalCount: reports.TotalCount,
+		Reports: &allReports,
+		Page:    reports.Page,
 	}
 
 	return &result, nil
@@ -225,8 +215,9 @@ func FindAllSent(handlerCtx *configs.HandlersCtx, page *int64, sort *string, aut
 // reportID is an instance of the toolkitEntities.ID struct, which represents the ID of the report to be deleted.
 // authenticatedUserID is an instance of the toolkitEntities.ID struct, which represents the ID of the user making the request.
 // reportsRepository is an instance of the ReportsRepository struct, which is used to access and modify report data.
+// usersRepository is used to check whether the authenticated user can review the reports of any user, see users.PERMISSION_REPORTS_REVIEW.
 // It returns an error if there was an issue deleting the report or if the user is not authorized to perform this action.
-func DeleteReport(handlerCtx *configs.HandlersCtx, reportID, authenticatedUserID toolkitEntities.ID, reportsRepository *ReportsRepository) error {
+func DeleteReport(handlerCtx *configs.HandlersCtx, reportID, authenticatedUserID toolkitEntities.ID, reportsRepository *ReportsRepository, usersRepository *users.UsersRepository) error {
 	r, err := reportsRepository.FindByID(reportID)
 
 	if err != nil {
@@ -237,12 +228,15 @@ func DeleteReport(handlerCtx *configs.HandlersCtx, reportID, authenticatedUserID
 		return err
 	}
 
-	if err := CanUserDeleteReport(r, authenticatedUserID); err != nil {
-		return err
-	}
+	// staff that review reports can delete the reports sent by any user
+	if !usersRepository.FindUserByID(authenticatedUserID).HasPermissions(users.PERMISSION_REPORTS_REVIEW) {
+		if err := CanUserDeleteReport(r, authenticatedUserID); err != nil {
+			return err
+		}
 
-	if err := CanViewReport(r, authenticatedUserID); err != nil {
-		return err
+		if err := CanViewReport(r, authenticatedUserID); err != nil {
+			return err
+		}
 	}
 
 	if err := reportsRepository.Delete(reportID); err != nil {
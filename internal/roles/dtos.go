@@ -0,0 +1,34 @@
+package roles
+
+import (
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ChangeRoleDTO is DTO for payload for change role handler.
+type ChangeRoleDTO struct {
+	// Role is the new role of the user, see users.ROLES.
+	Role   string
+	Reason string
+}
+
+// Validate is a method of ChangeRoleDTO that validates the fields of the struct.
+// The Role field is required and must be one of users.ROLES.
+// The Reason field is required and must have at most REASON_MAX_LENGTH characters.
+func (d ChangeRoleDTO) Validate() error {
+	roles := make([]any, len(users.ROLES))
+
+	for i, role := range users.ROLES {
+		roles[i] = role
+	}
+
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Role, validation.Required.Error(pkgErrors.ROLE_REQUIRED), validation.In(roles...).Error(pkgErrors.ROLE_INVALID)),
+		validation.Field(&d.Reason, validation.Required.Error(pkgErrors.REASON_FIELD_REQUIRED), validation.Length(1, REASON_MAX_LENGTH).Error(pkgErrors.ROLE_CHANGE_REASON_LENGTH)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
@@ -0,0 +1,31 @@
+package roles
+
+import (
+	"time"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// Sources of the role changes.
+const (
+	SOURCE_API = "api"
+	SOURCE_CLI = "cli"
+)
+
+// REASON_MAX_LENGTH is the maximum length of the reason of a role change.
+const REASON_MAX_LENGTH = 500
+
+// RoleChange is the audit entry of a change of the role of an user. Entries are never deleted: when the account of
+// the user is deleted, UserID is anonymised and the entry is kept.
+type RoleChange struct {
+	ID           toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID       toolkitEntities.ID `json:"userId" bson:"userId"`
+	PreviousRole string             `json:"previousRole" bson:"previousRole"`
+	Role         string             `json:"role" bson:"role"`
+	Reason       string             `json:"reason" bson:"reason"`
+	// ChangedBy is the admin that changed the role. It is nil for changes made with the CLI.
+	ChangedBy *toolkitEntities.ID `json:"changedBy" bson:"changedBy"`
+	// Source is where the change was made, SOURCE_API or SOURCE_CLI.
+	Source    string    `json:"source" bson:"source"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,53 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// ChangeRoleHandler changes the role of the user with the given ID. The authenticated admin is recorded on the audit trail.
+func ChangeRoleHandler(handlerCtx *configs.HandlersCtx, rolesRepository *RolesRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	payload := ChangeRoleDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	change, err := ChangeRole(&payload, id, &authenticatedUserID, rolesRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, change)
+}
+
+// GetRoleChangesHandler returns the role changes of the user with the given ID.
+func GetRoleChangesHandler(handlerCtx *configs.HandlersCtx, rolesRepository *RolesRepository, usersRepository *users.UsersRepository) error {
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	changes, err := GetRoleChanges(id, rolesRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusNotFound, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, changes)
+}
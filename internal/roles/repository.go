@@ -0,0 +1,53 @@
+package roles
+
+import (
+	"context"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RolesRepository represents the role changes repository.
+type RolesRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns the role changes repository.
+func NewRepository(db *mongo.Database) *RolesRepository {
+	return &RolesRepository{db}
+}
+
+// Create inserts a new role change.
+func (r *RolesRepository) Create(change *RoleChange) error {
+	coll := r.db.Collection(pkgConstants.ROLE_CHANGES)
+
+	_, err := coll.InsertOne(context.Background(), change)
+
+	return err
+}
+
+// FindUserRoleChanges finds the role changes of an user, the newest first.
+func (r *RolesRepository) FindUserRoleChanges(userID toolkitEntities.ID) (*[]RoleChange, error) {
+	coll := r.db.Collection(pkgConstants.ROLE_CHANGES)
+
+	filter := bson.D{{Key: "userId", Value: userID}}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []RoleChange{}
+
+	if err := cursor.All(context.Background(), &changes); err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
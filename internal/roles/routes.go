@@ -0,0 +1,23 @@
+package roles
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the admin routes for the roles.
+// It takes in an AppCtx, a RolesRepository, and a UsersRepository.
+// Only signed in users whose role grants users.PERMISSION_ROLES_MANAGE can use them.
+func LoadRoutes(AppCtx *configs.AppCtx, rolesRepository *RolesRepository, usersRepository *users.UsersRepository) {
+	g := AppCtx.App.Group("/admin/users/:id/role", middlewares.JWTMiddleware(AppCtx), middlewares.PolicyMiddleware(AppCtx, users.PERMISSION_ROLES_MANAGE))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetRoleChangesHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, rolesRepository, usersRepository)
+	})
+	g.Patch("/", func(c *fiber.Ctx) error {
+		return ChangeRoleHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, rolesRepository, usersRepository)
+	})
+}
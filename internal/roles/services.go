@@ -0,0 +1,105 @@
+package roles
+
+import (
+	"log"
+	"time"
+
+	"github.com/quessapp/core-go/internal/users"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// Policy checks the permissions of users by their role, see configs.Policy.
+type Policy struct {
+	usersRepository *users.UsersRepository
+}
+
+// NewPolicy creates a Policy that reads the roles of the users from the users repository.
+func NewPolicy(usersRepository *users.UsersRepository) *Policy {
+	return &Policy{usersRepository}
+}
+
+// HasPermissions returns true if the role of the user with the given ID grants all the given permissions.
+func (p *Policy) HasPermissions(userID string, permissions ...string) bool {
+	id, err := toolkitEntities.ParseID(userID)
+
+	if err != nil {
+		return false
+	}
+
+	u := p.usersRepository.FindUserByID(id)
+
+	if err := users.UserExists(u); err != nil {
+		return false
+	}
+
+	return u.HasPermissions(permissions...)
+}
+
+// ChangeRole changes the role of an user and records the change on the audit trail.
+// changedBy is the admin that made the change through the API, or nil if it was made with the CLI.
+// Admins can't change their own role, and the last admin can't lose the role.
+func ChangeRole(payload *ChangeRoleDTO, userID toolkitEntities.ID, changedBy *toolkitEntities.ID, rolesRepository *RolesRepository, usersRepository *users.UsersRepository) (*RoleChange, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(userID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsNotChangingOwnRole(u, changedBy); err != nil {
+		return nil, err
+	}
+
+	if err := IsNotSameRole(u, payload.Role); err != nil {
+		return nil, err
+	}
+
+	adminsCount, err := usersRepository.CountUsersWithRole(users.ROLE_ADMIN)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := IsNotLastAdmin(u, payload.Role, adminsCount); err != nil {
+		return nil, err
+	}
+
+	change := &RoleChange{
+		ID:           toolkitEntities.NewID(),
+		UserID:       u.ID,
+		PreviousRole: u.GetRole(),
+		Role:         payload.Role,
+		Reason:       payload.Reason,
+		ChangedBy:    changedBy,
+		Source:       SOURCE_API,
+		CreatedAt:    time.Now(),
+	}
+
+	if changedBy == nil {
+		change.Source = SOURCE_CLI
+	}
+
+	if err := usersRepository.UpdateRole(u.ID, payload.Role); err != nil {
+		return nil, err
+	}
+
+	if err := rolesRepository.Create(change); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Role of user %s changed from %s to %s (%s): %s", u.Nick, change.PreviousRole, change.Role, change.Source, change.Reason)
+
+	return change, nil
+}
+
+// GetRoleChanges returns the audit trail of the role changes of an user.
+func GetRoleChanges(userID toolkitEntities.ID, rolesRepository *RolesRepository, usersRepository *users.UsersRepository) (*[]RoleChange, error) {
+	if err := users.UserExists(usersRepository.FindUserByID(userID)); err != nil {
+		return nil, err
+	}
+
+	return rolesRepository.FindUserRoleChanges(userID)
+}
@@ -0,0 +1,38 @@
+package roles
+
+import (
+	"errors"
+
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// IsNotSameRole returns error if the user already has the given role.
+func IsNotSameRole(u *users.User, role string) error {
+	if u.GetRole() == role {
+		return errors.New(pkgErrors.ROLE_ALREADY_ASSIGNED)
+	}
+
+	return nil
+}
+
+// IsNotChangingOwnRole returns error if an admin is trying to change their own role.
+// Changes made with the CLI have no admin, so they are always allowed.
+func IsNotChangingOwnRole(u *users.User, changedBy *toolkitEntities.ID) error {
+	if changedBy != nil && *changedBy == u.ID {
+		return errors.New(pkgErrors.CANT_CHANGE_OWN_ROLE)
+	}
+
+	return nil
+}
+
+// IsNotLastAdmin returns error if the user is the only admin and is losing the role,
+// so there is always someone that can manage the roles through the API.
+func IsNotLastAdmin(u *users.User, role string, adminsCount int64) error {
+	if u.GetRole() == users.ROLE_ADMIN && role != users.ROLE_ADMIN && adminsCount <= 1 {
+		return errors.New(pkgErrors.CANT_REMOVE_LAST_ADMIN)
+	}
+
+	return nil
+}
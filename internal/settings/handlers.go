@@ -30,3 +30,22 @@ func UpdatePreferencesHandler(handlerCtx *configs.HandlersCtx, usersRepository *
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
+
+// UpdateInboxHandler is an HTTP request handler function that updates who can send questions to the user and how.
+// It parses the request body into an UpdateInboxDTO object and calls the UpdateInbox function.
+// If any error occurs during this process, it returns an error response with a 400 Bad Request status code.
+func UpdateInboxHandler(handlerCtx *configs.HandlersCtx, usersRepository *users.UsersRepository) error {
+	payload := users.UpdateInboxDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := UpdateInbox(handlerCtx, &payload, authenticatedUserID, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
@@ -12,9 +12,12 @@ import (
 // AppCtx is the application context.
 // UsersRepository is the repository for users.
 func LoadRoutes(AppCtx *configs.AppCtx, usersRepository *users.UsersRepository) {
-	g := AppCtx.App.Group("/settings", middlewares.JWTMiddleware(AppCtx.App, AppCtx.Cfg))
+	g := AppCtx.App.Group("/settings", middlewares.JWTMiddleware(AppCtx))
 
 	g.Patch("/preferences", func(c *fiber.Ctx) error {
 		return UpdatePreferencesHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
+	g.Patch("/inbox", func(c *fiber.Ctx) error {
+		return UpdateInboxHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
+	})
 }
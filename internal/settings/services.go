@@ -17,3 +17,13 @@ func UpdatePreferences(handlerCtx *configs.HandlersCtx, payload *users.UpdatePre
 
 	return usersRepository.UpdatePreferences(authenticatedUserID, payload)
 }
+
+// UpdateInbox updates who can send questions to the user and how, see users.Inbox.
+// The settings are enforced when the questions are sent, see questions.CreateQuestion.
+func UpdateInbox(handlerCtx *configs.HandlersCtx, payload *users.UpdateInboxDTO, authenticatedUserID toolkitEntities.ID, usersRepository *users.UsersRepository) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	return usersRepository.UpdateInbox(authenticatedUserID, payload)
+}
@@ -0,0 +1,35 @@
+package signingkeys
+
+import (
+	"time"
+
+	toolkitConstants "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+const (
+	// RELOAD_INTERVAL is how often the keys are reloaded from the database, to know the keys created by other instances of the API.
+	RELOAD_INTERVAL = time.Minute
+	// DEFAULT_ROTATION_INTERVAL is how long a key signs new tokens before a new one is created.
+	DEFAULT_ROTATION_INTERVAL = toolkitConstants.THIRTY_DAYS_IN_HOURS
+	// TOKENS_MAX_EXPIRES_IN is the longest lifetime of the tokens signed by the keys, the refresh tokens.
+	// Keys keep verifying tokens for this long after they are rotated.
+	TOKENS_MAX_EXPIRES_IN = toolkitConstants.THIRTY_DAYS_IN_HOURS
+	// JWKS_MAX_AGE is how many seconds clients may cache the JWKS.
+	JWKS_MAX_AGE = 300
+	// ACTIVATION_DELAY is how long a new key is only published before it signs tokens,
+	// so every instance reloaded it and every client refreshed its cached JWKS.
+	ACTIVATION_DELAY = RELOAD_INTERVAL + JWKS_MAX_AGE*time.Second
+)
+
+// SigningKey is a JWT signing key stored in the database, shared by every instance of the API.
+type SigningKey struct {
+	ID        toolkitEntities.ID `json:"id" bson:"_id"`
+	KeyID     string             `json:"kid" bson:"kid"`
+	Algorithm string             `json:"algorithm" bson:"algorithm"`
+	// PrivateKey is the PKCS #8 PEM private key, encrypted with the cipher key.
+	PrivateKey  string    `json:"-" bson:"privateKey"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	ActivatesAt time.Time `json:"activatesAt" bson:"activatesAt"`
+	ExpiresAt   time.Time `json:"expiresAt" bson:"expiresAt"`
+}
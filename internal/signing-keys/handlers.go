@@ -0,0 +1,16 @@
+package signingkeys
+
+import (
+	"fmt"
+
+	"github.com/quessapp/core-go/configs"
+)
+
+// GetJWKSHandler is an HTTP handler function that publishes the public keys of the keyring as a JSON Web Key Set,
+// so other services can verify the tokens issued by the API without sharing a secret.
+// The set is returned as is, without the response envelope, as expected by JWKS clients.
+func GetJWKSHandler(handlerCtx *configs.HandlersCtx) error {
+	handlerCtx.C.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", JWKS_MAX_AGE))
+
+	return handlerCtx.C.JSON(handlerCtx.Keyring.JWKS())
+}
@@ -0,0 +1,68 @@
+package signingkeys
+
+import (
+	"context"
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SigningKeysRepository represents signing keys repository.
+type SigningKeysRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns signing keys repository.
+func NewRepository(db *mongo.Database) *SigningKeysRepository {
+	return &SigningKeysRepository{db}
+}
+
+// FindKeys finds the keys that did not expire, from the oldest to the newest.
+func (s *SigningKeysRepository) FindKeys() ([]SigningKey, error) {
+	coll := s.db.Collection(pkgConstants.SIGNING_KEYS)
+
+	filter := bson.D{
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []SigningKey{}
+
+	if err := cursor.All(context.Background(), &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// CreateKey inserts a new key.
+func (s *SigningKeysRepository) CreateKey(key *SigningKey) error {
+	coll := s.db.Collection(pkgConstants.SIGNING_KEYS)
+
+	_, err := coll.InsertOne(context.Background(), key)
+
+	return err
+}
+
+// DeleteExpiredKeys deletes the keys that expired.
+func (s *SigningKeysRepository) DeleteExpiredKeys() error {
+	coll := s.db.Collection(pkgConstants.SIGNING_KEYS)
+
+	filter := bson.D{
+		{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: time.Now()}}},
+	}
+
+	_, err := coll.DeleteMany(context.Background(), filter)
+
+	return err
+}
@@ -0,0 +1,15 @@
+package signingkeys
+
+import (
+	"github.com/quessapp/core-go/configs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the route of the JWKS.
+// It takes in an AppCtx.
+func LoadRoutes(AppCtx *configs.AppCtx) {
+	AppCtx.App.Get("/.well-known/jwks.json", func(c *fiber.Ctx) error {
+		return GetJWKSHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx})
+	})
+}
@@ -0,0 +1,174 @@
+package signingkeys
+
+import (
+	"log"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/toolkit/crypto"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// GetAlgorithm returns the configured JWT_ALGORITHM, defaulting to HS256.
+func GetAlgorithm(cfg *configs.Conf) string {
+	if cfg.JWT.Algorithm == "" {
+		return keyring.HS256
+	}
+
+	return cfg.JWT.Algorithm
+}
+
+// GetRotationInterval returns the configured JWT_KEY_ROTATION_INTERVAL, defaulting to DEFAULT_ROTATION_INTERVAL.
+func GetRotationInterval(cfg *configs.Conf) time.Duration {
+	if cfg.JWT.KeyRotationInterval > 0 {
+		return time.Hour * time.Duration(cfg.JWT.KeyRotationInterval)
+	}
+
+	return DEFAULT_ROTATION_INTERVAL
+}
+
+// InitKeyring creates the keyring of the configured algorithm.
+// HS256 keyrings sign the tokens with JWT_SECRET, like before the keyring.
+// Asymmetric keyrings load the keys from the database, creating the first one if needed,
+// and keep reloading and rotating them on the background. JWT_SECRET, if set, still verifies the HS256 tokens issued before.
+func InitKeyring(cfg *configs.Conf, signingKeysRepository *SigningKeysRepository) (*keyring.Keyring, error) {
+	kr := keyring.New(cfg.JWT.Secret)
+	algorithm := GetAlgorithm(cfg)
+
+	if algorithm == keyring.HS256 {
+		return kr, nil
+	}
+
+	if err := Rotate(cfg, kr, signingKeysRepository); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for range time.Tick(RELOAD_INTERVAL) {
+			if err := Rotate(cfg, kr, signingKeysRepository); err != nil {
+				log.Printf("Error rotating signing keys: %v", err)
+			}
+		}
+	}()
+
+	return kr, nil
+}
+
+// Rotate reloads the keys of the keyring from the database and creates the next key when the active one
+// is older than the rotation interval or does not use the configured algorithm.
+// The next key only verifies tokens for ACTIVATION_DELAY before it signs them, so the tokens it signs are accepted by
+// every instance and by the clients of the JWKS. The first key of the keyring is active right away, since there is no
+// other key to sign tokens meanwhile.
+// The previous keys keep verifying tokens until they expire, TOKENS_MAX_EXPIRES_IN after they are rotated.
+func Rotate(cfg *configs.Conf, kr *keyring.Keyring, signingKeysRepository *SigningKeysRepository) error {
+	keys, err := loadKeys(cfg, signingKeysRepository)
+
+	if err != nil {
+		return err
+	}
+
+	kr.SetKeys(keys)
+
+	algorithm := GetAlgorithm(cfg)
+	interval := GetRotationInterval(cfg)
+	active := kr.SigningKey()
+
+	if kr.NextKey() != nil {
+		return nil
+	}
+
+	if active != nil && active.Algorithm == algorithm && time.Since(active.ActivatesAt) < interval {
+		return nil
+	}
+
+	delay := ACTIVATION_DELAY
+
+	if active == nil {
+		delay = 0
+	}
+
+	key, err := keyring.GenerateKey(algorithm, delay+interval+TOKENS_MAX_EXPIRES_IN)
+
+	if err != nil {
+		return err
+	}
+
+	key.ActivatesAt = key.CreatedAt.Add(delay)
+
+	if err := createKey(cfg, key, signingKeysRepository); err != nil {
+		return err
+	}
+
+	kr.SetKeys(append(keys, key))
+
+	if err := signingKeysRepository.DeleteExpiredKeys(); err != nil {
+		log.Printf("Error deleting expired signing keys: %v", err)
+	}
+
+	return nil
+}
+
+// loadKeys finds and decrypts the keys that did not expire.
+// Keys that can't be decrypted, like keys encrypted with a previous cipher key, are skipped.
+func loadKeys(cfg *configs.Conf, signingKeysRepository *SigningKeysRepository) ([]*keyring.Key, error) {
+	storedKeys, err := signingKeysRepository.FindKeys()
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*keyring.Key{}
+
+	for _, k := range storedKeys {
+		decrypted, err := crypto.Decrypt(k.PrivateKey, cfg.Crypto.Key)
+
+		if err != nil {
+			log.Printf("Error decrypting signing key %v: %v", k.KeyID, err)
+			continue
+		}
+
+		privateKey, err := keyring.ParsePrivateKey(k.Algorithm, decrypted)
+
+		if err != nil {
+			log.Printf("Error parsing signing key %v: %v", k.KeyID, err)
+			continue
+		}
+
+		keys = append(keys, &keyring.Key{
+			ID:          k.KeyID,
+			Algorithm:   k.Algorithm,
+			PrivateKey:  privateKey,
+			CreatedAt:   k.CreatedAt,
+			ActivatesAt: k.ActivatesAt,
+			ExpiresAt:   k.ExpiresAt,
+		})
+	}
+
+	return keys, nil
+}
+
+// createKey encrypts the private key with the cipher key and stores the key.
+func createKey(cfg *configs.Conf, key *keyring.Key, signingKeysRepository *SigningKeysRepository) error {
+	encoded, err := keyring.MarshalPrivateKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := crypto.Encrypt(encoded, cfg.Crypto.Key)
+
+	if err != nil {
+		return err
+	}
+
+	return signingKeysRepository.CreateKey(&SigningKey{
+		ID:          toolkitEntities.NewID(),
+		KeyID:       key.ID,
+		Algorithm:   key.Algorithm,
+		PrivateKey:  encrypted,
+		CreatedAt:   key.CreatedAt,
+		ActivatesAt: key.ActivatesAt,
+		ExpiresAt:   key.ExpiresAt,
+	})
+}
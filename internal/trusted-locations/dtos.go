@@ -0,0 +1,64 @@
+package trustedlocations
+
+import (
+	"errors"
+	"net"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// TrustLocationDTO is DTO for payload for trust location handler. Either CIDR or ASN is required.
+type TrustLocationDTO struct {
+	// CIDR is a network in CIDR notation, like 177.10.0.0/16.
+	CIDR string
+	// ASN is the number of an autonomous system. Only the autonomous system of the request IP can be trusted.
+	ASN uint
+	// Code is a two-factor code (or a recovery code), required when the user has two-factor authentication enabled,
+	// since signing in from a trusted location skips it.
+	Code string
+}
+
+// checkIfCIDRIsAllowed returns error if the given value is not a network in CIDR notation,
+// or if the network is broader than MIN_IPV4_PREFIX or MIN_IPV6_PREFIX.
+func checkIfCIDRIsAllowed(value any) error {
+	cidr, _ := value.(string)
+
+	if cidr == "" {
+		return nil
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+
+	if err != nil {
+		return errors.New(pkgErrors.TRUSTED_LOCATION_CIDR_INVALID)
+	}
+
+	prefix, bits := network.Mask.Size()
+
+	if (bits == 32 && prefix < MIN_IPV4_PREFIX) || (bits == 128 && prefix < MIN_IPV6_PREFIX) {
+		return errors.New(pkgErrors.TRUSTED_LOCATION_CIDR_TOO_BROAD)
+	}
+
+	return nil
+}
+
+// Validate is a method of TrustLocationDTO that validates the fields of the struct.
+// The CIDR field is required when the ASN field is empty, and must be a network that is not too broad, see checkIfCIDRIsAllowed.
+func (d TrustLocationDTO) Validate() error {
+	cidrRules := []validation.Rule{}
+
+	if d.ASN == 0 {
+		cidrRules = append(cidrRules, validation.Required.Error(pkgErrors.TRUSTED_LOCATION_REQUIRED))
+	}
+
+	cidrRules = append(cidrRules, validation.By(checkIfCIDRIsAllowed))
+
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.CIDR, cidrRules...),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
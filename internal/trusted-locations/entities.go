@@ -0,0 +1,75 @@
+package trustedlocations
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/geoip"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// Kinds of trusted locations.
+const (
+	// KIND_IP trusts a single IP. They are added when the user signs in and asks to trust the IP.
+	KIND_IP = "ip"
+	// KIND_CIDR trusts every IP of a network.
+	KIND_CIDR = "cidr"
+	// KIND_ASN trusts every IP announced by an autonomous system, like the network of an ISP or a company.
+	KIND_ASN = "asn"
+)
+
+// EXPIRES_IN is how long a trusted location is kept after it was last seen.
+const EXPIRES_IN = time.Hour * 24 * 90
+
+// MIN_IPV4_PREFIX and MIN_IPV6_PREFIX are the prefix lengths of the broadest networks that can be trusted.
+const (
+	MIN_IPV4_PREFIX = 16
+	MIN_IPV6_PREFIX = 32
+)
+
+// TrustedLocation is a model for an IP, a network or an autonomous system trusted by an user.
+// Users with two-factor authentication enabled are not asked for a code when signing in from a trusted location.
+type TrustedLocation struct {
+	ID     toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID toolkitEntities.ID `json:"-" bson:"userId"`
+	Kind   string             `json:"kind" bson:"kind"`
+	// Value is the IP, the network in CIDR notation or the autonomous system (like AS15169), depending on the kind.
+	Value string `json:"value" bson:"value"`
+	// ASN is the number of the autonomous system. For IPs, it is the one the IP belonged to when it was trusted.
+	ASN          uint                      `json:"asn,omitempty" bson:"asn,omitempty"`
+	Organization string                    `json:"organization,omitempty" bson:"organization,omitempty"`
+	Location     *toolkitEntities.Location `json:"location,omitempty" bson:"location,omitempty"`
+	// Current tells if the location is the one of the request. It is not stored.
+	Current     bool      `json:"current" bson:"-"`
+	FirstSeenAt time.Time `json:"firstSeenAt" bson:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt" bson:"lastSeenAt"`
+	ExpiresAt   time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// Matches returns true if the IP belongs to the location. The ASN is the autonomous system of the IP, and can be nil if it is unknown.
+func (l *TrustedLocation) Matches(ip net.IP, asn *geoip.ASN) bool {
+	if ip == nil {
+		return false
+	}
+
+	switch l.Kind {
+	case KIND_IP:
+		trustedIP := net.ParseIP(l.Value)
+
+		return trustedIP != nil && trustedIP.Equal(ip)
+	case KIND_CIDR:
+		_, network, err := net.ParseCIDR(l.Value)
+
+		return err == nil && network.Contains(ip)
+	case KIND_ASN:
+		return asn != nil && asn.Number == l.ASN
+	}
+
+	return false
+}
+
+// FormatASN formats the number of an autonomous system, like AS15169.
+func FormatASN(number uint) string {
+	return fmt.Sprintf("AS%d", number)
+}
@@ -0,0 +1,59 @@
+package trustedlocations
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/i18n"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// GetTrustedLocationsHandler returns the trusted locations of the authenticated user.
+func GetTrustedLocationsHandler(handlerCtx *configs.HandlersCtx, trustedLocationsRepository *TrustedLocationsRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	locations, err := GetTrustedLocations(handlerCtx, authenticatedUserID, trustedLocationsRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, locations)
+}
+
+// TrustLocationHandler trusts a network or an autonomous system for the authenticated user.
+func TrustLocationHandler(handlerCtx *configs.HandlersCtx, trustedLocationsRepository *TrustedLocationsRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+	payload := TrustLocationDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	l, err := TrustLocation(handlerCtx, &payload, authenticatedUserID, trustedLocationsRepository, usersRepository, twoFactorRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, l)
+}
+
+// RemoveTrustedLocationHandler removes a trusted location of the authenticated user.
+func RemoveTrustedLocationHandler(handlerCtx *configs.HandlersCtx, trustedLocationsRepository *TrustedLocationsRepository) error {
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+	id, err := toolkitEntities.ParseID(handlerCtx.C.Params("id"))
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	if err := RemoveTrustedLocation(handlerCtx, id, authenticatedUserID, trustedLocationsRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
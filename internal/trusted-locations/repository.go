@@ -0,0 +1,169 @@
+package trustedlocations
+
+import (
+	"context"
+	"time"
+
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TrustedLocationsRepository represents trusted locations repository.
+type TrustedLocationsRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns trusted locations repository.
+func NewRepository(db *mongo.Database) *TrustedLocationsRepository {
+	return &TrustedLocationsRepository{db}
+}
+
+// FindUserLocations finds the trusted locations of an user that did not expire, the last seen first.
+func (t *TrustedLocationsRepository) FindUserLocations(userID toolkitEntities.ID) (*[]TrustedLocation, error) {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}})
+
+	cursor, err := coll.Find(context.Background(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	locations := []TrustedLocation{}
+
+	if err := cursor.All(context.Background(), &locations); err != nil {
+		return nil, err
+	}
+
+	return &locations, nil
+}
+
+// FindUserLocation finds a trusted location of an user by its ID.
+func (t *TrustedLocationsRepository) FindUserLocation(userID, id toolkitEntities.ID) *TrustedLocation {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	var foundLocation TrustedLocation
+
+	coll.FindOne(context.Background(), filter).Decode(&foundLocation)
+
+	return &foundLocation
+}
+
+// FindUserLocationByValue finds a trusted location of an user by its kind and value.
+func (t *TrustedLocationsRepository) FindUserLocationByValue(userID toolkitEntities.ID, kind, value string) *TrustedLocation {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "kind", Value: kind},
+		{Key: "value", Value: value},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	var foundLocation TrustedLocation
+
+	coll.FindOne(context.Background(), filter).Decode(&foundLocation)
+
+	return &foundLocation
+}
+
+// Create inserts a new trusted location.
+func (t *TrustedLocationsRepository) Create(l *TrustedLocation) error {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	_, err := coll.InsertOne(context.Background(), l)
+
+	return err
+}
+
+// UpsertIP trusts an IP. If the IP is already trusted (even if it expired), it is seen again,
+// and the ASN and the location are updated.
+func (t *TrustedLocationsRepository) UpsertIP(l *TrustedLocation) error {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	now := time.Now()
+
+	filter := bson.D{
+		{Key: "userId", Value: l.UserID},
+		{Key: "kind", Value: KIND_IP},
+		{Key: "value", Value: l.Value},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "asn", Value: l.ASN},
+			{Key: "organization", Value: l.Organization},
+			{Key: "location", Value: l.Location},
+			{Key: "lastSeenAt", Value: now},
+			{Key: "expiresAt", Value: now.Add(EXPIRES_IN)},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "_id", Value: toolkitEntities.NewID()},
+			{Key: "firstSeenAt", Value: now},
+		}},
+	}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+// Touch marks a trusted location as seen now, postponing its expiration.
+func (t *TrustedLocationsRepository) Touch(id toolkitEntities.ID) error {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	now := time.Now()
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "lastSeenAt", Value: now},
+			{Key: "expiresAt", Value: now.Add(EXPIRES_IN)},
+		}},
+	}
+
+	_, err := coll.UpdateByID(context.Background(), id, update)
+
+	return err
+}
+
+// Delete deletes a trusted location of an user.
+func (t *TrustedLocationsRepository) Delete(userID, id toolkitEntities.ID) error {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "userId", Value: userID},
+	}
+
+	_, err := coll.DeleteOne(context.Background(), filter)
+
+	return err
+}
+
+// DeleteExpiredLocations deletes the trusted locations of an user that expired.
+func (t *TrustedLocationsRepository) DeleteExpiredLocations(userID toolkitEntities.ID) error {
+	coll := t.db.Collection(pkgConstants.TRUSTED_LOCATIONS)
+
+	filter := bson.D{
+		{Key: "userId", Value: userID},
+		{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: time.Now()}}},
+	}
+
+	_, err := coll.DeleteMany(context.Background(), filter)
+
+	return err
+}
@@ -0,0 +1,26 @@
+package trustedlocations
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the trusted locations API.
+// It takes in an AppCtx, a TrustedLocationsRepository, a UsersRepository and a TwoFactorRepository.
+func LoadRoutes(AppCtx *configs.AppCtx, trustedLocationsRepository *TrustedLocationsRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) {
+	g := AppCtx.App.Group("/trusted-locations", middlewares.JWTMiddleware(AppCtx))
+
+	g.Get("/", func(c *fiber.Ctx) error {
+		return GetTrustedLocationsHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, trustedLocationsRepository)
+	})
+	g.Post("/", func(c *fiber.Ctx) error {
+		return TrustLocationHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, trustedLocationsRepository, usersRepository, twoFactorRepository)
+	})
+	g.Delete("/:id", func(c *fiber.Ctx) error {
+		return RemoveTrustedLocationHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, trustedLocationsRepository)
+	})
+}
@@ -0,0 +1,180 @@
+package trustedlocations
+
+import (
+	"log"
+	"net"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/geoip"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// IsTrusted returns true if the IP belongs to a trusted location of the user, that is then marked as seen.
+// The autonomous system of the IP is resolved only if the user trusts an autonomous system.
+func IsTrusted(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, ip string, trustedLocationsRepository *TrustedLocationsRepository) bool {
+	l := findMatchingLocation(handlerCtx, userID, ip, trustedLocationsRepository)
+
+	if l == nil {
+		return false
+	}
+
+	if err := trustedLocationsRepository.Touch(l.ID); err != nil {
+		log.Printf("Error touching trusted location %v of user %v: %v", l.ID, userID, err)
+	}
+
+	return true
+}
+
+// TrustIP trusts an IP of the user, resolving its location and autonomous system.
+func TrustIP(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, ip string, trustedLocationsRepository *TrustedLocationsRepository) error {
+	l := &TrustedLocation{
+		UserID:   userID,
+		Kind:     KIND_IP,
+		Value:    ip,
+		Location: handlerCtx.GeoIP.Location(ip),
+	}
+
+	if asn := handlerCtx.GeoIP.ASN(ip); asn != nil {
+		l.ASN = asn.Number
+		l.Organization = asn.Organization
+	}
+
+	return trustedLocationsRepository.UpsertIP(l)
+}
+
+// GetTrustedLocations returns the trusted locations of the authenticated user.
+// The location of the request IP is flagged as current.
+func GetTrustedLocations(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, trustedLocationsRepository *TrustedLocationsRepository) (*[]TrustedLocation, error) {
+	if err := trustedLocationsRepository.DeleteExpiredLocations(authenticatedUserID); err != nil {
+		log.Printf("Error deleting expired trusted locations of user %v: %v", authenticatedUserID, err)
+	}
+
+	locations, err := trustedLocationsRepository.FindUserLocations(authenticatedUserID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ip := handlerCtx.C.IP()
+	markCurrentLocation(*locations, net.ParseIP(ip), handlerCtx.GeoIP.ASN(ip))
+
+	return locations, nil
+}
+
+// TrustLocation trusts a network or an autonomous system for the authenticated user.
+// Signing in from a trusted location skips two-factor authentication, so users that enabled it must confirm with a code,
+// and an autonomous system can only be trusted from one of its IPs. Otherwise a stolen access token would be enough
+// to skip two-factor authentication from anywhere.
+func TrustLocation(handlerCtx *configs.HandlersCtx, payload *TrustLocationDTO, authenticatedUserID toolkitEntities.ID, trustedLocationsRepository *TrustedLocationsRepository, usersRepository *users.UsersRepository, twoFactorRepository *twofactor.TwoFactorRepository) (*TrustedLocation, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsTwoFactorCodeProvided(u, payload.Code); err != nil {
+		return nil, err
+	}
+
+	if u.IsTwoFactorEnabled() {
+		if err := twofactor.VerifyCode(handlerCtx, u, payload.Code, twoFactorRepository); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+
+	l := &TrustedLocation{
+		ID:          toolkitEntities.NewID(),
+		UserID:      authenticatedUserID,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(EXPIRES_IN),
+	}
+
+	if payload.CIDR != "" {
+		// the network is normalized, so 177.10.20.30/16 and 177.10.0.0/16 are the same location
+		_, network, _ := net.ParseCIDR(payload.CIDR)
+
+		l.Kind = KIND_CIDR
+		l.Value = network.String()
+		l.Location = handlerCtx.GeoIP.Location(network.IP.String())
+	} else {
+		asn := handlerCtx.GeoIP.ASN(handlerCtx.C.IP())
+
+		if err := IsOwnASN(asn, payload.ASN); err != nil {
+			return nil, err
+		}
+
+		l.Kind = KIND_ASN
+		l.Value = FormatASN(payload.ASN)
+		l.ASN = asn.Number
+		l.Organization = asn.Organization
+	}
+
+	if err := IsNotTrustedYet(trustedLocationsRepository.FindUserLocationByValue(authenticatedUserID, l.Kind, l.Value)); err != nil {
+		return nil, err
+	}
+
+	if err := trustedLocationsRepository.Create(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RemoveTrustedLocation removes a trusted location of the authenticated user.
+// Users with two-factor authentication enabled will be asked for a code again when signing in from it.
+func RemoveTrustedLocation(handlerCtx *configs.HandlersCtx, id, authenticatedUserID toolkitEntities.ID, trustedLocationsRepository *TrustedLocationsRepository) error {
+	if err := TrustedLocationExists(trustedLocationsRepository.FindUserLocation(authenticatedUserID, id)); err != nil {
+		return err
+	}
+
+	return trustedLocationsRepository.Delete(authenticatedUserID, id)
+}
+
+// findMatchingLocation finds the trusted location of the user the IP belongs to. It returns nil if there is none.
+func findMatchingLocation(handlerCtx *configs.HandlersCtx, userID toolkitEntities.ID, ip string, trustedLocationsRepository *TrustedLocationsRepository) *TrustedLocation {
+	parsedIP := net.ParseIP(ip)
+
+	if parsedIP == nil || toolkitEntities.IsZeroID(userID) {
+		return nil
+	}
+
+	locations, err := trustedLocationsRepository.FindUserLocations(userID)
+
+	if err != nil {
+		log.Printf("Error finding trusted locations of user %v: %v", userID, err)
+		return nil
+	}
+
+	var asn *geoip.ASN
+	isASNResolved := false
+
+	for i, l := range *locations {
+		if l.Kind == KIND_ASN && !isASNResolved {
+			asn = handlerCtx.GeoIP.ASN(ip)
+			isASNResolved = true
+		}
+
+		if l.Matches(parsedIP, asn) {
+			return &(*locations)[i]
+		}
+	}
+
+	return nil
+}
+
+// markCurrentLocation flags the locations the IP belongs to as current.
+func markCurrentLocation(locations []TrustedLocation, ip net.IP, asn *geoip.ASN) {
+	for i := range locations {
+		locations[i].Current = locations[i].Matches(ip, asn)
+	}
+}
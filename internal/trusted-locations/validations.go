@@ -0,0 +1,47 @@
+package trustedlocations
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/geoip"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// TrustedLocationExists returns error if the trusted location does not exist.
+func TrustedLocationExists(l *TrustedLocation) error {
+	if toolkitEntities.IsZeroID(l.ID) {
+		return errors.New(pkgErrors.TRUSTED_LOCATION_NOT_FOUND)
+	}
+
+	return nil
+}
+
+// IsNotTrustedYet returns error if the location is already trusted by the user.
+func IsNotTrustedYet(l *TrustedLocation) error {
+	if !toolkitEntities.IsZeroID(l.ID) {
+		return errors.New(pkgErrors.TRUSTED_LOCATION_ALREADY_EXISTS)
+	}
+
+	return nil
+}
+
+// IsTwoFactorCodeProvided returns error if the user has two-factor authentication enabled and no code was provided.
+func IsTwoFactorCodeProvided(u *users.User, code string) error {
+	if u.IsTwoFactorEnabled() && strings.TrimSpace(code) == "" {
+		return errors.New(pkgErrors.TWO_FACTOR_CODE_REQUIRED)
+	}
+
+	return nil
+}
+
+// IsOwnASN returns error if the autonomous system is not the one of the request IP, which is nil if it is unknown.
+func IsOwnASN(asn *geoip.ASN, number uint) error {
+	if asn == nil || asn.Number != number {
+		return errors.New(pkgErrors.TRUSTED_LOCATION_ASN_NOT_OWN)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package twofactor
+
+import (
+	"github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/toolkit/validations"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// EnrollDTO is DTO for payload for two-factor enrol handler.
+type EnrollDTO struct {
+	// The current password of the user. Enrolment requires the user to authenticate again.
+	Password string
+}
+
+// EnableDTO is DTO for payload for two-factor enable handler.
+type EnableDTO struct {
+	// A code generated by the authenticator app for the pending secret.
+	Code string
+}
+
+// ReauthenticateDTO is DTO for payload for handlers that change two-factor settings, like disable and regenerate recovery codes.
+// Both the password and a two-factor code (or a recovery code) are required.
+type ReauthenticateDTO struct {
+	Password string
+	Code     string
+}
+
+// Validate is a method of EnrollDTO that validates the fields of the struct.
+// The Password field is required and must have a length between 6 and 200 characters.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d EnrollDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Password, validation.Required.Error(errors.PASSWORD_FIELD_REQUIRED), validation.Length(6, 200).Error(errors.PASSWORD_FIELD_LENGTH)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of EnableDTO that validates the fields of the struct.
+// The Code field is required.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d EnableDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Code, validation.Required.Error(errors.TWO_FACTOR_CODE_REQUIRED)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of ReauthenticateDTO that validates the fields of the struct.
+// The Password field is required and must have a length between 6 and 200 characters.
+// The Code field is required.
+// The method then returns the validation error, if any, using the validations.GetValidationError method.
+func (d ReauthenticateDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.Password, validation.Required.Error(errors.PASSWORD_FIELD_REQUIRED), validation.Length(6, 200).Error(errors.PASSWORD_FIELD_LENGTH)),
+		validation.Field(&d.Code, validation.Required.Error(errors.TWO_FACTOR_CODE_REQUIRED)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
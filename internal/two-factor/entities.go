@@ -0,0 +1,14 @@
+package twofactor
+
+// Enrollment is a model for the data returned when an user starts the two-factor enrolment.
+type Enrollment struct {
+	// Secret is the base32 TOTP secret, for users that can't scan the QR code.
+	Secret string `json:"secret"`
+	// URI is the otpauth:// URI to be rendered as a QR code.
+	URI string `json:"uri"`
+}
+
+// RecoveryCodes is a model for the one-time recovery codes. They are shown only once, when generated.
+type RecoveryCodes struct {
+	Codes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,87 @@
+package twofactor
+
+import (
+	"net/http"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/toolkit/responses"
+)
+
+// EnrollHandler handles the request to start the two-factor enrolment of the authenticated user.
+// It returns the secret and the otpauth URI to be registered in an authenticator app.
+func EnrollHandler(handlerCtx *configs.HandlersCtx, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) error {
+	payload := EnrollDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	enrollment, err := Enroll(handlerCtx, &payload, authenticatedUserID, twoFactorRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, enrollment)
+}
+
+// EnableHandler handles the request to verify the pending secret and enable two-factor authentication.
+// It returns the recovery codes of the user.
+func EnableHandler(handlerCtx *configs.HandlersCtx, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) error {
+	payload := EnableDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	codes, err := Enable(handlerCtx, &payload, authenticatedUserID, twoFactorRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, codes)
+}
+
+// DisableHandler handles the request to disable two-factor authentication of the authenticated user.
+func DisableHandler(handlerCtx *configs.HandlersCtx, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) error {
+	payload := ReauthenticateDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	if err := Disable(handlerCtx, &payload, authenticatedUserID, twoFactorRepository, usersRepository); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, nil)
+}
+
+// RegenerateRecoveryCodesHandler handles the request to replace the recovery codes of the authenticated user.
+// It returns the new recovery codes.
+func RegenerateRecoveryCodesHandler(handlerCtx *configs.HandlersCtx, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) error {
+	payload := ReauthenticateDTO{}
+
+	if err := handlerCtx.C.BodyParser(&payload); err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	authenticatedUserID := users.GetUserByToken(handlerCtx).ID
+
+	codes, err := RegenerateRecoveryCodes(handlerCtx, &payload, authenticatedUserID, twoFactorRepository, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, codes)
+}
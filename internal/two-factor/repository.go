@@ -0,0 +1,115 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+
+	collections "github.com/quessapp/toolkit/constants"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TwoFactorRepository represents two-factor repository.
+// Two-factor settings are embedded in the user document, so it operates over the users collection.
+type TwoFactorRepository struct {
+	db *mongo.Database
+}
+
+// NewRepository returns two-factor repository.
+func NewRepository(db *mongo.Database) *TwoFactorRepository {
+	return &TwoFactorRepository{db}
+}
+
+// SetPendingSecret stores the encrypted secret generated on enrolment.
+// It does not enable two-factor authentication, the user must verify a code first.
+func (t *TwoFactorRepository) SetPendingSecret(userID toolkitEntities.ID, encryptedSecret string) error {
+	coll := t.db.Collection(collections.USERS)
+
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor.isEnabled", Value: false},
+		{Key: "twoFactor.pendingSecret", Value: encryptedSecret},
+	}}}
+
+	_, err := coll.UpdateByID(context.Background(), userID, update)
+
+	return err
+}
+
+// Enable promotes the pending secret to the active secret, stores the hashed recovery codes and
+// the time step of the code used to verify the enrolment.
+func (t *TwoFactorRepository) Enable(userID toolkitEntities.ID, encryptedSecret string, hashedRecoveryCodes []string, step int64) error {
+	coll := t.db.Collection(collections.USERS)
+
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor.isEnabled", Value: true},
+		{Key: "twoFactor.enabledAt", Value: time.Now()},
+		{Key: "twoFactor.secret", Value: encryptedSecret},
+		{Key: "twoFactor.pendingSecret", Value: ""},
+		{Key: "twoFactor.recoveryCodes", Value: hashedRecoveryCodes},
+		{Key: "twoFactor.lastUsedStep", Value: step},
+	}}}
+
+	_, err := coll.UpdateByID(context.Background(), userID, update)
+
+	return err
+}
+
+// Disable removes every two-factor setting of the user.
+func (t *TwoFactorRepository) Disable(userID toolkitEntities.ID) error {
+	coll := t.db.Collection(collections.USERS)
+
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "twoFactor", Value: ""}}}}
+
+	_, err := coll.UpdateByID(context.Background(), userID, update)
+
+	return err
+}
+
+// ReplaceRecoveryCodes replaces all recovery codes of the user with the given hashed codes.
+func (t *TwoFactorRepository) ReplaceRecoveryCodes(userID toolkitEntities.ID, hashedRecoveryCodes []string) error {
+	coll := t.db.Collection(collections.USERS)
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "twoFactor.recoveryCodes", Value: hashedRecoveryCodes}}}}
+
+	_, err := coll.UpdateByID(context.Background(), userID, update)
+
+	return err
+}
+
+// ConsumeRecoveryCode removes the given hashed recovery code from the user.
+// The filter and the removal happen in the same operation, so a code can't be used twice by concurrent requests.
+// It returns true if the code existed and was consumed.
+func (t *TwoFactorRepository) ConsumeRecoveryCode(userID toolkitEntities.ID, hashedRecoveryCode string) bool {
+	coll := t.db.Collection(collections.USERS)
+
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "twoFactor.recoveryCodes", Value: hashedRecoveryCode},
+	}
+
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "twoFactor.recoveryCodes", Value: hashedRecoveryCode}}}}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err == nil && result.ModifiedCount == 1
+}
+
+// UseStep marks the given TOTP time step as used.
+// It only succeeds if the step is newer than the last used one, so a code can't be replayed.
+// It returns true if the step was accepted.
+func (t *TwoFactorRepository) UseStep(userID toolkitEntities.ID, step int64) bool {
+	coll := t.db.Collection(collections.USERS)
+
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "twoFactor.lastUsedStep", Value: bson.D{{Key: "$lt", Value: step}}},
+	}
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "twoFactor.lastUsedStep", Value: step}}}}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err == nil && result.ModifiedCount == 1
+}
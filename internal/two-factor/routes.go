@@ -0,0 +1,28 @@
+package twofactor
+
+import (
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadRoutes is a function that sets up the routes for the two-factor API.
+// It takes in an AppCtx, a TwoFactorRepository, and a UsersRepository.
+func LoadRoutes(AppCtx *configs.AppCtx, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) {
+	g := AppCtx.App.Group("/2fa", middlewares.JWTMiddleware(AppCtx))
+
+	g.Post("/enroll", func(c *fiber.Ctx) error {
+		return EnrollHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, twoFactorRepository, usersRepository)
+	})
+	g.Post("/enable", func(c *fiber.Ctx) error {
+		return EnableHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, twoFactorRepository, usersRepository)
+	})
+	g.Delete("/", func(c *fiber.Ctx) error {
+		return DisableHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, twoFactorRepository, usersRepository)
+	})
+	g.Post("/recovery-codes", func(c *fiber.Ctx) error {
+		return RegenerateRecoveryCodesHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, twoFactorRepository, usersRepository)
+	})
+}
@@ -0,0 +1,264 @@
+package twofactor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/totp"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+
+	"github.com/quessapp/toolkit/crypto"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// RECOVERY_CODES_AMOUNT is how many recovery codes are generated at once.
+	RECOVERY_CODES_AMOUNT = 10
+	// RECOVERY_CODE_LENGTH is the length of each recovery code, without the separator.
+	RECOVERY_CODE_LENGTH = 10
+)
+
+// recoveryCodeAlphabet avoids characters that are easily confused, like 0/O and 1/I.
+const recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NormalizeRecoveryCode uppercases the code and removes separators and spaces, so users can type it in any format.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.ReplaceAll(code, "-", "")
+
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// HashRecoveryCode hashes a recovery code with SHA-256. Codes are random and long enough, so a slow hash is not needed.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeRecoveryCode(code)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateRecoveryCodes generates RECOVERY_CODES_AMOUNT random recovery codes formatted as XXXXX-XXXXX.
+// It returns the plain codes, to be shown to the user once, and their hashes, to be stored.
+func GenerateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, RECOVERY_CODES_AMOUNT)
+	hashes := make([]string, 0, RECOVERY_CODES_AMOUNT)
+
+	for i := 0; i < RECOVERY_CODES_AMOUNT; i++ {
+		b := make([]byte, RECOVERY_CODE_LENGTH)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		code := fmt.Sprintf("%s-%s", b[:RECOVERY_CODE_LENGTH/2], b[RECOVERY_CODE_LENGTH/2:])
+
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// isTOTPCode returns true if the code looks like a code generated by an authenticator app.
+func isTOTPCode(code string) bool {
+	if len(code) != totp.DIGITS {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// VerifyCode verifies a two-factor code of an user with two-factor authentication enabled.
+// The code can be a TOTP code generated by the authenticator app or one of the recovery codes.
+// TOTP codes can't be reused and recovery codes are consumed once used.
+func VerifyCode(handlerCtx *configs.HandlersCtx, u *users.User, code string, twoFactorRepository *TwoFactorRepository) error {
+	if err := IsEnabled(u); err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+
+	if isTOTPCode(code) {
+		secret, err := crypto.Decrypt(u.TwoFactor.Secret, handlerCtx.Cfg.Crypto.Key)
+
+		if err != nil {
+			return err
+		}
+
+		step, ok := totp.Validate(secret, code, time.Now())
+
+		if err := IsCodeValid(ok && twoFactorRepository.UseStep(u.ID, step)); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	return IsCodeValid(twoFactorRepository.ConsumeRecoveryCode(u.ID, HashRecoveryCode(code)))
+}
+
+// reauthenticate checks the password and a two-factor code of the authenticated user.
+// It is required before changing two-factor settings.
+func reauthenticate(handlerCtx *configs.HandlersCtx, payload *ReauthenticateDTO, authenticatedUserID toolkitEntities.ID, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) (*users.User, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsPasswordCorrect(bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(payload.Password))); err != nil {
+		return nil, err
+	}
+
+	if err := VerifyCode(handlerCtx, u, payload.Code, twoFactorRepository); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Enroll starts the two-factor enrolment of the authenticated user.
+// It checks the password, generates a new secret and stores it encrypted as pending.
+// Two-factor authentication is only enabled after the user verifies a code with Enable.
+// Calling it again replaces the pending secret.
+func Enroll(handlerCtx *configs.HandlersCtx, payload *EnrollDTO, authenticatedUserID toolkitEntities.ID, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) (*Enrollment, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsAlreadyEnabled(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsPasswordCorrect(bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(payload.Password))); err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedSecret, err := crypto.Encrypt(secret, handlerCtx.Cfg.Crypto.Key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := twoFactorRepository.SetPendingSecret(u.ID, encryptedSecret); err != nil {
+		return nil, err
+	}
+
+	enrollment := &Enrollment{
+		Secret: secret,
+		URI:    totp.KeyURI(handlerCtx.Cfg.App.APPName, u.Nick, secret),
+	}
+
+	return enrollment, nil
+}
+
+// Enable verifies a code for the pending secret and enables two-factor authentication.
+// It returns the recovery codes, that are shown only this time.
+func Enable(handlerCtx *configs.HandlersCtx, payload *EnableDTO, authenticatedUserID toolkitEntities.ID, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) (*RecoveryCodes, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := users.UserExists(u); err != nil {
+		return nil, err
+	}
+
+	if err := IsAlreadyEnabled(u); err != nil {
+		return nil, err
+	}
+
+	if err := HasPendingEnrollment(u); err != nil {
+		return nil, err
+	}
+
+	secret, err := crypto.Decrypt(u.TwoFactor.PendingSecret, handlerCtx.Cfg.Crypto.Key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, payload.Code, time.Now())
+
+	if err := IsCodeValid(ok); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := GenerateRecoveryCodes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := twoFactorRepository.Enable(u.ID, u.TwoFactor.PendingSecret, hashes, step); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodes{Codes: codes}, nil
+}
+
+// Disable disables two-factor authentication of the authenticated user.
+// The user must provide the password and a valid two-factor or recovery code.
+func Disable(handlerCtx *configs.HandlersCtx, payload *ReauthenticateDTO, authenticatedUserID toolkitEntities.ID, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) error {
+	u, err := reauthenticate(handlerCtx, payload, authenticatedUserID, twoFactorRepository, usersRepository)
+
+	if err != nil {
+		return err
+	}
+
+	return twoFactorRepository.Disable(u.ID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the authenticated user, invalidating the old ones.
+// The user must provide the password and a valid two-factor or recovery code.
+func RegenerateRecoveryCodes(handlerCtx *configs.HandlersCtx, payload *ReauthenticateDTO, authenticatedUserID toolkitEntities.ID, twoFactorRepository *TwoFactorRepository, usersRepository *users.UsersRepository) (*RecoveryCodes, error) {
+	u, err := reauthenticate(handlerCtx, payload, authenticatedUserID, twoFactorRepository, usersRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := GenerateRecoveryCodes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := twoFactorRepository.ReplaceRecoveryCodes(u.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodes{Codes: codes}, nil
+}
@@ -0,0 +1,54 @@
+package twofactor
+
+import (
+	"errors"
+
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// IsPasswordCorrect checks if the given hash result is nil. If it is, it means that the
+// password is correct, and it returns nil. Otherwise, it returns an error.
+func IsPasswordCorrect(hashResult error) error {
+	if hashResult != nil {
+		return errors.New(pkgErrors.INCORRECT_PASSWORD)
+	}
+
+	return nil
+}
+
+// IsAlreadyEnabled returns error if the user already has two-factor authentication enabled.
+func IsAlreadyEnabled(u *users.User) error {
+	if u.IsTwoFactorEnabled() {
+		return errors.New(pkgErrors.TWO_FACTOR_ALREADY_ENABLED)
+	}
+
+	return nil
+}
+
+// IsEnabled returns error if the user does not have two-factor authentication enabled.
+func IsEnabled(u *users.User) error {
+	if !u.IsTwoFactorEnabled() {
+		return errors.New(pkgErrors.TWO_FACTOR_NOT_ENABLED)
+	}
+
+	return nil
+}
+
+// HasPendingEnrollment returns error if the user did not start the enrolment, so there is no secret to be verified.
+func HasPendingEnrollment(u *users.User) error {
+	if u.TwoFactor == nil || u.TwoFactor.PendingSecret == "" {
+		return errors.New(pkgErrors.TWO_FACTOR_NOT_ENROLLED)
+	}
+
+	return nil
+}
+
+// IsCodeValid returns error if the provided two-factor code is not valid.
+func IsCodeValid(isValid bool) error {
+	if !isValid {
+		return errors.New(pkgErrors.TWO_FACTOR_CODE_INVALID)
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package users
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/nicks"
 	"github.com/quessapp/toolkit/regexes"
 	"github.com/quessapp/toolkit/validations"
 
@@ -23,6 +25,26 @@ type UpdateProfileDTO struct {
 type UpdatePreferencesDTO struct {
 	EnableAPPPushNotifications bool `json:"enableAppPushNotifications" bson:"enableAppPushNotifications"`
 	EnableAPPEmails            bool `json:"enableAppEmails" bson:"enableAppEmails"`
+	// AnswersVisibility is optional, the visibility is kept when it is empty.
+	AnswersVisibility string `json:"answersVisibility,omitempty" bson:"answersVisibility"`
+	// IsReplyHistoryPublic is optional, the setting is kept when it is nil.
+	IsReplyHistoryPublic *bool `json:"isReplyHistoryPublic,omitempty" bson:"isReplyHistoryPublic"`
+}
+
+// UpdateInboxDTO is DTO for payload for update inbox handler, see Inbox.
+type UpdateInboxDTO struct {
+	DenyAnonymous        bool   `json:"denyAnonymous"`
+	RequireVerifiedEmail bool   `json:"requireVerifiedEmail"`
+	MinAccountAgeDays    int    `json:"minAccountAgeDays"`
+	IsPaused             bool   `json:"isPaused"`
+	PausedMessage        string `json:"pausedMessage"`
+	DailyLimit           int    `json:"dailyLimit"`
+}
+
+// Format formats DTO information. It normalises the nick, see nicks.Normalize, and trim email.
+func (d *UpdateProfileDTO) Format() {
+	d.Nick = nicks.Normalize(d.Nick)
+	d.Email = strings.TrimSpace(d.Email)
 }
 
 // Validate is a method of UpdateProfileDTO that validates the fields of the struct.
@@ -46,13 +68,33 @@ func (d UpdateProfileDTO) Validate() error {
 
 // Validate is a method of UpdatePreferencesDTO that validates the fields of the struct.
 // The method uses the validation package to validate the EnableAPPEmails and EnableAPPPushNotifications fields.
-// Both fields are required and must be present.
+// Both fields are required and must be present. The AnswersVisibility field, when present, must be one of ANSWERS_VISIBILITIES.
 // The method then returns the validation error, if any, using the validations.GetValidationError method.
 // If there are no validation errors, the method returns nil.
 func (d UpdatePreferencesDTO) Validate() error {
+	visibilities := make([]any, len(ANSWERS_VISIBILITIES))
+
+	for i, visibility := range ANSWERS_VISIBILITIES {
+		visibilities[i] = visibility
+	}
+
 	validationResult := validation.ValidateStruct(&d,
 		validation.Field(&d.EnableAPPEmails, validation.Required.Error(errors.ENABLE_APP_EMAILS_FIELD_REQUIRED)),
 		validation.Field(&d.EnableAPPPushNotifications, validation.Required.Error(errors.ENABLE_APP_NOTIFICATIONS_FIELD_REQUIRED)),
+		validation.Field(&d.AnswersVisibility, validation.In(visibilities...).Error(errors.ANSWERS_VISIBILITY_INVALID)),
+	)
+
+	return validations.GetValidationError(validationResult)
+}
+
+// Validate is a method of UpdateInboxDTO that validates the fields of the struct.
+// The MinAccountAgeDays field must be between 0 and INBOX_MAX_MIN_ACCOUNT_AGE_DAYS, and the DailyLimit field between 0 and INBOX_MAX_DAILY_LIMIT.
+// The PausedMessage field is optional and must have at most INBOX_PAUSED_MESSAGE_MAX_LENGTH characters.
+func (d UpdateInboxDTO) Validate() error {
+	validationResult := validation.ValidateStruct(&d,
+		validation.Field(&d.MinAccountAgeDays, validation.Min(0).Error(errors.INBOX_MIN_ACCOUNT_AGE_INVALID), validation.Max(INBOX_MAX_MIN_ACCOUNT_AGE_DAYS).Error(errors.INBOX_MIN_ACCOUNT_AGE_INVALID)),
+		validation.Field(&d.DailyLimit, validation.Min(0).Error(errors.INBOX_DAILY_LIMIT_INVALID), validation.Max(INBOX_MAX_DAILY_LIMIT).Error(errors.INBOX_DAILY_LIMIT_INVALID)),
+		validation.Field(&d.PausedMessage, validation.Length(1, INBOX_PAUSED_MESSAGE_MAX_LENGTH).Error(errors.INBOX_PAUSED_MESSAGE_LENGTH)),
 	)
 
 	return validations.GetValidationError(validationResult)
@@ -1,12 +1,85 @@
 package users
 
 import (
-	"regexp"
 	"strings"
 	"time"
 
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/pagination"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
-	regexes "github.com/quessapp/toolkit/regexes"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	EMAIL_VERIFICATION_TOKEN_TYPE = "EmailVerification"
+	// EMAIL_VERIFICATION_DEFAULT_EXPIRES_IN is used when EMAIL_VERIFICATION_EXPIRES_IN is not set.
+	EMAIL_VERIFICATION_DEFAULT_EXPIRES_IN = time.Hour * 24
+	// EMAIL_VERIFICATION_DEFAULT_RESEND_INTERVAL is used when EMAIL_VERIFICATION_RESEND_INTERVAL is not set.
+	EMAIL_VERIFICATION_DEFAULT_RESEND_INTERVAL = time.Minute
+	// NICK_DEFAULT_RESERVATION_PERIOD is used when NICK_RESERVATION_PERIOD is not set.
+	NICK_DEFAULT_RESERVATION_PERIOD = time.Hour * 24 * 30
+	// NICK_DEFAULT_CHANGE_INTERVAL is used when NICK_CHANGE_INTERVAL is not set.
+	NICK_DEFAULT_CHANGE_INTERVAL = time.Hour * 24 * 7
+)
+
+// Roles of the users. Users without a role have ROLE_USER.
+const (
+	ROLE_USER      = "user"
+	ROLE_MODERATOR = "moderator"
+	ROLE_ADMIN     = "admin"
+)
+
+// ROLES are all the roles that can be assigned to users, from the least to the most privileged, see Outranks.
+var ROLES = []string{ROLE_USER, ROLE_MODERATOR, ROLE_ADMIN}
+
+// Permissions granted by the roles. Route groups declare the permissions they require, see middlewares.PolicyMiddleware.
+const (
+	// PERMISSION_USERS_BAN allows to ban users and to lift their bans.
+	PERMISSION_USERS_BAN = "users:ban"
+	// PERMISSION_USERS_UNLOCK allows to see and to lift the account lockouts of users.
+	PERMISSION_USERS_UNLOCK = "users:unlock"
+	// PERMISSION_REPORTS_REVIEW allows to see and to delete the reports sent by any user.
+	PERMISSION_REPORTS_REVIEW = "reports:review"
+	// PERMISSION_ROLES_MANAGE allows to change the roles of users.
+	PERMISSION_ROLES_MANAGE = "roles:manage"
+)
+
+// ROLE_PERMISSIONS are the permissions granted by each role.
+var ROLE_PERMISSIONS = map[string][]string{
+	ROLE_USER:      {},
+	ROLE_MODERATOR: {PERMISSION_USERS_BAN, PERMISSION_REPORTS_REVIEW},
+	ROLE_ADMIN:     {PERMISSION_USERS_BAN, PERMISSION_USERS_UNLOCK, PERMISSION_REPORTS_REVIEW, PERMISSION_ROLES_MANAGE},
+}
+
+// Visibilities of the answered questions of an user, see User.AnswersVisibility.
+const (
+	// ANSWERS_VISIBILITY_PUBLIC shows the answers to everyone, including visitors that are not signed in.
+	ANSWERS_VISIBILITY_PUBLIC = "public"
+	// ANSWERS_VISIBILITY_USERS shows the answers to signed in users only.
+	ANSWERS_VISIBILITY_USERS = "users"
+	// ANSWERS_VISIBILITY_PRIVATE shows the answers to the user only.
+	ANSWERS_VISIBILITY_PRIVATE = "private"
+)
+
+// Plans of the users, see GetPlan.
+const (
+	PLAN_FREE = "free"
+	PLAN_PRO  = "pro"
+)
+
+// ANSWERS_VISIBILITIES are all the visibilities that users can choose.
+var ANSWERS_VISIBILITIES = []string{ANSWERS_VISIBILITY_PUBLIC, ANSWERS_VISIBILITY_USERS, ANSWERS_VISIBILITY_PRIVATE}
+
+const (
+	// INBOX_MAX_MIN_ACCOUNT_AGE_DAYS is the maximum account age, in days, that users can require from the senders.
+	INBOX_MAX_MIN_ACCOUNT_AGE_DAYS = 365
+	// INBOX_MAX_DAILY_LIMIT is the maximum number of questions received per day that users can set.
+	INBOX_MAX_DAILY_LIMIT = 1000
+	// INBOX_PAUSED_MESSAGE_MAX_LENGTH is the maximum number of characters of the message shown when the inbox is paused.
+	INBOX_PAUSED_MESSAGE_MAX_LENGTH = 150
+	// INBOX_DAILY_LIMIT_WINDOW is the period over which the questions received are counted, see Inbox.DailyLimit.
+	INBOX_DAILY_LIMIT_WINDOW = time.Hour * 24
 )
 
 // BlockedUser is a model for each blocked user in app.
@@ -21,11 +94,23 @@ type User struct {
 	ID         toolkitEntities.ID `json:"id" bson:"_id"`
 	IsVerified bool               `json:"isVerified" bson:"isVerified"`
 	Nick       string             `json:"nick,omitempty"`
-	Name       string             `json:"name,omitempty"`
-	AvatarURL  string             `json:"avatarUrl" bson:"avatarUrl"`
+	// NickChangedAt is the last time that the user changed the nick. It is used to rate limit nick changes.
+	NickChangedAt *time.Time `json:"-" bson:"nickChangedAt,omitempty"`
+	// RedirectedFrom is the old nick used to find the user, when it was found by a nick it no longer uses. It is not stored.
+	RedirectedFrom string `json:"redirectedFrom,omitempty" bson:"-"`
+	// NickSkeleton is the confusable skeleton of the nick, see nicks.Skeleton. Nicks with the same skeleton look alike, so they can't be used by different users.
+	NickSkeleton string `json:"-" bson:"nickSkeleton,omitempty"`
+	Name         string `json:"name,omitempty"`
+	AvatarURL    string `json:"avatarUrl" bson:"avatarUrl"`
 
 	Password string `json:"-"`
-	Email    string `json:"email,omitempty"`
+	// PasswordNotSet is true for users created by a social sign-in that never defined a password.
+	PasswordNotSet bool   `json:"passwordNotSet,omitempty" bson:"passwordNotSet,omitempty"`
+	Email          string `json:"email,omitempty"`
+	// PendingEmail is the new email requested by the user. It only replaces Email once it is verified.
+	PendingEmail string `json:"pendingEmail,omitempty" bson:"pendingEmail,omitempty"`
+	// VerificationEmailSentAt is the last time that a verification email was sent. It is used to rate limit resends.
+	VerificationEmailSentAt *time.Time `json:"-" bson:"verificationEmailSentAt,omitempty"`
 
 	// EnanbleAPPPushNotifications is a bool value to verify if user can push notifications (received questions, etc.)
 	EnanbleAPPPushNotifications bool `json:"enableAppPushNotifications,omitempty" bson:"enableAppPushNotifications"`
@@ -43,9 +128,65 @@ type User struct {
 	// LastPublishAt is the last published post of user. Type must be Time.time or nil.
 	LastPublishAt *time.Time `json:"lastPublishAt,omitempty" bson:"lastPublishAt"`
 	// CreatedAt is the date that user is created. Type must be Time.time or nil.
-	CreatedAt  *time.Time `json:"createdAt,omitempty" bson:"createdAt"`
-	Locale     string     `json:"locale,omitempty" bson:"locale"`
-	TrustedIPs []string   `json:"-" bson:"trustedIps"`
+	CreatedAt *time.Time `json:"createdAt,omitempty" bson:"createdAt"`
+	Locale    string     `json:"locale,omitempty" bson:"locale"`
+	// TrustedIPs is the legacy list of trusted IPs. They are moved to the trusted locations when seen again, and new IPs are not added here.
+	TrustedIPs []string `json:"-" bson:"trustedIps"`
+	// Role is the role of the user, see ROLES. It can only be changed by an admin or the CLI, and every change is audited.
+	Role string `json:"role,omitempty" bson:"role,omitempty"`
+	// DeleteAt is when the account is deleted, set when the user requests the deletion.
+	// The account is deactivated until then, and the user can reactivate it, unsetting DeleteAt.
+	DeleteAt *time.Time `json:"deleteAt,omitempty" bson:"deleteAt,omitempty"`
+	// TwoFactor holds the TOTP two-factor authentication settings. It is nil if the user never enrolled.
+	TwoFactor *TwoFactor `json:"twoFactor,omitempty" bson:"twoFactor,omitempty"`
+
+	// AnswersVisibility is who can see the timeline of answered questions of the user, see ANSWERS_VISIBILITIES.
+	AnswersVisibility string `json:"answersVisibility,omitempty" bson:"answersVisibility,omitempty"`
+	// Inbox holds who can send questions to the user and how. It is nil if the user never changed it, see GetInbox.
+	Inbox *Inbox `json:"inbox,omitempty" bson:"inbox,omitempty"`
+	// IsReplyHistoryPublic shows the edit history of the replies of the user to the users that can see the answers.
+	// The senders of the questions can always see it.
+	IsReplyHistoryPublic bool `json:"isReplyHistoryPublic,omitempty" bson:"isReplyHistoryPublic,omitempty"`
+}
+
+// Inbox is a model for the settings of an user on the questions received. The zero value receives every question.
+// The settings that are not shown on the profiles are omitted when disabled, see Inbox.Public.
+type Inbox struct {
+	// DenyAnonymous rejects the anonymous questions.
+	DenyAnonymous bool `json:"denyAnonymous" bson:"denyAnonymous"`
+	// RequireVerifiedEmail rejects the questions of senders that did not verify their email.
+	RequireVerifiedEmail bool `json:"requireVerifiedEmail,omitempty" bson:"requireVerifiedEmail"`
+	// MinAccountAgeDays rejects the questions of senders whose accounts are younger, in days. It is disabled when 0.
+	MinAccountAgeDays int `json:"minAccountAgeDays,omitempty" bson:"minAccountAgeDays"`
+	// IsPaused rejects all the questions, showing the PausedMessage to the senders.
+	IsPaused      bool   `json:"isPaused" bson:"isPaused"`
+	PausedMessage string `json:"pausedMessage,omitempty" bson:"pausedMessage,omitempty"`
+	// DailyLimit is how many questions are received per INBOX_DAILY_LIMIT_WINDOW. It is disabled when 0.
+	DailyLimit int `json:"dailyLimit,omitempty" bson:"dailyLimit"`
+}
+
+// Public returns the settings shown to the senders on the profile of the user, so they know if the inbox is paused or
+// denies anonymous questions before asking. The other settings are kept private, so they can't be worked around.
+func (i Inbox) Public() *Inbox {
+	return &Inbox{
+		DenyAnonymous: i.DenyAnonymous,
+		IsPaused:      i.IsPaused,
+		PausedMessage: i.PausedMessage,
+	}
+}
+
+// TwoFactor is a model for the TOTP two-factor authentication settings of an user.
+type TwoFactor struct {
+	IsEnabled bool       `json:"isEnabled" bson:"isEnabled"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty" bson:"enabledAt"`
+	// Secret is the TOTP secret encrypted with the app cipher key.
+	Secret string `json:"-" bson:"secret"`
+	// PendingSecret is the encrypted secret generated on enrolment. It becomes the Secret once the user verifies a code.
+	PendingSecret string `json:"-" bson:"pendingSecret"`
+	// RecoveryCodes are SHA-256 hashes of the one-time recovery codes.
+	RecoveryCodes []string `json:"-" bson:"recoveryCodes"`
+	// LastUsedStep is the last accepted TOTP time step. It is used to reject replayed codes.
+	LastUsedStep int64 `json:"-" bson:"lastUsedStep"`
 }
 
 // ResponseWithUser is a model to use with Response model.
@@ -54,18 +195,153 @@ type ResponseWithUser struct {
 	User         *User  `json:"user"`
 	AccessToken  string `json:"accessToken,omitempty"`
 	RefreshToken string `json:"refreshToken,omitempty"`
+	// TwoFactorRequired is true when the credentials are correct but a second step is needed to sign in.
+	// In that case no user or tokens are returned, only the ChallengeToken to be exchanged on /auth/signin/2fa.
+	TwoFactorRequired bool   `json:"twoFactorRequired,omitempty"`
+	ChallengeToken    string `json:"challengeToken,omitempty"`
+}
+
+// NickChange is a nick that an user stopped using. The nick stays reserved for the user until ReleasedAt,
+// and profile links with it redirect to the current nick of the user.
+type NickChange struct {
+	ID           toolkitEntities.ID `json:"id" bson:"_id"`
+	UserID       toolkitEntities.ID `json:"-" bson:"userId"`
+	Nick         string             `json:"nick" bson:"nick"`
+	NickSkeleton string             `json:"-" bson:"nickSkeleton"`
+	ChangedAt    time.Time          `json:"changedAt" bson:"changedAt"`
+	// ReleasedAt is when the nick can be taken by other users.
+	ReleasedAt time.Time `json:"releasedAt" bson:"releasedAt"`
+}
+
+// NickSuggestions holds available nicks like the one the user chose, when it is not available.
+type NickSuggestions struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// IsTwoFactorEnabled returns a bool value if user has TOTP two-factor authentication enabled.
+func (u User) IsTwoFactorEnabled() bool {
+	return u.TwoFactor != nil && u.TwoFactor.IsEnabled
+}
+
+// GetRole returns the role of the user. Users created before roles existed have ROLE_USER.
+func (u User) GetRole() string {
+	if u.Role == "" {
+		return ROLE_USER
+	}
+
+	return u.Role
+}
+
+// Outranks returns true if the role of the user is more privileged than the role of the other user, see ROLES.
+func (u User) Outranks(other User) bool {
+	rank := map[string]int{}
+
+	for i, role := range ROLES {
+		rank[role] = i
+	}
+
+	return rank[u.GetRole()] > rank[other.GetRole()]
+}
+
+// GetPlan returns the plan of the user, PLAN_PRO for PRO members and PLAN_FREE for the others.
+func (u User) GetPlan() string {
+	if u.IsPRO {
+		return PLAN_PRO
+	}
+
+	return PLAN_FREE
+}
+
+// GetAnswersVisibility returns who can see the answers of the user. Users that never chose have ANSWERS_VISIBILITY_PUBLIC.
+func (u User) GetAnswersVisibility() string {
+	if u.AnswersVisibility == "" {
+		return ANSWERS_VISIBILITY_PUBLIC
+	}
+
+	return u.AnswersVisibility
+}
+
+// GetInbox returns the inbox settings of the user. Users that never changed them receive every question.
+func (u User) GetInbox() Inbox {
+	if u.Inbox == nil {
+		return Inbox{}
+	}
+
+	return *u.Inbox
+}
+
+// GetCreatedAt returns when the account was created. Old accounts without a creation date use the date of their ID.
+func (u User) GetCreatedAt() time.Time {
+	if u.CreatedAt == nil {
+		return u.ID.Timestamp()
+	}
+
+	return *u.CreatedAt
+}
+
+// HasPermissions returns true if the role of the user grants all the given permissions.
+// It is false when no permission is given, so callers must declare what they require.
+func (u User) HasPermissions(permissions ...string) bool {
+	if len(permissions) == 0 {
+		return false
+	}
+
+	for _, permission := range permissions {
+		isGranted := false
+
+		for _, granted := range ROLE_PERMISSIONS[u.GetRole()] {
+			if granted == permission {
+				isGranted = true
+				break
+			}
+		}
+
+		if !isGranted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsPendingDeletion returns true if the user requested the deletion of the account.
+func (u User) IsPendingDeletion() bool {
+	return u.DeleteAt != nil
+}
+
+// EmailVerificationClaims are the claims of the signed token sent on verification links.
+// The email is part of the claims, so a link stops working once the user requests another address.
+type EmailVerificationClaims struct {
+	ID    toolkitEntities.ID `json:"id"`
+	Email string             `json:"email"`
+	Type  string             `json:"type"`
+	jwt.RegisteredClaims
+}
+
+// EmailToVerify returns the email address that is waiting for verification.
+// It is the pending email if the user requested a change, otherwise the current email if it is not verified yet.
+// It returns an empty string if there is nothing to verify.
+func (u User) EmailToVerify() string {
+	if u.PendingEmail != "" {
+		return u.PendingEmail
+	}
+
+	if !u.IsVerified {
+		return u.Email
+	}
+
+	return ""
 }
 
 // PaginatedUsers is a model for paginated users in app.
 type PaginatedUsers struct {
-	Users      *[]User `json:"users"`
-	TotalCount int64   `json:"totalCount"`
+	Users *[]User `json:"users"`
+	pagination.Page
 }
 
-// Format formats user information. It removes special characters from nick, trim email, etc.
+// Format formats user information. It normalises the nick, see nicks.Normalize, trim email, etc.
 func (u *User) Format() {
-	u.Nick = regexp.MustCompile(regexes.SPECIAL_CHARS).ReplaceAllString(u.Nick, "")
-	u.Nick = strings.TrimSpace(strings.ToLower(u.Nick))
+	u.Nick = nicks.Normalize(u.Nick)
 	u.Email = strings.TrimSpace(u.Email)
 }
 
@@ -1,33 +1,33 @@
 package users
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/quessapp/core-go/configs"
 	i18n "github.com/quessapp/core-go/pkg/i18n"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/pagination"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/quessapp/toolkit/responses"
 )
 
 // SearchUsersByValue performs a search for users based on a search value.
-// It returns a list of users matching the search, if any. The page of search results can be specified using the "page" parameter.
+// It returns a list of users matching the search, if any. The page of search results can be specified using the "limit", "cursor", "sort" and "total" parameters.
 // The authenticated user ID is obtained from the JWT token in the request context.
 // If an error occurs during the search or parsing of parameters, a Bad Request response is returned.
 // Otherwise, a successful response is returned with the list of matching users.
 func SearchUserHandler(handlerCtx *configs.HandlersCtx, usersRepository *UsersRepository) error {
 	value := handlerCtx.C.Query("search")
+	authenticatedUserID := GetUserByToken(handlerCtx).ID
 
-	p, err := strconv.Atoi(handlerCtx.C.Query("page"))
-
-	page := int64(p)
+	params, err := pagination.ParseParams(handlerCtx.Cfg.Crypto.Key, pagination.Scope("users:search", authenticatedUserID.Hex()), handlerCtx.C.Query("limit"), handlerCtx.C.Query("cursor"), handlerCtx.C.Query("sort"), handlerCtx.C.Query("total"))
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
-	authenticatedUserID := GetUserByToken(handlerCtx).ID
-
-	users, err := SearchUser(handlerCtx, value, &page, authenticatedUserID, usersRepository)
+	users, err := SearchUser(handlerCtx, value, params, authenticatedUserID, usersRepository)
 
 	if err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
@@ -96,8 +96,52 @@ func UpdateUserProfileHandler(handlerCtx *configs.HandlersCtx, usersRepository *
 	}
 
 	if err := UpdateUserProfile(handlerCtx, &payload, authenticatedUserID, usersRepository); err != nil {
+		return ParseUnsuccessfulNick(handlerCtx, err)
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
+}
+
+// ResendVerificationEmailHandler sends a new verification link to the email of the authenticated user that is waiting for verification.
+// It returns a Bad Request HTTP response if there is nothing to verify or if a verification email was sent recently.
+func ResendVerificationEmailHandler(handlerCtx *configs.HandlersCtx, usersRepository *UsersRepository) error {
+	authenticatedUserID := GetUserByToken(handlerCtx).ID
+
+	if err := ResendVerificationEmail(handlerCtx, authenticatedUserID, usersRepository); err != nil {
 		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
 	}
 
 	return responses.ParseSuccessful(handlerCtx.C, http.StatusCreated, nil)
 }
+
+// GetNickHistoryHandler gets the old nicks of the authenticated user.
+func GetNickHistoryHandler(handlerCtx *configs.HandlersCtx, usersRepository *UsersRepository) error {
+	authenticatedUserID := GetUserByToken(handlerCtx).ID
+
+	history, err := GetNickHistory(handlerCtx, authenticatedUserID, usersRepository)
+
+	if err != nil {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	return responses.ParseSuccessful(handlerCtx.C, http.StatusOK, history)
+}
+
+// ParseUnsuccessfulNick parses an unsuccessful response like responses.ParseUnsuccesfull.
+// When the nick is not available, the suggested nicks are returned in the data, so the user can pick one of them.
+func ParseUnsuccessfulNick(handlerCtx *configs.HandlersCtx, err error) error {
+	var unavailableErr *nicks.UnavailableError
+
+	if !errors.As(err, &unavailableErr) {
+		return responses.ParseUnsuccesfull(handlerCtx.C, http.StatusBadRequest, i18n.Translate(handlerCtx, err.Error()))
+	}
+
+	handlerCtx.C.Status(http.StatusBadRequest)
+
+	return handlerCtx.C.JSON(&toolkitEntities.Response{
+		Ok:      false,
+		Error:   true,
+		Message: i18n.Translate(handlerCtx, unavailableErr.Key),
+		Data:    NickSuggestions{Suggestions: unavailableErr.Suggestions},
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	pkgConstants "github.com/quessapp/core-go/pkg/constants"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/pagination"
 	collections "github.com/quessapp/toolkit/constants"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 
@@ -69,17 +72,129 @@ func (u UsersRepository) FindUserByID(userID toolkitEntities.ID) *User {
 	return &foundUser
 }
 
-// IsNickInUse checks if a user with the given nickname exists in the database.
-// It takes the user's nickname as a parameter and performs a database lookup to find the matching user.
-// If a user with the given nickname is found, it returns true. Otherwise, it returns false.
-func (u UsersRepository) IsNickInUse(nick string) bool {
+// IsNickInUse checks if another user has the given nickname, or a nickname that looks like it (see nicks.Skeleton).
+// The old nicks of other users are in use until they are released, see NickChange.
+// The user with exceptUserID is ignored, so users can change their nick to a look-alike of their own, or get an old nick back.
+// It is a zero ID on sign up.
+// If another user with the given nickname is found, it returns true. Otherwise, it returns false.
+func (u UsersRepository) IsNickInUse(nick string, exceptUserID toolkitEntities.ID) bool {
 	coll := u.db.Collection(collections.USERS)
 
 	var user User
 
-	coll.FindOne(context.Background(), bson.D{{Key: "nick", Value: nick}}).Decode(&user)
+	skeleton := nicks.Skeleton(nick)
+
+	filter := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "nick", Value: nick}},
+			bson.D{{Key: "nickSkeleton", Value: skeleton}},
+		}},
+		{Key: "_id", Value: bson.D{{Key: "$ne", Value: exceptUserID}}},
+	}
+
+	coll.FindOne(context.Background(), filter).Decode(&user)
+
+	if !toolkitEntities.IsZeroID(user.ID) {
+		return true
+	}
+
+	historyColl := u.db.Collection(pkgConstants.NICK_HISTORY)
+
+	var change NickChange
+
+	historyFilter := bson.D{
+		{Key: "nickSkeleton", Value: skeleton},
+		{Key: "userId", Value: bson.D{{Key: "$ne", Value: exceptUserID}}},
+		{Key: "releasedAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	historyColl.FindOne(context.Background(), historyFilter).Decode(&change)
 
-	return user.Nick != ""
+	return !toolkitEntities.IsZeroID(change.ID)
+}
+
+// FindNickChangeByNick finds the newest change from the given nick that was not released yet.
+// If it is not found, the NickChange has a zero ID.
+func (u UsersRepository) FindNickChangeByNick(nick string) *NickChange {
+	coll := u.db.Collection(pkgConstants.NICK_HISTORY)
+
+	var foundChange NickChange
+
+	filter := bson.D{
+		{Key: "nick", Value: nick},
+		{Key: "releasedAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "changedAt", Value: -1}})
+
+	coll.FindOne(context.Background(), filter, opts).Decode(&foundChange)
+
+	return &foundChange
+}
+
+// FindNickChanges finds the nick changes of an user, the newest first.
+func (u UsersRepository) FindNickChanges(userID toolkitEntities.ID) (*[]NickChange, error) {
+	coll := u.db.Collection(pkgConstants.NICK_HISTORY)
+
+	opts := options.Find().SetSort(bson.D{{Key: "changedAt", Value: -1}})
+
+	cursor, err := coll.Find(context.Background(), bson.D{{Key: "userId", Value: userID}}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []NickChange{}
+
+	if err := cursor.All(context.Background(), &changes); err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
+
+// ClaimNickChange sets when the user changed the nick, as long as the previous change was at least interval before.
+// The check and the update are a single operation, so concurrent changes can't both pass the interval.
+// It returns false if the user changed the nick less than interval before.
+func (u *UsersRepository) ClaimNickChange(userID toolkitEntities.ID, changedAt time.Time, interval time.Duration) (bool, error) {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "nickChangedAt", Value: nil}},
+			bson.D{{Key: "nickChangedAt", Value: bson.D{{Key: "$lte", Value: changedAt.Add(-interval)}}}},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "nickChangedAt", Value: changedAt}}}}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// RecordNickChange stores the old nick of an user on the history, once the change was claimed with ClaimNickChange.
+// Old nicks of the user that look like the new one are released, since the user is using it again.
+func (u *UsersRepository) RecordNickChange(change *NickChange, newNick string) error {
+	historyColl := u.db.Collection(pkgConstants.NICK_HISTORY)
+
+	releaseFilter := bson.D{
+		{Key: "userId", Value: change.UserID},
+		{Key: "nickSkeleton", Value: nicks.Skeleton(newNick)},
+		{Key: "releasedAt", Value: bson.D{{Key: "$gt", Value: change.ChangedAt}}},
+	}
+	release := bson.D{{Key: "$set", Value: bson.D{{Key: "releasedAt", Value: change.ChangedAt}}}}
+
+	if _, err := historyColl.UpdateMany(context.Background(), releaseFilter, release); err != nil {
+		return err
+	}
+
+	_, err := historyColl.InsertOne(context.Background(), change)
+
+	return err
 }
 
 // IsEmailInUse checks is an user already take an email.
@@ -94,18 +209,16 @@ func (u UsersRepository) IsEmailInUse(email string) bool {
 }
 
 // Search searches for users whose names or nicks match the given value, and returns a paginated list of results.
-// The page parameter is used to determine which page of the results to return.
+// The results are ordered by nick, and the pagination params are used to determine which page of the results to return.
 // If the value parameter is an empty string, an empty list is returned.
 // The function returns a pointer to a PaginatedUsers struct and an error.
-func (u UsersRepository) Search(value string, page *int64) (*PaginatedUsers, error) {
+func (u UsersRepository) Search(value string, params *pagination.Params) (*PaginatedUsers, error) {
 	if value == "" {
 		return &PaginatedUsers{
 			Users: &[]User{},
 		}, nil
 	}
 
-	var LIMIT int64 = 30
-
 	coll := u.db.Collection(collections.USERS)
 
 	findFilterOptions := bson.D{
@@ -113,29 +226,20 @@ func (u UsersRepository) Search(value string, page *int64) (*PaginatedUsers, err
 			bson.D{{Key: "name", Value: primitive.Regex{Pattern: value, Options: ""}}},
 			bson.D{{Key: "nick", Value: primitive.Regex{Pattern: value, Options: ""}}},
 		}},
+		// accounts pending deletion are hidden
+		{Key: "deleteAt", Value: bson.D{{Key: "$exists", Value: false}}},
 	}
 
-	findOptions := options.Find().SetSort(bson.D{
-		{Key: "nick", Value: 1},
-		{Key: "name", Value: 1},
-	}).SetProjection(bson.D{
+	findOptions := params.FindOptionsByKey("nick").SetProjection(bson.D{
 		{Key: "id", Value: 1},
 		{Key: "nick", Value: 1},
 		{Key: "avatarUrl", Value: 1},
 		{Key: "name", Value: 1},
 	})
 
-	findOptions.SetSkip((*page - 1) * LIMIT)
-	findOptions.SetLimit(LIMIT)
-
-	countOptions := options.Count()
-
-	countOptions.SetSkip((*page - 1) * LIMIT)
-	countOptions.SetLimit(LIMIT)
-
 	users := []User{}
 
-	cursor, err := coll.Find(context.Background(), findFilterOptions, findOptions)
+	cursor, err := coll.Find(context.Background(), params.FilterByKey("nick", findFilterOptions), findOptions)
 
 	if err != nil {
 		return nil, err
@@ -145,15 +249,23 @@ func (u UsersRepository) Search(value string, page *int64) (*PaginatedUsers, err
 		return nil, err
 	}
 
-	totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions, countOptions)
+	users, page := pagination.NewPage(params, users, func(u User) pagination.Position {
+		return pagination.Position{ID: u.ID, Key: u.Nick}
+	})
+
+	if params.WithTotal {
+		totalCount, err := coll.CountDocuments(context.Background(), findFilterOptions)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
+
+		page.TotalCount = &totalCount
 	}
 
 	result := PaginatedUsers{
-		TotalCount: totalCount,
-		Users:      &users,
+		Users: &users,
+		Page:  *page,
 	}
 
 	return &result, nil
@@ -204,20 +316,48 @@ func (u *UsersRepository) UpdatePreferences(userID toolkitEntities.ID, payload *
 	coll := u.db.Collection(collections.USERS)
 
 	filter := bson.D{{Key: "_id", Value: userID}}
-	update := bson.D{{Key: "$set", Value: bson.D{
+	set := bson.D{
 		{
 			Key: "enableAppEmails", Value: payload.EnableAPPEmails,
 		},
 		{
 			Key: "enableAppPushNotifications", Value: payload.EnableAPPPushNotifications,
 		},
-	}}}
+	}
+
+	if payload.AnswersVisibility != "" {
+		set = append(set, bson.E{Key: "answersVisibility", Value: payload.AnswersVisibility})
+	}
+
+	if payload.IsReplyHistoryPublic != nil {
+		set = append(set, bson.E{Key: "isReplyHistoryPublic", Value: *payload.IsReplyHistoryPublic})
+	}
+
+	update := bson.D{{Key: "$set", Value: set}}
 
 	_, err := coll.UpdateOne(context.Background(), filter, update)
 
 	return err
 }
 
+// UpdateInbox replaces the inbox settings of the user.
+func (u *UsersRepository) UpdateInbox(userID toolkitEntities.ID, payload *UpdateInboxDTO) error {
+	coll := u.db.Collection(collections.USERS)
+
+	inbox := Inbox{
+		DenyAnonymous:        payload.DenyAnonymous,
+		RequireVerifiedEmail: payload.RequireVerifiedEmail,
+		MinAccountAgeDays:    payload.MinAccountAgeDays,
+		IsPaused:             payload.IsPaused,
+		PausedMessage:        payload.PausedMessage,
+		DailyLimit:           payload.DailyLimit,
+	}
+
+	_, err := coll.UpdateByID(context.Background(), userID, bson.D{{Key: "$set", Value: bson.D{{Key: "inbox", Value: inbox}}}})
+
+	return err
+}
+
 // UpdateLastPublishedAt takes a user ID and updates the corresponding user document in the database with the new value for field "lastPublishAt".
 // It returns an error if the update operation fails.
 func (u *UsersRepository) UpdateLastPublishedAt(userID toolkitEntities.ID) error {
@@ -247,6 +387,9 @@ func (u *UsersRepository) UpdateProfile(userID toolkitEntities.ID, payload *Upda
 		{
 			Key: "nick", Value: payload.Nick,
 		},
+		{
+			Key: "nickSkeleton", Value: nicks.Skeleton(payload.Nick),
+		},
 		{
 			Key: "name", Value: payload.Name,
 		},
@@ -263,6 +406,144 @@ func (u *UsersRepository) UpdateProfile(userID toolkitEntities.ID, payload *Upda
 	return err
 }
 
+// SetPendingEmail stores the new email requested by the user until it is verified.
+// It also clears the last verification email date, so a verification email can be sent to the new address right away.
+func (u *UsersRepository) SetPendingEmail(userID toolkitEntities.ID, email string) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "pendingEmail", Value: email}}},
+		{Key: "$unset", Value: bson.D{{Key: "verificationEmailSentAt", Value: ""}}},
+	}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// MarkVerificationEmailSent sets the "verificationEmailSentAt" field of the user to the current date,
+// only if no verification email was sent in the last interval.
+// The check and the update are a single operation, so concurrent requests can't send more than one email.
+// It returns true if the field was updated, meaning that the email can be sent.
+func (u *UsersRepository) MarkVerificationEmailSent(userID toolkitEntities.ID, interval time.Duration) bool {
+	coll := u.db.Collection(collections.USERS)
+
+	now := time.Now()
+
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		// $not also matches documents without the field
+		{Key: "verificationEmailSentAt", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: now.Add(-interval)}}}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "verificationEmailSentAt", Value: now}}}}
+
+	result, err := coll.UpdateOne(context.Background(), filter, update)
+
+	if err != nil {
+		return false
+	}
+
+	return result.ModifiedCount == 1
+}
+
+// VerifyEmail sets the given email as the verified email of the user and removes the pending email, if any.
+func (u *UsersRepository) VerifyEmail(userID toolkitEntities.ID, email string) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "email", Value: email},
+			{Key: "isVerified", Value: true},
+		}},
+		{Key: "$unset", Value: bson.D{
+			{Key: "pendingEmail", Value: ""},
+			{Key: "verificationEmailSentAt", Value: ""},
+		}},
+	}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// SetPasswordNotSet flags an user created without a password, see User.PasswordNotSet.
+func (u *UsersRepository) SetPasswordNotSet(userID toolkitEntities.ID) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "passwordNotSet", Value: true}}}}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// UpdateRole sets the role of an user. See users.ROLES.
+func (u *UsersRepository) UpdateRole(userID toolkitEntities.ID, role string) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "role", Value: role}}}}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// CountUsersWithRole returns how many users have the given role.
+func (u *UsersRepository) CountUsersWithRole(role string) (int64, error) {
+	coll := u.db.Collection(collections.USERS)
+
+	return coll.CountDocuments(context.Background(), bson.D{{Key: "role", Value: role}})
+}
+
+// ScheduleDeletion sets when the account of an user is deleted.
+func (u *UsersRepository) ScheduleDeletion(userID toolkitEntities.ID, deleteAt time.Time) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "deleteAt", Value: deleteAt}}}}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// CancelDeletion unsets when the account of an user is deleted, reactivating it.
+func (u *UsersRepository) CancelDeletion(userID toolkitEntities.ID) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "deleteAt", Value: ""}}}}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
+
+// FindUsersToDelete finds the users whose deletion is due.
+func (u *UsersRepository) FindUsersToDelete() (*[]User, error) {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "deleteAt", Value: bson.D{{Key: "$lte", Value: time.Now()}}}}
+
+	cursor, err := coll.Find(context.Background(), filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := []User{}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
 // Delete takes a user ID and deletes the corresponding user document from the database.
 func (u *UsersRepository) Delete(userID toolkitEntities.ID) error {
 	coll := u.db.Collection(collections.USERS)
@@ -273,3 +554,36 @@ func (u *UsersRepository) Delete(userID toolkitEntities.ID) error {
 
 	return err
 }
+
+// FindUsersWithoutNickSkeleton finds the users created before the nick skeletons, see nicks.Skeleton.
+func (u *UsersRepository) FindUsersWithoutNickSkeleton() (*[]User, error) {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "nickSkeleton", Value: bson.D{{Key: "$exists", Value: false}}}}
+
+	cursor, err := coll.Find(context.Background(), filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := []User{}
+
+	if err := cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// UpdateNickSkeleton sets the skeleton of the nick of an user.
+func (u *UsersRepository) UpdateNickSkeleton(userID toolkitEntities.ID, skeleton string) error {
+	coll := u.db.Collection(collections.USERS)
+
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "nickSkeleton", Value: skeleton}}}}
+
+	_, err := coll.UpdateOne(context.Background(), filter, update)
+
+	return err
+}
@@ -10,22 +10,29 @@ import (
 // LoadRoutes is responsible for setting up the users related to settings in the Fiber app.
 // AppCtx is the application context.
 // usersRepository is the repository for users.
+// Routes that bots and integrations can use declare the scopes their personal access tokens need, see middlewares.AuthMiddleware.
 func LoadRoutes(AppCtx *configs.AppCtx, usersRepository *UsersRepository) {
-	g := AppCtx.App.Group("/users", middlewares.JWTMiddleware(AppCtx.App, AppCtx.Cfg))
+	g := AppCtx.App.Group("/users")
 
-	g.Get("/", func(c *fiber.Ctx) error {
+	g.Get("/", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return SearchUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
-	g.Get("/me", func(c *fiber.Ctx) error {
+	g.Get("/me", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_PROFILE_READ), func(c *fiber.Ctx) error {
 		return GetAuthenticatedUserHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
-	g.Put("/me", func(c *fiber.Ctx) error {
+	g.Put("/me", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return UpdateUserProfileHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
-	g.Patch("/me/avatar", func(c *fiber.Ctx) error {
+	g.Patch("/me/avatar", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return UpdateUserAvatarHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
-	g.Get("/:nick", func(c *fiber.Ctx) error {
+	g.Post("/me/email/verification", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
+		return ResendVerificationEmailHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
+	})
+	g.Get("/me/nicks", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_PROFILE_READ), func(c *fiber.Ctx) error {
+		return GetNickHistoryHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
+	})
+	g.Get("/:nick", middlewares.AuthMiddleware(AppCtx), func(c *fiber.Ctx) error {
 		return FindUserByNickHandler(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}, usersRepository)
 	})
 }
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/queues/verifications"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/pagination"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
 	toolkitS3 "github.com/quessapp/toolkit/s3"
 
@@ -22,14 +25,10 @@ var (
 )
 
 // SearchUser searches for users based on a search value and returns a paginated list of matching users.
-// If the page argument is 0, it sets it to 1 (default). The authenticatedUserID argument is used to filter out the authenticated user from the search results.
+// The page is given by the pagination params. The authenticatedUserID argument is used to filter out the authenticated user from the search results.
 // The function returns a pointer to a PaginatedUsers struct representing the paginated list of matching users, and an error, if any occurred during the search process.
-func SearchUser(handlerCtx *configs.HandlersCtx, value string, page *int64, authenticatedUserID toolkitEntities.ID, usersRepository *UsersRepository) (*PaginatedUsers, error) {
-	if *page == 0 {
-		*page = 1
-	}
-
-	return usersRepository.Search(value, page)
+func SearchUser(handlerCtx *configs.HandlersCtx, value string, params *pagination.Params, authenticatedUserID toolkitEntities.ID, usersRepository *UsersRepository) (*PaginatedUsers, error) {
+	return usersRepository.Search(value, params)
 }
 
 // GetAuthenticatedUser retrieves the authenticated user's data and returns a ResponseWithUser struct containing the user's data and tokens.
@@ -51,6 +50,22 @@ func GetAuthenticatedUser(handlerCtx *configs.HandlersCtx, authenticatedUserID t
 		IsPRO:      u.IsPRO,
 		PostsLimit: u.PostsLimit,
 		Locale:     u.Locale,
+		IsVerified: u.IsVerified,
+
+		AnswersVisibility:    u.GetAnswersVisibility(),
+		Inbox:                u.Inbox,
+		IsReplyHistoryPublic: u.IsReplyHistoryPublic,
+
+		PendingEmail:   u.PendingEmail,
+		PasswordNotSet: u.PasswordNotSet,
+		DeleteAt:       u.DeleteAt,
+	}
+
+	if u.TwoFactor != nil {
+		user.TwoFactor = &TwoFactor{
+			IsEnabled: u.TwoFactor.IsEnabled,
+			EnabledAt: u.TwoFactor.EnabledAt,
+		}
 	}
 
 	return user, nil
@@ -58,25 +73,65 @@ func GetAuthenticatedUser(handlerCtx *configs.HandlersCtx, authenticatedUserID t
 
 // FindUserByNick searches for a user with the given nickname in the given users repository,
 // and returns the corresponding User object, if it exists.
-// If the user with the given nickname is not found, an error is returned.
+// Old nicks that were not released yet also find the user, with RedirectedFrom set to the old nick, so shared profile links keep working.
+// If the user with the given nickname is not found, or the account is pending deletion, an error is returned.
 // If an error occurs while checking if the user exists, that error is returned as well.
 func FindUserByNick(handlerCtx *configs.HandlersCtx, nick string, usersRepository *UsersRepository) (*User, error) {
 	u := usersRepository.FindUserByNick(nick)
+	redirectedFrom := ""
+
+	if toolkitEntities.IsZeroID(u.ID) {
+		if change := usersRepository.FindNickChangeByNick(nick); !toolkitEntities.IsZeroID(change.ID) {
+			u = usersRepository.FindUserByID(change.UserID)
+			redirectedFrom = nick
+		}
+	}
 
 	if err := UserExists(u); err != nil {
 		return nil, err
 	}
 
+	if err := IsActive(u); err != nil {
+		return nil, err
+	}
+
 	user := &User{
-		ID:        u.ID,
-		Nick:      u.Nick,
-		Name:      u.Name,
-		AvatarURL: u.AvatarURL,
+		ID:             u.ID,
+		Nick:           u.Nick,
+		Name:           u.Name,
+		AvatarURL:      u.AvatarURL,
+		RedirectedFrom: redirectedFrom,
+
+		AnswersVisibility: u.GetAnswersVisibility(),
+		Inbox:             u.GetInbox().Public(),
 	}
 
 	return user, nil
 }
 
+// GetNickReservationPeriod returns the configured NICK_RESERVATION_PERIOD, defaulting to NICK_DEFAULT_RESERVATION_PERIOD.
+func GetNickReservationPeriod(cfg *configs.Conf) time.Duration {
+	if cfg.Nicks.ReservationPeriod > 0 {
+		return time.Hour * time.Duration(cfg.Nicks.ReservationPeriod)
+	}
+
+	return NICK_DEFAULT_RESERVATION_PERIOD
+}
+
+// GetNickChangeInterval returns the configured NICK_CHANGE_INTERVAL, defaulting to NICK_DEFAULT_CHANGE_INTERVAL.
+func GetNickChangeInterval(cfg *configs.Conf) time.Duration {
+	if cfg.Nicks.ChangeInterval > 0 {
+		return time.Hour * time.Duration(cfg.Nicks.ChangeInterval)
+	}
+
+	return NICK_DEFAULT_CHANGE_INTERVAL
+}
+
+// GetNickHistory gets the old nicks of the authenticated user, the newest first.
+func GetNickHistory(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, usersRepository *UsersRepository) (*[]NickChange, error) {
+	return usersRepository.FindNickChanges(authenticatedUserID)
+}
+
 // DecrementUserLimit decrements the posts limit of the user with the given ID by one.
 // If the user is a PRO member, their limit will not be decremented and no error will be returned.
 // If an error occurs while decrementing the limit, that error will be returned.
@@ -233,10 +288,36 @@ func ResetLimit(u *User, usersRepository *UsersRepository) error {
 	return usersRepository.ResetLimit(u.ID)
 }
 
+// CheckNickAvailability returns a *nicks.UnavailableError, with available nicks to suggest, if the normalised nick
+// mixes scripts, is reserved, or looks like the nick of another user. See nicks.Policy and nicks.Skeleton.
+// userID is the user choosing the nick, whose own nick is ignored. It is a zero ID on sign up.
+func CheckNickAvailability(handlerCtx *configs.HandlersCtx, nick string, userID toolkitEntities.ID, usersRepository *UsersRepository) error {
+	err := handlerCtx.Nicks.Validate(nick)
+
+	if err == nil {
+		err = IsNickInUse(usersRepository.IsNickInUse(nick, userID))
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	suggestions := handlerCtx.Nicks.Suggest(nick, func(suggestion string) bool {
+		return !usersRepository.IsNickInUse(suggestion, userID)
+	})
+
+	return &nicks.UnavailableError{Key: err.Error(), Suggestions: suggestions}
+}
+
 // UpdateUserProfile updates the profile of the user with the given ID using the provided payload.
 // It takes four parameters, a HandlerCtx, an UpdateProfileDTO payload, an authenticatedUserID of type toolkitEntities.ID,
 // and a UsersRepository, and returns an error if the update is unsuccessful.
+// A new nick must be available, see CheckNickAvailability, and it can be changed once every NICK_CHANGE_INTERVAL.
+// The old nick is kept on the history, reserved for the user for NICK_RESERVATION_PERIOD.
+// A new email is stored as pending and a verification link is sent to it. It only replaces the current email once verified.
 func UpdateUserProfile(handlerCtx *configs.HandlersCtx, payload *UpdateProfileDTO, authenticatedUserID toolkitEntities.ID, usersRepository *UsersRepository) error {
+	payload.Format()
+
 	if err := payload.Validate(); err != nil {
 		return err
 	}
@@ -254,9 +335,33 @@ func UpdateUserProfile(handlerCtx *configs.HandlersCtx, payload *UpdateProfileDT
 		}
 	}
 
+	// the new email is not active until it is verified, so the current one is kept
+	newEmail := payload.Email
+	payload.Email = u.Email
+
 	// if new value equals to prev value, do not update
-	if payload.Nick != u.Nick {
-		if err := IsNickInUse(usersRepository.IsNickInUse(payload.Nick)); err != nil {
+	isNickChanged := payload.Nick != u.Nick
+
+	changedAt := time.Now()
+
+	if isNickChanged {
+		interval := GetNickChangeInterval(handlerCtx.Cfg)
+
+		if err := CanChangeNick(u, interval); err != nil {
+			return err
+		}
+
+		if err := CheckNickAvailability(handlerCtx, payload.Nick, u.ID, usersRepository); err != nil {
+			return err
+		}
+
+		claimed, err := usersRepository.ClaimNickChange(u.ID, changedAt, interval)
+
+		if err != nil {
+			return err
+		}
+
+		if err := WasNickChangeClaimed(claimed); err != nil {
 			return err
 		}
 	}
@@ -265,24 +370,185 @@ func UpdateUserProfile(handlerCtx *configs.HandlersCtx, payload *UpdateProfileDT
 		return err
 	}
 
-	return nil
+	if isNickChanged {
+		change := &NickChange{
+			ID:           toolkitEntities.NewID(),
+			UserID:       u.ID,
+			Nick:         u.Nick,
+			NickSkeleton: nicks.Skeleton(u.Nick),
+			ChangedAt:    changedAt,
+			ReleasedAt:   changedAt.Add(GetNickReservationPeriod(handlerCtx.Cfg)),
+		}
+
+		if err := usersRepository.RecordNickChange(change, payload.Nick); err != nil {
+			return err
+		}
+	}
+
+	if newEmail == u.Email {
+		// going back to the current email cancels the pending change
+		if u.PendingEmail != "" {
+			return usersRepository.SetPendingEmail(authenticatedUserID, "")
+		}
+
+		return nil
+	}
+
+	// the verification email was already sent, the user can ask to resend it
+	if newEmail == u.PendingEmail {
+		return nil
+	}
+
+	if err := usersRepository.SetPendingEmail(authenticatedUserID, newEmail); err != nil {
+		return err
+	}
+
+	u.PendingEmail = newEmail
+
+	return SendVerificationEmail(handlerCtx, u, usersRepository)
+}
+
+// CreateEmailVerificationToken creates the signed token sent on verification links.
+// The token is signed with the cipher key instead of the JWT secret, so it can't be used as an access token.
+// It expires after EMAIL_VERIFICATION_EXPIRES_IN hours.
+func CreateEmailVerificationToken(cfg *configs.Conf, userID toolkitEntities.ID, email string) (string, error) {
+	expiresIn := EMAIL_VERIFICATION_DEFAULT_EXPIRES_IN
+
+	if cfg.Verification.EmailVerificationExpiresIn > 0 {
+		expiresIn = time.Hour * time.Duration(cfg.Verification.EmailVerificationExpiresIn)
+	}
+
+	claims := EmailVerificationClaims{
+		ID:    userID,
+		Email: email,
+		Type:  EMAIL_VERIFICATION_TOKEN_TYPE,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(cfg.Crypto.Key))
+}
+
+// ParseEmailVerificationToken parses and verifies a token created by CreateEmailVerificationToken.
+// It returns an error if the signature is invalid, the token is expired or it is not a verification token.
+func ParseEmailVerificationToken(cfg *configs.Conf, token string) (*EmailVerificationClaims, error) {
+	claims := &EmailVerificationClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+
+		return []byte(cfg.Crypto.Key), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := IsVerificationTokenValid(claims.Type == EMAIL_VERIFICATION_TOKEN_TYPE && claims.Email != ""); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// SendVerificationEmail sends a verification link to the email that is waiting for verification, see User.EmailToVerify.
+// It returns an error if there is nothing to verify or if a verification email was sent less than EMAIL_VERIFICATION_RESEND_INTERVAL seconds ago.
+func SendVerificationEmail(handlerCtx *configs.HandlersCtx, u *User, usersRepository *UsersRepository) error {
+	emailToVerify := u.EmailToVerify()
+
+	if err := HasEmailToVerify(emailToVerify); err != nil {
+		return err
+	}
+
+	resendInterval := EMAIL_VERIFICATION_DEFAULT_RESEND_INTERVAL
+
+	if handlerCtx.Cfg.Verification.EmailVerificationResendInterval > 0 {
+		resendInterval = time.Second * time.Duration(handlerCtx.Cfg.Verification.EmailVerificationResendInterval)
+	}
+
+	if err := CanSendVerificationEmail(usersRepository.MarkVerificationEmailSent(u.ID, resendInterval)); err != nil {
+		return err
+	}
+
+	token, err := CreateEmailVerificationToken(handlerCtx.Cfg, u.ID, emailToVerify)
+
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", handlerCtx.Cfg.App.FrontendURL, token)
+
+	return verifications.SendEmailVerification(handlerCtx, emailToVerify, link)
+}
+
+// ResendVerificationEmail sends a new verification link to the authenticated user. See SendVerificationEmail.
+func ResendVerificationEmail(handlerCtx *configs.HandlersCtx, authenticatedUserID toolkitEntities.ID, usersRepository *UsersRepository) error {
+	u := usersRepository.FindUserByID(authenticatedUserID)
+
+	if err := UserExists(u); err != nil {
+		return err
+	}
+
+	return SendVerificationEmail(handlerCtx, u, usersRepository)
+}
+
+// VerifyEmail verifies the email of a verification token created by CreateEmailVerificationToken.
+// If the email is a pending email, it replaces the current email of the user.
+// The token only works while its email is still waiting for verification, so it can't be used twice.
+func VerifyEmail(handlerCtx *configs.HandlersCtx, token string, usersRepository *UsersRepository) error {
+	claims, err := ParseEmailVerificationToken(handlerCtx.Cfg, token)
+
+	if err := IsVerificationTokenValid(err == nil); err != nil {
+		return err
+	}
+
+	u := usersRepository.FindUserByID(claims.ID)
+
+	if err := UserExists(u); err != nil {
+		return err
+	}
+
+	if err := IsVerificationTokenValid(claims.Email == u.EmailToVerify()); err != nil {
+		return err
+	}
+
+	// another user may have taken the email while it was pending
+	if claims.Email != u.Email {
+		if err := IsEmailInUse(usersRepository.IsEmailInUse(claims.Email)); err != nil {
+			return err
+		}
+	}
+
+	return usersRepository.VerifyEmail(u.ID, claims.Email)
 }
 
 // DecodeUserToken decodes an user JWT token and returns user's ID.
+// On routes protected by a middleware, the token that was already verified is used, which can also be a personal access token.
+// Otherwise, the token is verified by the keyring, an empty result is returned if it is invalid or expired.
 func DecodeUserToken(cfg *configs.HandlersCtx) toolkitEntities.DecodeUserTokenResult {
 	claims := jwt.MapClaims{}
 
-	t := strings.Split(cfg.C.Get("Authorization"), "Bearer ")
+	if token, ok := cfg.C.Locals("user").(*jwt.Token); ok && token.Valid {
+		claims, _ = token.Claims.(jwt.MapClaims)
+	} else {
+		t := strings.Split(cfg.C.Get("Authorization"), "Bearer ")
 
-	if len(t) == 1 {
-		return toolkitEntities.DecodeUserTokenResult{}
-	}
+		if len(t) == 1 {
+			return toolkitEntities.DecodeUserTokenResult{}
+		}
 
-	jwt.ParseWithClaims(t[1], &claims, func(token *jwt.Token) (interface{}, error) {
-		return cfg.Cfg.JWT.Secret, nil
-	})
+		if _, err := cfg.Keyring.Parse(t[1], &claims); err != nil {
+			return toolkitEntities.DecodeUserTokenResult{}
+		}
+	}
 
-	parsedID, _ := toolkitEntities.ParseID(claims["id"].(string))
+	id, _ := claims["id"].(string)
+	parsedID, _ := toolkitEntities.ParseID(id)
 
 	u := toolkitEntities.DecodeUserTokenResult{
 		ID: parsedID,
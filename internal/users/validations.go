@@ -2,6 +2,7 @@ package users
 
 import (
 	"errors"
+	"time"
 
 	pkgErrors "github.com/quessapp/core-go/pkg/errors"
 	toolkitEntities "github.com/quessapp/toolkit/entities"
@@ -18,6 +19,16 @@ func UserExists(u *User) error {
 	return nil
 }
 
+// IsActive returns error if the user requested the deletion of the account.
+// Accounts pending deletion are hidden as if they did not exist.
+func IsActive(u *User) error {
+	if u.IsPendingDeletion() {
+		return errors.New(pkgErrors.USER_NOT_FOUND)
+	}
+
+	return nil
+}
+
 // ReachedMaxSizeLimit checks if the file size is greater than or equal to 1MB.
 // It returns an error if the file size exceeds the limit, otherwise it returns nil.
 func ReachedMaxSizeLimit(fileSize int64) error {
@@ -58,3 +69,58 @@ func IsNickInUse(isNickInUse bool) error {
 
 	return nil
 }
+
+// CanChangeNick returns error if the user changed the nick less than interval ago.
+func CanChangeNick(u *User, interval time.Duration) error {
+	if u.NickChangedAt != nil && time.Since(*u.NickChangedAt) < interval {
+		return errors.New(pkgErrors.NICK_CHANGED_RECENTLY)
+	}
+
+	return nil
+}
+
+// WasNickChangeClaimed returns error if the nick change was not claimed, since a concurrent request may have changed the nick first.
+func WasNickChangeClaimed(claimed bool) error {
+	if !claimed {
+		return errors.New(pkgErrors.NICK_CHANGED_RECENTLY)
+	}
+
+	return nil
+}
+
+// IsEmailVerified returns error if the user did not verify their email yet.
+func IsEmailVerified(u *User) error {
+	if !u.IsVerified {
+		return errors.New(pkgErrors.EMAIL_NOT_VERIFIED)
+	}
+
+	return nil
+}
+
+// HasEmailToVerify returns error if there is no email waiting for verification.
+func HasEmailToVerify(emailToVerify string) error {
+	if emailToVerify == "" {
+		return errors.New(pkgErrors.EMAIL_ALREADY_VERIFIED)
+	}
+
+	return nil
+}
+
+// CanSendVerificationEmail returns error if a verification email was sent recently.
+func CanSendVerificationEmail(canSend bool) error {
+	if !canSend {
+		return errors.New(pkgErrors.VERIFICATION_EMAIL_RECENTLY_SENT)
+	}
+
+	return nil
+}
+
+// IsVerificationTokenValid returns error if the verification token is invalid, expired or
+// was issued for an email that is no longer waiting for verification.
+func IsVerificationTokenValid(isValid bool) error {
+	if !isValid {
+		return errors.New(pkgErrors.VERIFICATION_TOKEN_INVALID)
+	}
+
+	return nil
+}
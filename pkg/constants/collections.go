@@ -0,0 +1,22 @@
+package constants
+
+// Collections owned by core. The shared collections (users, questions, etc.) are defined in the toolkit constants.
+const (
+	IDENTITIES  = "identities"
+	OIDC_STATES = "oidc_states"
+
+	SIGN_IN_ATTEMPTS      = "sign_in_attempts"
+	PASSWORDLESS_SIGN_INS = "passwordless_sign_ins"
+
+	SIGNING_KEYS = "signing_keys"
+
+	TRUSTED_LOCATIONS = "trusted_locations"
+	PERSONAL_TOKENS   = "personal_tokens"
+
+	ROLE_CHANGES = "role_changes"
+	DATA_EXPORTS = "data_exports"
+	NICK_HISTORY = "nick_history"
+
+	REACTIONS  = "reactions"
+	FOLLOW_UPS = "follow_ups"
+)
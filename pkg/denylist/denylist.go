@@ -0,0 +1,174 @@
+package denylist
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// KEY_PREFIX is the prefix of the denylist keys on the cache.
+	KEY_PREFIX = "denylist"
+	// CACHE_TIMEOUT is how long a cache operation can take before the in-memory entries are used alone.
+	CACHE_TIMEOUT = 200 * time.Millisecond
+)
+
+// Denylist holds the revoked tokens until they expire: single tokens, by their ID ("jti" claim),
+// and every token of an user issued before a time, the user watermark.
+//
+// Entries are stored on Redis, shared by every instance of the API, and in memory.
+// The in-memory entries are the fallback when Redis is not configured or not available,
+// so the tokens revoked by an instance are always rejected by it.
+type Denylist struct {
+	cache   *redis.Client
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// New creates a denylist. The cache can be nil, to keep the entries only in memory.
+func New(cache *redis.Client) *Denylist {
+	return &Denylist{
+		cache:   cache,
+		entries: map[string]entry{},
+	}
+}
+
+func getTokenKey(tokenID string) string {
+	return fmt.Sprintf("%s:token:%s", KEY_PREFIX, tokenID)
+}
+
+func getUserKey(userID string) string {
+	return fmt.Sprintf("%s:user:%s", KEY_PREFIX, userID)
+}
+
+// RevokeToken revokes the token with the given ID until it expires.
+func (d *Denylist) RevokeToken(tokenID string, expiresAt time.Time) {
+	d.set(getTokenKey(tokenID), 1, time.Until(expiresAt))
+}
+
+// RevokeUserTokens revokes every token of the user issued until now, including the ones issued in the current second,
+// since the issue time of the tokens has no fraction of seconds.
+// The watermark is kept for ttl, that must be the lifetime of the longest token.
+func (d *Denylist) RevokeUserTokens(userID string, ttl time.Duration) {
+	d.set(getUserKey(userID), time.Now().Unix(), ttl)
+}
+
+// IsRevoked returns true if the token with the given ID was revoked, or if it was issued until the watermark of the user.
+func (d *Denylist) IsRevoked(tokenID, userID string, issuedAt time.Time) bool {
+	keys := []string{getUserKey(userID)}
+
+	if tokenID != "" {
+		keys = append(keys, getTokenKey(tokenID))
+	}
+
+	values := d.get(keys)
+
+	if watermark, ok := values[getUserKey(userID)]; ok && issuedAt.Unix() <= watermark {
+		return true
+	}
+
+	_, isTokenRevoked := values[getTokenKey(tokenID)]
+
+	return tokenID != "" && isTokenRevoked
+}
+
+// IsClaimsRevoked reads the "jti", "id" and "iat" claims of an user token and checks them with IsRevoked.
+// Tokens without "iat", issued before the denylist, are revoked by any watermark of the user.
+func (d *Denylist) IsClaimsRevoked(claims jwt.MapClaims) bool {
+	tokenID, _ := claims["jti"].(string)
+	userID, _ := claims["id"].(string)
+	issuedAt, _ := claims["iat"].(float64)
+
+	return d.IsRevoked(tokenID, userID, time.Unix(int64(issuedAt), 0))
+}
+
+// set stores the entry in memory and on the cache. Cache errors are only logged, since the in-memory entry is already set.
+func (d *Denylist) set(key string, value int64, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	d.mu.Lock()
+
+	for k, e := range d.entries {
+		if !now.Before(e.expiresAt) {
+			delete(d.entries, k)
+		}
+	}
+
+	if e, ok := d.entries[key]; !ok || e.value < value || e.expiresAt.Before(now.Add(ttl)) {
+		d.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+	}
+
+	d.mu.Unlock()
+
+	if d.cache == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), CACHE_TIMEOUT)
+	defer cancel()
+
+	if err := d.cache.Set(ctx, key, value, ttl).Err(); err != nil {
+		log.Printf("Error writing %s to the cache, keeping it only in memory: %v", key, err)
+	}
+}
+
+// get returns the values of the keys found in memory or on the cache.
+func (d *Denylist) get(keys []string) map[string]int64 {
+	now := time.Now()
+	values := map[string]int64{}
+
+	d.mu.Lock()
+
+	for _, key := range keys {
+		if e, ok := d.entries[key]; ok && now.Before(e.expiresAt) {
+			values[key] = e.value
+		}
+	}
+
+	d.mu.Unlock()
+
+	if d.cache == nil {
+		return values
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), CACHE_TIMEOUT)
+	defer cancel()
+
+	cached, err := d.cache.MGet(ctx, keys...).Result()
+
+	if err != nil {
+		log.Printf("Error reading the denylist from the cache, using the in-memory entries: %v", err)
+		return values
+	}
+
+	for i, c := range cached {
+		s, ok := c.(string)
+
+		if !ok {
+			continue
+		}
+
+		value, err := strconv.ParseInt(s, 10, 64)
+
+		if err == nil && value > values[keys[i]] {
+			values[keys[i]] = value
+		}
+	}
+
+	return values
+}
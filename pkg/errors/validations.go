@@ -100,3 +100,200 @@ const (
 const (
 	TRUST_IP_FIELD_REQUIRED = "trust_ip_field_required"
 )
+
+const (
+	INCORRECT_PASSWORD = "incorrect_password"
+
+	TWO_FACTOR_ALREADY_ENABLED = "two_factor_already_enabled"
+	TWO_FACTOR_NOT_ENABLED     = "two_factor_not_enabled"
+	TWO_FACTOR_NOT_ENROLLED    = "two_factor_not_enrolled"
+	TWO_FACTOR_CODE_REQUIRED   = "two_factor_code_required"
+	TWO_FACTOR_CODE_INVALID    = "two_factor_code_invalid"
+
+	CHALLENGE_TOKEN_REQUIRED = "challenge_token_required"
+	CHALLENGE_TOKEN_INVALID  = "challenge_token_invalid"
+)
+
+const (
+	EMAIL_NOT_VERIFIED               = "email_not_verified"
+	EMAIL_ALREADY_VERIFIED           = "email_already_verified"
+	VERIFICATION_TOKEN_REQUIRED      = "verification_token_required"
+	VERIFICATION_TOKEN_INVALID       = "verification_token_invalid"
+	VERIFICATION_EMAIL_RECENTLY_SENT = "verification_email_recently_sent"
+)
+
+const (
+	OIDC_PROVIDER_NOT_FOUND         = "oidc_provider_not_found"
+	OIDC_STATE_REQUIRED             = "oidc_state_required"
+	OIDC_STATE_INVALID              = "oidc_state_invalid"
+	OIDC_AUTHENTICATION_FAILED      = "oidc_authentication_failed"
+	OIDC_EMAIL_NOT_VERIFIED         = "oidc_email_not_verified"
+	IDENTITY_NOT_FOUND              = "identity_not_found"
+	IDENTITY_NOT_LINKED             = "identity_not_linked"
+	IDENTITY_ALREADY_LINKED         = "identity_already_linked"
+	IDENTITY_LINKED_TO_ANOTHER_USER = "identity_linked_to_another_user"
+	CANT_UNLINK_LAST_SIGN_IN_METHOD = "cant_unlink_last_sign_in_method"
+)
+
+const (
+	TOO_MANY_SIGN_IN_ATTEMPTS = "too_many_sign_in_attempts"
+	ADMIN_NOT_AUTHORIZED      = "admin_not_authorized"
+)
+
+const (
+	PASSWORD_TOO_SHORT              = "password_too_short"
+	PASSWORD_TOO_WEAK               = "password_too_weak"
+	PASSWORD_CONTAINS_PERSONAL_INFO = "password_contains_personal_info"
+	PASSWORD_BREACHED               = "password_breached"
+)
+
+const (
+	TOKEN_REVOKED          = "token_revoked"
+	ACCESS_TOKEN_REQUIRED  = "access_token_required"
+	USER_BANNED            = "user_banned"
+	BAN_NOT_FOUND          = "ban_not_found"
+	BAN_REASON_LENGTH      = "ban_reason_length"
+	BAN_EXPIRES_AT_INVALID = "ban_expires_at_invalid"
+	CANT_BAN_YOURSELF      = "cant_ban_yourself"
+	CANT_BAN_USER_ROLE     = "cant_ban_user_role"
+)
+
+const (
+	DEVICE_TOKEN_REQUIRED           = "device_token_required"
+	PASSWORDLESS_CODE_REQUIRED      = "passwordless_code_required"
+	PASSWORDLESS_CODE_INVALID       = "passwordless_code_invalid"
+	PASSWORDLESS_RECENTLY_REQUESTED = "passwordless_recently_requested"
+)
+
+const (
+	TRUSTED_LOCATION_NOT_FOUND      = "trusted_location_not_found"
+	TRUSTED_LOCATION_REQUIRED       = "trusted_location_required"
+	TRUSTED_LOCATION_CIDR_INVALID   = "trusted_location_cidr_invalid"
+	TRUSTED_LOCATION_CIDR_TOO_BROAD = "trusted_location_cidr_too_broad"
+	TRUSTED_LOCATION_ALREADY_EXISTS = "trusted_location_already_exists"
+	TRUSTED_LOCATION_ASN_NOT_OWN    = "trusted_location_asn_not_own"
+)
+
+const (
+	PERSONAL_TOKEN_INVALID            = "personal_token_invalid"
+	PERSONAL_TOKEN_NOT_FOUND          = "personal_token_not_found"
+	PERSONAL_TOKEN_NAME_REQUIRED      = "personal_token_name_required"
+	PERSONAL_TOKEN_NAME_LENGTH        = "personal_token_name_length"
+	PERSONAL_TOKEN_SCOPES_REQUIRED    = "personal_token_scopes_required"
+	PERSONAL_TOKEN_SCOPE_INVALID      = "personal_token_scope_invalid"
+	PERSONAL_TOKEN_EXPIRES_AT_INVALID = "personal_token_expires_at_invalid"
+	PERSONAL_TOKENS_LIMIT_REACHED     = "personal_tokens_limit_reached"
+	INSUFFICIENT_SCOPE                = "insufficient_scope"
+)
+
+const (
+	ROLE_REQUIRED             = "role_required"
+	ROLE_INVALID              = "role_invalid"
+	ROLE_ALREADY_ASSIGNED     = "role_already_assigned"
+	ROLE_CHANGE_REASON_LENGTH = "role_change_reason_length"
+	CANT_CHANGE_OWN_ROLE      = "cant_change_own_role"
+	CANT_REMOVE_LAST_ADMIN    = "cant_remove_last_admin"
+)
+
+const (
+	ACCOUNT_DELETION_ALREADY_REQUESTED = "account_deletion_already_requested"
+	ACCOUNT_DELETION_NOT_REQUESTED     = "account_deletion_not_requested"
+	REAUTHENTICATION_REQUIRED          = "reauthentication_required"
+)
+
+const (
+	DATA_EXPORT_IN_PROGRESS        = "data_export_in_progress"
+	DATA_EXPORT_REQUESTED_RECENTLY = "data_export_requested_recently"
+)
+
+const (
+	NICK_RESERVED      = "nick_reserved"
+	NICK_MIXED_SCRIPTS = "nick_mixed_scripts"
+)
+
+const (
+	NICK_CHANGED_RECENTLY = "nick_changed_recently"
+)
+
+const (
+	PAGINATION_LIMIT_INVALID  = "pagination_limit_invalid"
+	PAGINATION_CURSOR_INVALID = "pagination_cursor_invalid"
+	PAGINATION_TOTAL_INVALID  = "pagination_total_invalid"
+)
+
+const (
+	ANSWERS_VISIBILITY_INVALID = "answers_visibility_invalid"
+	ANSWERS_PRIVATE            = "answers_private"
+	ANSWERS_SIGN_IN_REQUIRED   = "answers_sign_in_required"
+	ANSWERS_BLOCKED            = "answers_blocked"
+)
+
+const (
+	REACTION_TYPE_REQUIRED = "reaction_type_required"
+	REACTION_TYPE_INVALID  = "reaction_type_invalid"
+	REACTION_NOT_REPLIED   = "reaction_not_replied"
+	REACTION_BLOCKED       = "reaction_blocked"
+)
+
+const (
+	FOLLOW_UP_NOT_FOUND            = "follow_up_not_found"
+	FOLLOW_UP_NOT_SENDER           = "follow_up_not_sender"
+	FOLLOW_UP_QUESTION_NOT_REPLIED = "follow_up_question_not_replied"
+	FOLLOW_UP_PENDING_REPLY        = "follow_up_pending_reply"
+	FOLLOW_UPS_LIMIT_REACHED       = "follow_ups_limit_reached"
+	FOLLOW_UP_ALREADY_REPLIED      = "follow_up_already_replied"
+	FOLLOW_UP_HIDDEN               = "follow_up_hidden"
+	FOLLOW_UP_BLOCKED              = "follow_up_blocked"
+)
+
+const (
+	MEDIA_CORRUPTED               = "media_corrupted"
+	AUDIO_TOO_LONG                = "audio_too_long"
+	REPLY_ATTACHMENTS_LIMIT       = "reply_attachments_limit"
+	REPLY_AUDIO_ATTACHMENTS_LIMIT = "reply_audio_attachments_limit"
+)
+
+const (
+	POLL_OPTIONS_LENGTH     = "poll_options_length"
+	POLL_OPTION_INVALID     = "poll_option_invalid"
+	POLL_OPTIONS_DUPLICATED = "poll_options_duplicated"
+	POLL_NOT_FOUND          = "poll_not_found"
+	POLL_ALREADY_SENT       = "poll_already_sent"
+	POLL_NOT_AUTHORIZED     = "poll_not_authorized"
+	POLL_CHOICE_REQUIRED    = "poll_choice_required"
+	POLL_CHOICE_INVALID     = "poll_choice_invalid"
+	POLL_REPLY_NOT_EDITABLE = "poll_reply_not_editable"
+	POLL_COMMENT_LENGTH     = "poll_comment_length"
+	QUESTION_NOT_POLL       = "question_not_poll"
+)
+
+const (
+	INBOX_MIN_ACCOUNT_AGE_INVALID = "inbox_min_account_age_invalid"
+	INBOX_DAILY_LIMIT_INVALID     = "inbox_daily_limit_invalid"
+	INBOX_PAUSED_MESSAGE_LENGTH   = "inbox_paused_message_length"
+	INBOX_PAUSED                  = "inbox_paused"
+	INBOX_ANONYMOUS_DENIED        = "inbox_anonymous_denied"
+	INBOX_VERIFIED_EMAIL_REQUIRED = "inbox_verified_email_required"
+	INBOX_ACCOUNT_TOO_NEW         = "inbox_account_too_new"
+	INBOX_DAILY_LIMIT_REACHED     = "inbox_daily_limit_reached"
+)
+
+const (
+	SEARCH_QUERY_REQUIRED    = "search_query_required"
+	SEARCH_QUERY_LENGTH      = "search_query_length"
+	SEARCH_BOX_INVALID       = "search_box_invalid"
+	SEARCH_ANONYMOUS_INVALID = "search_anonymous_invalid"
+	SEARCH_DATE_INVALID      = "search_date_invalid"
+	SEARCH_PERIOD_INVALID    = "search_period_invalid"
+)
+
+const (
+	DRAFT_PUBLISH_AT_PAST    = "draft_publish_at_past"
+	DRAFT_PUBLISH_AT_TOO_FAR = "draft_publish_at_too_far"
+	DRAFT_NOT_FOUND          = "draft_not_found"
+)
+
+const (
+	REPLY_HISTORY_NOT_REPLIED = "reply_history_not_replied"
+	REPLY_HISTORY_PRIVATE     = "reply_history_private"
+)
@@ -0,0 +1,274 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// Data types of the MaxMind DB data section.
+// See https://maxmind.github.io/MaxMind-DB/#output-data-section
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBoolean
+	typeFloat
+)
+
+// MAX_DEPTH is how deep maps and arrays can be nested, so a corrupted database can't exhaust the stack.
+const MAX_DEPTH = 32
+
+// ErrInvalidDatabase is returned when the database is not a valid MaxMind DB file.
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB database")
+
+// decoder decodes values of a MaxMind DB data section. Pointers are relative to the start of buf.
+type decoder struct {
+	buf []byte
+}
+
+// decode decodes the value at the given offset and returns it along with the offset of the next value.
+// Maps are decoded to map[string]any, arrays to []any, unsigned integers to uint64 (or *big.Int for uint128),
+// signed integers to int64 and floats to float64.
+func (d *decoder) decode(offset uint, depth int) (any, uint, error) {
+	if depth > MAX_DEPTH {
+		return nil, 0, ErrInvalidDatabase
+	}
+
+	dataType, size, offset, err := d.decodeControl(offset)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if dataType == typePointer {
+		pointer, next, err := d.decodePointer(size, offset)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		value, _, err := d.decode(pointer, depth+1)
+
+		return value, next, err
+	}
+
+	return d.decodeValue(dataType, size, offset, depth)
+}
+
+// decodeControl decodes the control byte at the given offset, returning the type, the size and the offset of the payload.
+// For pointers, size holds the raw control byte bits, see decodePointer.
+func (d *decoder) decodeControl(offset uint) (int, uint, uint, error) {
+	if offset >= uint(len(d.buf)) {
+		return 0, 0, 0, ErrInvalidDatabase
+	}
+
+	control := d.buf[offset]
+	offset++
+
+	dataType := int(control >> 5)
+
+	if dataType == typePointer {
+		return dataType, uint(control & 0x1f), offset, nil
+	}
+
+	if dataType == typeExtended {
+		if offset >= uint(len(d.buf)) {
+			return 0, 0, 0, ErrInvalidDatabase
+		}
+
+		dataType = 7 + int(d.buf[offset])
+		offset++
+	}
+
+	size := uint(control & 0x1f)
+
+	if size >= 29 {
+		extra := size - 28
+
+		if offset+extra > uint(len(d.buf)) {
+			return 0, 0, 0, ErrInvalidDatabase
+		}
+
+		value := uint(0)
+
+		for _, b := range d.buf[offset : offset+extra] {
+			value = value<<8 | uint(b)
+		}
+
+		offset += extra
+
+		switch extra {
+		case 1:
+			size = 29 + value
+		case 2:
+			size = 285 + value
+		default:
+			size = 65821 + value
+		}
+	}
+
+	return dataType, size, offset, nil
+}
+
+// decodePointer decodes a pointer, returning the offset it points to and the offset after the pointer.
+func (d *decoder) decodePointer(bits, offset uint) (uint, uint, error) {
+	pointerSize := ((bits >> 3) & 0x3) + 1
+
+	if offset+pointerSize > uint(len(d.buf)) {
+		return 0, 0, ErrInvalidDatabase
+	}
+
+	value := uint(0)
+
+	if pointerSize != 4 {
+		value = bits & 0x7
+	}
+
+	for _, b := range d.buf[offset : offset+pointerSize] {
+		value = value<<8 | uint(b)
+	}
+
+	switch pointerSize {
+	case 2:
+		value += 2048
+	case 3:
+		value += 526336
+	}
+
+	return value, offset + pointerSize, nil
+}
+
+// decodeValue decodes a value that is not a pointer.
+func (d *decoder) decodeValue(dataType int, size, offset uint, depth int) (any, uint, error) {
+	switch dataType {
+	case typeMap:
+		return d.decodeMap(size, offset, depth)
+	case typeArray:
+		return d.decodeArray(size, offset, depth)
+	case typeBoolean:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.buf)) {
+		return nil, 0, ErrInvalidDatabase
+	}
+
+	payload := d.buf[offset : offset+size]
+	next := offset + size
+
+	switch dataType {
+	case typeString:
+		return string(payload), next, nil
+	case typeBytes:
+		return append([]byte{}, payload...), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(payload)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(payload))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		value := uint64(0)
+
+		for _, b := range payload {
+			value = value<<8 | uint64(b)
+		}
+
+		return value, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		value := uint32(0)
+
+		for _, b := range payload {
+			value = value<<8 | uint32(b)
+		}
+
+		if size == 4 {
+			return int64(int32(value)), next, nil
+		}
+
+		return int64(value), next, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		return new(big.Int).SetBytes(payload), next, nil
+	}
+
+	return nil, 0, ErrInvalidDatabase
+}
+
+// decodeMap decodes a map of size key/value pairs. Keys must be strings.
+func (d *decoder) decodeMap(size, offset uint, depth int) (any, uint, error) {
+	m := make(map[string]any, size)
+
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decode(offset, depth+1)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		k, ok := key.(string)
+
+		if !ok {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		value, next, err := d.decode(next, depth+1)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		m[k] = value
+		offset = next
+	}
+
+	return m, offset, nil
+}
+
+// decodeArray decodes an array of size values.
+func (d *decoder) decodeArray(size, offset uint, depth int) (any, uint, error) {
+	a := make([]any, 0, size)
+
+	for i := uint(0); i < size; i++ {
+		value, next, err := d.decode(offset, depth+1)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		a = append(a, value)
+		offset = next
+	}
+
+	return a, offset, nil
+}
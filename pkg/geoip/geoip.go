@@ -0,0 +1,131 @@
+package geoip
+
+import (
+	"net"
+
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+)
+
+// LANGUAGE is the language of the names read from the City database.
+const LANGUAGE = "en"
+
+// ASN is the autonomous system an IP belongs to.
+type ASN struct {
+	Number       uint
+	Organization string
+	// Network is the network of the IP that is announced by the autonomous system, in CIDR notation.
+	Network string
+}
+
+// Resolver resolves the location and the autonomous system of IPs from local MaxMind DB files,
+// like GeoLite2-City and GeoLite2-ASN. No request is made to external services.
+// Both databases are optional, and a Resolver without them resolves nothing.
+type Resolver struct {
+	city *Reader
+	asn  *Reader
+}
+
+// NewResolver creates a Resolver from already loaded databases. Any of them can be nil.
+func NewResolver(city, asn *Reader) *Resolver {
+	return &Resolver{city: city, asn: asn}
+}
+
+// LoadResolver creates a Resolver from the City and ASN database files. Empty paths are skipped.
+func LoadResolver(cityFile, asnFile string) (*Resolver, error) {
+	r := &Resolver{}
+
+	if cityFile != "" {
+		city, err := Open(cityFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.city = city
+	}
+
+	if asnFile != "" {
+		asn, err := Open(asnFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.asn = asn
+	}
+
+	return r, nil
+}
+
+// Location resolves the location of an IP. It returns nil if the IP can't be resolved.
+func (r *Resolver) Location(ip string) *toolkitEntities.Location {
+	parsedIP := net.ParseIP(ip)
+
+	if r == nil || r.city == nil || parsedIP == nil {
+		return nil
+	}
+
+	record, _, err := r.city.Lookup(parsedIP)
+
+	if err != nil || record == nil {
+		return nil
+	}
+
+	location := &toolkitEntities.Location{
+		CountryCode: getString(record, "country", "iso_code"),
+		CountryName: getString(record, "country", "names", LANGUAGE),
+		City:        getString(record, "city", "names", LANGUAGE),
+		Postal:      getString(record, "postal", "code"),
+		Latitude:    getFloat(record, "location", "latitude"),
+		Longitude:   getFloat(record, "location", "longitude"),
+	}
+
+	if subdivisions, ok := record["subdivisions"].([]any); ok && len(subdivisions) > 0 {
+		if subdivision, ok := subdivisions[0].(map[string]any); ok {
+			location.State = getString(subdivision, "names", LANGUAGE)
+		}
+	}
+
+	if parsedIP.To4() != nil {
+		location.IPv4 = parsedIP.String()
+	}
+
+	return location
+}
+
+// ASN resolves the autonomous system of an IP. It returns nil if the IP can't be resolved.
+func (r *Resolver) ASN(ip string) *ASN {
+	parsedIP := net.ParseIP(ip)
+
+	if r == nil || r.asn == nil || parsedIP == nil {
+		return nil
+	}
+
+	record, prefix, err := r.asn.Lookup(parsedIP)
+
+	if err != nil || record == nil {
+		return nil
+	}
+
+	number := getUint(record, "autonomous_system_number")
+
+	if number == 0 {
+		return nil
+	}
+
+	bits := 128
+
+	if parsedIP.To4() != nil {
+		bits = 32
+	}
+
+	network := net.IPNet{IP: parsedIP.Mask(net.CIDRMask(prefix, bits)), Mask: net.CIDRMask(prefix, bits)}
+
+	data := &ASN{
+		Number:       uint(number),
+		Organization: getString(record, "autonomous_system_organization"),
+		Network:      network.String(),
+	}
+
+	return data
+}
@@ -0,0 +1,255 @@
+package geoip
+
+import (
+	"bytes"
+	"net"
+	"os"
+)
+
+// METADATA_START_MARKER marks the start of the metadata section, at the end of the file.
+var METADATA_START_MARKER = []byte("\xab\xcd\xefMaxMind.com")
+
+// METADATA_MAX_SIZE is how many bytes from the end of the file are searched for the metadata.
+const METADATA_MAX_SIZE = 128 * 1024
+
+// DATA_SECTION_SEPARATOR_SIZE is the size of the zeroed bytes between the search tree and the data section.
+const DATA_SECTION_SEPARATOR_SIZE = 16
+
+// Metadata is the metadata of a MaxMind DB file.
+type Metadata struct {
+	DatabaseType string
+	IPVersion    uint
+	NodeCount    uint
+	RecordSize   uint
+	BuildEpoch   uint64
+}
+
+// Reader reads a MaxMind DB file, like the GeoLite2 City and ASN databases, fully loaded in memory.
+// See https://maxmind.github.io/MaxMind-DB/
+type Reader struct {
+	Metadata Metadata
+
+	buf        []byte
+	data       *decoder
+	ipv4Start  uint
+	ipv4Prefix int
+	nodeSize   uint
+}
+
+// Open reads the MaxMind DB file at the given path.
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReader(buf)
+}
+
+// NewReader parses a MaxMind DB file from its bytes.
+func NewReader(buf []byte) (*Reader, error) {
+	searchFrom := 0
+
+	if len(buf) > METADATA_MAX_SIZE {
+		searchFrom = len(buf) - METADATA_MAX_SIZE
+	}
+
+	markerIndex := bytes.LastIndex(buf[searchFrom:], METADATA_START_MARKER)
+
+	if markerIndex == -1 {
+		return nil, ErrInvalidDatabase
+	}
+
+	metadataStart := searchFrom + markerIndex + len(METADATA_START_MARKER)
+	metadataDecoder := &decoder{buf: buf[metadataStart:]}
+
+	value, _, err := metadataDecoder.decode(0, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := value.(map[string]any)
+
+	if !ok {
+		return nil, ErrInvalidDatabase
+	}
+
+	metadata := Metadata{
+		DatabaseType: getString(m, "database_type"),
+		IPVersion:    uint(getUint(m, "ip_version")),
+		NodeCount:    uint(getUint(m, "node_count")),
+		RecordSize:   uint(getUint(m, "record_size")),
+		BuildEpoch:   getUint(m, "build_epoch"),
+	}
+
+	if metadata.RecordSize != 24 && metadata.RecordSize != 28 && metadata.RecordSize != 32 {
+		return nil, ErrInvalidDatabase
+	}
+
+	if metadata.IPVersion != 4 && metadata.IPVersion != 6 {
+		return nil, ErrInvalidDatabase
+	}
+
+	nodeSize := metadata.RecordSize / 4
+	treeSize := metadata.NodeCount * nodeSize
+	dataStart := treeSize + DATA_SECTION_SEPARATOR_SIZE
+
+	if dataStart > uint(metadataStart-len(METADATA_START_MARKER)) {
+		return nil, ErrInvalidDatabase
+	}
+
+	r := &Reader{
+		Metadata: metadata,
+		buf:      buf,
+		data:     &decoder{buf: buf[dataStart : metadataStart-len(METADATA_START_MARKER)]},
+		nodeSize: nodeSize,
+	}
+
+	r.findIPv4Start()
+
+	return r, nil
+}
+
+// findIPv4Start finds the node where IPv4 addresses start on IPv6 databases, the ::/96 subtree.
+func (r *Reader) findIPv4Start() {
+	if r.Metadata.IPVersion != 6 {
+		return
+	}
+
+	node := uint(0)
+	i := 0
+
+	for ; i < 96 && node < r.Metadata.NodeCount; i++ {
+		node = r.readNode(node, 0)
+	}
+
+	r.ipv4Start = node
+	r.ipv4Prefix = i
+}
+
+// readNode reads the left (bit 0) or the right (bit 1) record of a node of the search tree.
+func (r *Reader) readNode(node uint, bit uint) uint {
+	offset := node * r.nodeSize
+	b := r.buf[offset : offset+r.nodeSize]
+
+	switch r.Metadata.RecordSize {
+	case 24:
+		b = b[bit*3:]
+
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xf0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		b = b[bit*4:]
+
+		return uint(b[0])<<24 | uint(b[1])<<16 | uint(b[2])<<8 | uint(b[3])
+	}
+}
+
+// Lookup finds the record of the given IP. It returns nil if the IP is not in the database.
+// The prefix length of the network the record belongs to is returned too.
+func (r *Reader) Lookup(ip net.IP) (map[string]any, int, error) {
+	address := ip.To4()
+	node := uint(0)
+	prefix := 0
+
+	if address != nil && r.Metadata.IPVersion == 6 {
+		node = r.ipv4Start
+		prefix = r.ipv4Prefix
+	}
+
+	if address == nil {
+		if r.Metadata.IPVersion == 4 {
+			return nil, 0, nil
+		}
+
+		address = ip.To16()
+
+		if address == nil {
+			return nil, 0, nil
+		}
+	}
+
+	bitCount := len(address) * 8
+	i := 0
+
+	for ; i < bitCount && node < r.Metadata.NodeCount; i++ {
+		bit := uint(address[i>>3]>>(7-uint(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	if node <= r.Metadata.NodeCount {
+		return nil, 0, nil
+	}
+
+	offset := node - r.Metadata.NodeCount - DATA_SECTION_SEPARATOR_SIZE
+
+	value, _, err := r.data.decode(offset, 0)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	record, ok := value.(map[string]any)
+
+	if !ok {
+		return nil, 0, ErrInvalidDatabase
+	}
+
+	prefix += i
+
+	// the prefix of IPv4 addresses on IPv6 databases is relative to the ::/96 subtree
+	if r.Metadata.IPVersion == 6 && ip.To4() != nil {
+		prefix -= 96
+
+		if prefix < 0 {
+			prefix = 0
+		}
+	}
+
+	return record, prefix, nil
+}
+
+// getString returns the string at the given path of a record, or an empty string if it is not a string.
+func getString(m map[string]any, path ...string) string {
+	s, _ := getValue(m, path...).(string)
+
+	return s
+}
+
+// getUint returns the unsigned integer at the given path of a record, or zero if it is not an unsigned integer.
+func getUint(m map[string]any, path ...string) uint64 {
+	n, _ := getValue(m, path...).(uint64)
+
+	return n
+}
+
+// getFloat returns the float at the given path of a record, or zero if it is not a float.
+func getFloat(m map[string]any, path ...string) float64 {
+	n, _ := getValue(m, path...).(float64)
+
+	return n
+}
+
+// getValue returns the value at the given path of nested maps of a record.
+func getValue(m map[string]any, path ...string) any {
+	var value any = m
+
+	for _, key := range path {
+		current, ok := value.(map[string]any)
+
+		if !ok {
+			return nil
+		}
+
+		value = current[key]
+	}
+
+	return value
+}
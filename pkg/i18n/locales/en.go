@@ -0,0 +1,171 @@
+package locales
+
+// GetAmericanEnglishTranslations returns a map with the american english translations owned by core.
+// Keys that are not found here are looked up in the toolkit translations.
+func GetAmericanEnglishTranslations() *map[string]string {
+	return &map[string]string{
+		"incorrect_password": "the provided password is incorrect",
+
+		"two_factor_already_enabled": "two-factor authentication is already enabled",
+		"two_factor_not_enabled":     "two-factor authentication is not enabled",
+		"two_factor_not_enrolled":    "you must start the two-factor enrolment before enabling it",
+		"two_factor_code_required":   "two-factor code field is required",
+		"two_factor_code_invalid":    "the provided two-factor code is invalid",
+		"challenge_token_required":   "challenge token field is required",
+		"challenge_token_invalid":    "the provided challenge token is invalid or has expired",
+
+		"email_not_verified":               "you must verify your email to do this",
+		"email_already_verified":           "your email is already verified",
+		"verification_token_required":      "verification token field is required",
+		"verification_token_invalid":       "the verification link is invalid or has expired",
+		"verification_email_recently_sent": "a verification email was sent recently, please wait before requesting another one",
+		"emails_verify_email_subject":      "Verify your email",
+		"emails_verify_email_body":         "Click on the link below to verify your email: ",
+
+		"oidc_provider_not_found":         "sign-in provider not found",
+		"oidc_state_required":             "state field is required",
+		"oidc_state_invalid":              "the sign-in request is invalid or has expired, please try again",
+		"oidc_authentication_failed":      "could not authenticate with the sign-in provider",
+		"oidc_email_not_verified":         "the email of your provider account is not verified",
+		"identity_not_found":              "this sign-in provider is not linked to your account",
+		"identity_not_linked":             "an account with this email already exists, sign in with your password and link the provider in your settings",
+		"identity_already_linked":         "this sign-in provider is already linked to your account",
+		"identity_linked_to_another_user": "this provider account is already linked to another user",
+		"cant_unlink_last_sign_in_method": "you can't unlink your only sign-in method, set a password first",
+
+		"too_many_sign_in_attempts":     "too many failed sign-in attempts, please try again later",
+		"admin_not_authorized":          "you are not authorized to access this resource",
+		"emails_account_locked_subject": "Your account was temporarily locked",
+		"emails_account_locked_body":    "We detected many failed sign-in attempts on your account, so it was temporarily locked. If it was not you, we recommend that you change your password and enable two-factor authentication.",
+
+		"password_too_short":              "the password is too short",
+		"password_too_weak":               "the password is too weak, use a longer password, mixing letters, numbers and symbols",
+		"password_contains_personal_info": "the password must not contain your nick, name or email",
+		"password_breached":               "this password has appeared in a data breach and can not be used, choose another password",
+
+		"token_revoked":          "this session has ended, please sign in again",
+		"access_token_required":  "only access tokens can authenticate requests",
+		"user_banned":            "this account is banned",
+		"ban_not_found":          "this user is not banned",
+		"ban_reason_length":      "the reason must have at most 500 characters",
+		"ban_expires_at_invalid": "the ban expiration date must be in the future",
+
+		"device_token_required":           "device token field is required",
+		"passwordless_code_required":      "code field is required",
+		"passwordless_code_invalid":       "the sign-in link or code is invalid or has expired",
+		"passwordless_recently_requested": "a sign-in link was recently sent, wait before requesting another",
+		"emails_passwordless_subject":     "Your sign-in link",
+		"emails_passwordless_body":        "Use the code %s or click on the link below to sign in. If it was not you, ignore this email: ",
+
+		"trusted_location_not_found":      "trusted location not found",
+		"trusted_location_required":       "a network or an autonomous system is required",
+		"trusted_location_cidr_invalid":   "the network must be in CIDR notation, like 177.10.0.0/16",
+		"trusted_location_cidr_too_broad": "the network is too broad to be trusted",
+		"trusted_location_already_exists": "this location is already trusted",
+		"trusted_location_asn_not_own":    "only the autonomous system of your current connection can be trusted",
+
+		"personal_token_invalid":            "personal access token is invalid or expired",
+		"personal_token_not_found":          "personal access token not found",
+		"personal_token_name_required":      "personal access token name is required",
+		"personal_token_name_length":        "personal access token name must have at most 100 characters",
+		"personal_token_scopes_required":    "at least one scope is required",
+		"personal_token_scope_invalid":      "one of the scopes is invalid",
+		"personal_token_expires_at_invalid": "expiration date must be in the future and at most one year from now",
+		"personal_tokens_limit_reached":     "you have reached the limit of personal access tokens",
+		"insufficient_scope":                "this token does not have the scope required by this action",
+
+		"role_required":             "role is required",
+		"role_invalid":              "role is invalid",
+		"role_already_assigned":     "user already has this role",
+		"role_change_reason_length": "reason must have at most 500 characters",
+		"cant_change_own_role":      "you can't change your own role",
+		"cant_remove_last_admin":    "the last admin can't lose the role",
+		"cant_ban_yourself":         "you can't ban yourself",
+		"cant_ban_user_role":        "you can't ban users with the same or a higher role",
+
+		"account_deletion_already_requested":        "the deletion of your account was already requested",
+		"account_deletion_not_requested":            "the deletion of your account was not requested",
+		"reauthentication_required":                 "please sign in again to confirm this action",
+		"emails_account_deletion_scheduled_subject": "Your account will be deleted",
+		"emails_account_deletion_scheduled_body":    "We received your request to delete your account. It will be deleted on %s, along with all your data. Cancel the deletion in the settings of your account before then if you want to reactivate it.",
+		"emails_account_deleted_subject":            "Your account was deleted",
+		"emails_account_deleted_body":               "Your account and all your data were deleted. Questions you sent to other users were kept anonymously.",
+
+		"data_export_in_progress":          "a copy of your data is already being prepared",
+		"data_export_requested_recently":   "you can request a copy of your data once a day",
+		"emails_data_export_ready_subject": "Your data is ready to download",
+		"emails_data_export_ready_body":    "The copy of your data you requested is ready. Download it until %s: %s",
+
+		"nick_reserved":      "this nick is reserved, try one of the suggestions",
+		"nick_mixed_scripts": "nick can't mix letters of different alphabets",
+
+		"nick_changed_recently": "you changed your nick recently, please try again later",
+
+		"pagination_limit_invalid":  "the page size must be a number between 1 and 100",
+		"pagination_cursor_invalid": "this page link is invalid, please reload the list",
+		"pagination_total_invalid":  "the total parameter must be true or false",
+
+		"answers_visibility_invalid": "the answers visibility must be public, users or private",
+		"answers_private":            "this user's answers are private",
+		"answers_sign_in_required":   "sign in to see this user's answers",
+		"answers_blocked":            "you can't see this user's answers",
+
+		"reaction_type_required": "the reaction is required",
+		"reaction_type_invalid":  "this reaction is not available",
+		"reaction_not_replied":   "you can only react to answered questions",
+		"reaction_blocked":       "you can't react to this answer",
+
+		"follow_up_not_found":              "follow-up not found",
+		"follow_up_not_sender":             "only the sender of the question can do this",
+		"follow_up_question_not_replied":   "you can only follow up on answered questions",
+		"follow_up_pending_reply":          "wait for a reply to your last follow-up before sending another one",
+		"follow_ups_limit_reached":         "this question reached the maximum number of follow-ups",
+		"follow_up_already_replied":        "this follow-up was already replied",
+		"follow_up_hidden":                 "this thread was hidden by the receiver",
+		"follow_up_blocked":                "you can't follow up on this question",
+		"emails_new_follow_up_subject":     "You received a follow-up on a question",
+		"emails_follow_up_replied_subject": "%s replied to your follow-up",
+
+		"media_corrupted":               "the file could not be read, it may be corrupted",
+		"audio_too_long":                "audio clips must be at most 1 minute long",
+		"reply_attachments_limit":       "replies can have at most 3 attachments",
+		"reply_audio_attachments_limit": "replies can have at most 1 audio clip",
+
+		"poll_options_length":     "polls must have between 2 and 6 options",
+		"poll_option_invalid":     "poll options must have between 1 and 50 characters",
+		"poll_options_duplicated": "poll options must be different",
+		"poll_not_found":          "poll not found",
+		"poll_already_sent":       "this poll was already sent to this user",
+		"poll_not_authorized":     "only the sender of the poll can do this",
+		"poll_choice_required":    "choose an option to reply to the poll",
+		"poll_choice_invalid":     "the chosen option does not exist",
+		"poll_reply_not_editable": "the reply to a poll can't be edited, remove it to choose again",
+		"question_not_poll":       "this question is not a poll",
+		"poll_comment_length":     "comment is too long for the chosen option",
+
+		"inbox_min_account_age_invalid": "the minimum account age must be between 0 and 365 days",
+		"inbox_daily_limit_invalid":     "the daily limit must be between 0 and 1000 questions",
+		"inbox_paused_message_length":   "the pause message must have at most 150 characters",
+		"inbox_paused":                  "this user is not receiving questions right now",
+		"inbox_anonymous_denied":        "this user does not receive anonymous questions",
+		"inbox_verified_email_required": "this user only receives questions from users with a verified email",
+		"inbox_account_too_new":         "your account is too new to send questions to this user",
+		"inbox_daily_limit_reached":     "this user reached the limit of questions received today, try again later",
+
+		"search_query_required":    "the search query is required",
+		"search_query_length":      "the search query must have between 2 and 100 characters",
+		"search_box_invalid":       "the boxes to search must be received, sent or replied",
+		"search_anonymous_invalid": "the anonymous filter must be true or false",
+		"search_date_invalid":      "the dates of the search must be in the YYYY-MM-DD format",
+		"search_period_invalid":    "the start date of the search must be before the end date",
+
+		"draft_publish_at_past":           "replies can only be scheduled for the future",
+		"draft_publish_at_too_far":        "replies can be scheduled up to 30 days ahead",
+		"draft_not_found":                 "this question has no draft",
+		"emails_question_replied_subject": "%s replied to your question",
+
+		"cant_edit_reply_reached_limit": "you reached the limit of edits of this reply for your plan",
+		"reply_history_not_replied":     "this question was not replied yet",
+		"reply_history_private":         "the edit history of this reply is private",
+	}
+}
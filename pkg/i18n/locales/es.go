@@ -0,0 +1,171 @@
+package locales
+
+// GetSpanishTranslations returns a map with the Spanish translations owned by core.
+// Keys that are not found here are looked up in the toolkit translations.
+func GetSpanishTranslations() *map[string]string {
+	return &map[string]string{
+		"incorrect_password": "la contraseña proporcionada es incorrecta",
+
+		"two_factor_already_enabled": "la autenticación en dos pasos ya está activada",
+		"two_factor_not_enabled":     "la autenticación en dos pasos no está activada",
+		"two_factor_not_enrolled":    "debe iniciar la configuración de la autenticación en dos pasos antes de activarla",
+		"two_factor_code_required":   "el campo de código de dos pasos es obligatorio",
+		"two_factor_code_invalid":    "el código de dos pasos proporcionado no es válido",
+		"challenge_token_required":   "el campo de token de desafío es obligatorio",
+		"challenge_token_invalid":    "el token de desafío proporcionado no es válido o ha expirado",
+
+		"email_not_verified":               "debes verificar tu correo electrónico para hacer esto",
+		"email_already_verified":           "tu correo electrónico ya está verificado",
+		"verification_token_required":      "el campo token de verificación es obligatorio",
+		"verification_token_invalid":       "el enlace de verificación no es válido o ha caducado",
+		"verification_email_recently_sent": "se envió un correo de verificación recientemente, espera antes de solicitar otro",
+		"emails_verify_email_subject":      "Verifica tu correo electrónico",
+		"emails_verify_email_body":         "Haz clic en el siguiente enlace para verificar tu correo electrónico: ",
+
+		"oidc_provider_not_found":         "proveedor de inicio de sesión no encontrado",
+		"oidc_state_required":             "el campo state es obligatorio",
+		"oidc_state_invalid":              "la solicitud de inicio de sesión no es válida o ha caducado, inténtalo de nuevo",
+		"oidc_authentication_failed":      "no se pudo autenticar con el proveedor de inicio de sesión",
+		"oidc_email_not_verified":         "el correo electrónico de tu cuenta del proveedor no está verificado",
+		"identity_not_found":              "este proveedor de inicio de sesión no está vinculado a tu cuenta",
+		"identity_not_linked":             "ya existe una cuenta con este correo electrónico, inicia sesión con tu contraseña y vincula el proveedor en tu configuración",
+		"identity_already_linked":         "este proveedor de inicio de sesión ya está vinculado a tu cuenta",
+		"identity_linked_to_another_user": "esta cuenta del proveedor ya está vinculada a otro usuario",
+		"cant_unlink_last_sign_in_method": "no puedes desvincular tu único método de inicio de sesión, define una contraseña primero",
+
+		"too_many_sign_in_attempts":     "demasiados intentos fallidos de inicio de sesión, inténtalo de nuevo más tarde",
+		"admin_not_authorized":          "no tienes autorización para acceder a este recurso",
+		"emails_account_locked_subject": "Tu cuenta fue bloqueada temporalmente",
+		"emails_account_locked_body":    "Detectamos muchos intentos fallidos de inicio de sesión en tu cuenta, por eso fue bloqueada temporalmente. Si no fuiste tú, te recomendamos cambiar tu contraseña y activar la autenticación en dos pasos.",
+
+		"password_too_short":              "la contraseña es demasiado corta",
+		"password_too_weak":               "la contraseña es demasiado débil, usa una contraseña más larga, mezclando letras, números y símbolos",
+		"password_contains_personal_info": "la contraseña no puede contener tu nick, nombre o email",
+		"password_breached":               "esta contraseña apareció en una filtración de datos y no puede usarse, elige otra contraseña",
+
+		"token_revoked":          "esta sesión ha finalizado, inicia sesión nuevamente",
+		"access_token_required":  "solo los tokens de acceso pueden autenticar solicitudes",
+		"user_banned":            "esta cuenta está bloqueada",
+		"ban_not_found":          "este usuario no está bloqueado",
+		"ban_reason_length":      "el motivo debe tener como máximo 500 caracteres",
+		"ban_expires_at_invalid": "la fecha de expiración del bloqueo debe estar en el futuro",
+
+		"device_token_required":           "el campo token del dispositivo es obligatorio",
+		"passwordless_code_required":      "el campo código es obligatorio",
+		"passwordless_code_invalid":       "el enlace o código de inicio de sesión no es válido o ha expirado",
+		"passwordless_recently_requested": "se envió un enlace de inicio de sesión recientemente, espera antes de solicitar otro",
+		"emails_passwordless_subject":     "Tu enlace de inicio de sesión",
+		"emails_passwordless_body":        "Usa el código %s o haz clic en el enlace de abajo para iniciar sesión. Si no fuiste tú, ignora este correo: ",
+
+		"trusted_location_not_found":      "ubicación de confianza no encontrada",
+		"trusted_location_required":       "se requiere una red o un sistema autónomo",
+		"trusted_location_cidr_invalid":   "la red debe estar en notación CIDR, como 177.10.0.0/16",
+		"trusted_location_cidr_too_broad": "la red es demasiado amplia para ser de confianza",
+		"trusted_location_already_exists": "esta ubicación ya es de confianza",
+		"trusted_location_asn_not_own":    "solo el sistema autónomo de tu conexión actual puede ser de confianza",
+
+		"personal_token_invalid":            "el token de acceso personal no es válido o ha expirado",
+		"personal_token_not_found":          "token de acceso personal no encontrado",
+		"personal_token_name_required":      "el nombre del token de acceso personal es obligatorio",
+		"personal_token_name_length":        "el nombre del token de acceso personal debe tener como máximo 100 caracteres",
+		"personal_token_scopes_required":    "se requiere al menos un alcance",
+		"personal_token_scope_invalid":      "uno de los alcances no es válido",
+		"personal_token_expires_at_invalid": "la fecha de expiración debe estar en el futuro y ser como máximo dentro de un año",
+		"personal_tokens_limit_reached":     "has alcanzado el límite de tokens de acceso personal",
+		"insufficient_scope":                "este token no tiene el alcance requerido para esta acción",
+
+		"role_required":             "el rol es obligatorio",
+		"role_invalid":              "el rol no es válido",
+		"role_already_assigned":     "el usuario ya tiene este rol",
+		"role_change_reason_length": "el motivo debe tener como máximo 500 caracteres",
+		"cant_change_own_role":      "no puedes cambiar tu propio rol",
+		"cant_remove_last_admin":    "el último administrador no puede perder el rol",
+		"cant_ban_yourself":         "no puedes banearte a ti mismo",
+		"cant_ban_user_role":        "no puedes banear a usuarios con el mismo rol o uno superior",
+
+		"account_deletion_already_requested":        "la eliminación de tu cuenta ya fue solicitada",
+		"account_deletion_not_requested":            "la eliminación de tu cuenta no fue solicitada",
+		"reauthentication_required":                 "inicia sesión de nuevo para confirmar esta acción",
+		"emails_account_deletion_scheduled_subject": "Tu cuenta será eliminada",
+		"emails_account_deletion_scheduled_body":    "Recibimos tu solicitud para eliminar tu cuenta. Será eliminada el %s, junto con todos tus datos. Cancela la eliminación en la configuración de tu cuenta antes si quieres reactivarla.",
+		"emails_account_deleted_subject":            "Tu cuenta fue eliminada",
+		"emails_account_deleted_body":               "Tu cuenta y todos tus datos fueron eliminados. Las preguntas que enviaste a otros usuarios se mantuvieron de forma anónima.",
+
+		"data_export_in_progress":          "ya se está preparando una copia de tus datos",
+		"data_export_requested_recently":   "puedes solicitar una copia de tus datos una vez al día",
+		"emails_data_export_ready_subject": "Tus datos están listos para descargar",
+		"emails_data_export_ready_body":    "La copia de tus datos que solicitaste está lista. Descárgala hasta el %s: %s",
+
+		"nick_reserved":      "este nick está reservado, prueba una de las sugerencias",
+		"nick_mixed_scripts": "el nick no puede mezclar letras de alfabetos diferentes",
+
+		"nick_changed_recently": "cambiaste tu nick recientemente, inténtalo de nuevo más tarde",
+
+		"pagination_limit_invalid":  "el tamaño de la página debe ser un número entre 1 y 100",
+		"pagination_cursor_invalid": "el enlace de esta página no es válido, por favor, recarga la lista",
+		"pagination_total_invalid":  "el parámetro total debe ser true o false",
+
+		"answers_visibility_invalid": "la visibilidad de las respuestas debe ser public, users o private",
+		"answers_private":            "las respuestas de este usuario son privadas",
+		"answers_sign_in_required":   "inicia sesión para ver las respuestas de este usuario",
+		"answers_blocked":            "no puedes ver las respuestas de este usuario",
+
+		"reaction_type_required": "la reacción es obligatoria",
+		"reaction_type_invalid":  "esta reacción no está disponible",
+		"reaction_not_replied":   "solo puedes reaccionar a preguntas respondidas",
+		"reaction_blocked":       "no puedes reaccionar a esta respuesta",
+
+		"follow_up_not_found":              "seguimiento no encontrado",
+		"follow_up_not_sender":             "solo quien envió la pregunta puede hacer esto",
+		"follow_up_question_not_replied":   "solo puedes dar seguimiento a preguntas respondidas",
+		"follow_up_pending_reply":          "espera la respuesta a tu último seguimiento antes de enviar otro",
+		"follow_ups_limit_reached":         "esta pregunta alcanzó el número máximo de seguimientos",
+		"follow_up_already_replied":        "este seguimiento ya fue respondido",
+		"follow_up_hidden":                 "esta conversación fue ocultada por quien recibió la pregunta",
+		"follow_up_blocked":                "no puedes dar seguimiento a esta pregunta",
+		"emails_new_follow_up_subject":     "Recibiste un seguimiento de una pregunta",
+		"emails_follow_up_replied_subject": "%s respondió a tu seguimiento",
+
+		"media_corrupted":               "no se pudo leer el archivo, puede estar dañado",
+		"audio_too_long":                "los audios deben durar como máximo 1 minuto",
+		"reply_attachments_limit":       "las respuestas pueden tener como máximo 3 adjuntos",
+		"reply_audio_attachments_limit": "las respuestas pueden tener como máximo 1 audio",
+
+		"poll_options_length":     "las encuestas deben tener entre 2 y 6 opciones",
+		"poll_option_invalid":     "las opciones de la encuesta deben tener entre 1 y 50 caracteres",
+		"poll_options_duplicated": "las opciones de la encuesta deben ser diferentes",
+		"poll_not_found":          "encuesta no encontrada",
+		"poll_already_sent":       "esta encuesta ya fue enviada a este usuario",
+		"poll_not_authorized":     "solo quien envió la encuesta puede hacer esto",
+		"poll_choice_required":    "elige una opción para responder a la encuesta",
+		"poll_choice_invalid":     "la opción elegida no existe",
+		"poll_reply_not_editable": "la respuesta de una encuesta no se puede editar, elimínala para elegir de nuevo",
+		"question_not_poll":       "esta pregunta no es una encuesta",
+		"poll_comment_length":     "comentario demasiado largo para la opción elegida",
+
+		"inbox_min_account_age_invalid": "la antigüedad mínima de la cuenta debe estar entre 0 y 365 días",
+		"inbox_daily_limit_invalid":     "el límite diario debe estar entre 0 y 1000 preguntas",
+		"inbox_paused_message_length":   "el mensaje de pausa debe tener como máximo 150 caracteres",
+		"inbox_paused":                  "este usuario no está recibiendo preguntas en este momento",
+		"inbox_anonymous_denied":        "este usuario no recibe preguntas anónimas",
+		"inbox_verified_email_required": "este usuario solo recibe preguntas de usuarios con email verificado",
+		"inbox_account_too_new":         "tu cuenta es demasiado nueva para enviar preguntas a este usuario",
+		"inbox_daily_limit_reached":     "este usuario alcanzó el límite de preguntas recibidas hoy, inténtalo más tarde",
+
+		"search_query_required":    "el término de búsqueda es obligatorio",
+		"search_query_length":      "el término de búsqueda debe tener entre 2 y 100 caracteres",
+		"search_box_invalid":       "las bandejas de la búsqueda deben ser received, sent o replied",
+		"search_anonymous_invalid": "el filtro de anónimas debe ser true o false",
+		"search_date_invalid":      "las fechas de la búsqueda deben tener el formato AAAA-MM-DD",
+		"search_period_invalid":    "la fecha inicial de la búsqueda debe ser anterior a la fecha final",
+
+		"draft_publish_at_past":           "las respuestas solo se pueden programar para el futuro",
+		"draft_publish_at_too_far":        "las respuestas se pueden programar con hasta 30 días de antelación",
+		"draft_not_found":                 "esta pregunta no tiene borrador",
+		"emails_question_replied_subject": "%s respondió a tu pregunta",
+
+		"cant_edit_reply_reached_limit": "alcanzaste el límite de ediciones de esta respuesta de tu plan",
+		"reply_history_not_replied":     "esta pregunta aún no fue respondida",
+		"reply_history_private":         "el historial de ediciones de esta respuesta es privado",
+	}
+}
@@ -0,0 +1,171 @@
+package locales
+
+// GetBrazilianPortugueseTranslation returns a map with the Brazilian Portuguese translations owned by core.
+// Keys that are not found here are looked up in the toolkit translations.
+func GetBrazilianPortugueseTranslation() *map[string]string {
+	return &map[string]string{
+		"incorrect_password": "a senha informada está incorreta",
+
+		"two_factor_already_enabled": "a autenticação em dois fatores já está ativada",
+		"two_factor_not_enabled":     "a autenticação em dois fatores não está ativada",
+		"two_factor_not_enrolled":    "você precisa iniciar a configuração da autenticação em dois fatores antes de ativá-la",
+		"two_factor_code_required":   "campo de código de dois fatores é obrigatório",
+		"two_factor_code_invalid":    "o código de dois fatores informado é inválido",
+		"challenge_token_required":   "campo de token de desafio é obrigatório",
+		"challenge_token_invalid":    "o token de desafio informado é inválido ou expirou",
+
+		"email_not_verified":               "você precisa verificar seu email para fazer isso",
+		"email_already_verified":           "seu email já está verificado",
+		"verification_token_required":      "o campo token de verificação é obrigatório",
+		"verification_token_invalid":       "o link de verificação é inválido ou expirou",
+		"verification_email_recently_sent": "um email de verificação foi enviado recentemente, aguarde antes de solicitar outro",
+		"emails_verify_email_subject":      "Verifique seu email",
+		"emails_verify_email_body":         "Clique no link abaixo para verificar seu email: ",
+
+		"oidc_provider_not_found":         "provedor de login não encontrado",
+		"oidc_state_required":             "o campo state é obrigatório",
+		"oidc_state_invalid":              "a solicitação de login é inválida ou expirou, tente novamente",
+		"oidc_authentication_failed":      "não foi possível autenticar com o provedor de login",
+		"oidc_email_not_verified":         "o email da sua conta no provedor não está verificado",
+		"identity_not_found":              "este provedor de login não está vinculado à sua conta",
+		"identity_not_linked":             "já existe uma conta com este email, entre com sua senha e vincule o provedor nas configurações",
+		"identity_already_linked":         "este provedor de login já está vinculado à sua conta",
+		"identity_linked_to_another_user": "esta conta do provedor já está vinculada a outro usuário",
+		"cant_unlink_last_sign_in_method": "você não pode desvincular seu único método de login, defina uma senha primeiro",
+
+		"too_many_sign_in_attempts":     "muitas tentativas de login sem sucesso, tente novamente mais tarde",
+		"admin_not_authorized":          "você não tem autorização para acessar este recurso",
+		"emails_account_locked_subject": "Sua conta foi bloqueada temporariamente",
+		"emails_account_locked_body":    "Detectamos muitas tentativas de login sem sucesso na sua conta, por isso ela foi bloqueada temporariamente. Se não foi você, recomendamos que altere sua senha e ative a autenticação em dois fatores.",
+
+		"password_too_short":              "a senha é muito curta",
+		"password_too_weak":               "a senha é muito fraca, use uma senha mais longa, misturando letras, números e símbolos",
+		"password_contains_personal_info": "a senha não pode conter seu nick, nome ou email",
+		"password_breached":               "esta senha apareceu em um vazamento de dados e não pode ser usada, escolha outra senha",
+
+		"token_revoked":          "esta sessão foi encerrada, entre novamente",
+		"access_token_required":  "apenas tokens de acesso podem autenticar requisições",
+		"user_banned":            "esta conta está banida",
+		"ban_not_found":          "este usuário não está banido",
+		"ban_reason_length":      "o motivo deve ter no máximo 500 caracteres",
+		"ban_expires_at_invalid": "a data de expiração do banimento deve estar no futuro",
+
+		"device_token_required":           "o campo token do dispositivo é obrigatório",
+		"passwordless_code_required":      "o campo código é obrigatório",
+		"passwordless_code_invalid":       "o link ou código de login é inválido ou expirou",
+		"passwordless_recently_requested": "um link de login foi enviado recentemente, aguarde antes de solicitar outro",
+		"emails_passwordless_subject":     "Seu link de login",
+		"emails_passwordless_body":        "Use o código %s ou clique no link abaixo para entrar. Se não foi você, ignore este email: ",
+
+		"trusted_location_not_found":      "local confiável não encontrado",
+		"trusted_location_required":       "uma rede ou um sistema autônomo é obrigatório",
+		"trusted_location_cidr_invalid":   "a rede deve estar na notação CIDR, como 177.10.0.0/16",
+		"trusted_location_cidr_too_broad": "a rede é muito ampla para ser confiável",
+		"trusted_location_already_exists": "este local já é confiável",
+		"trusted_location_asn_not_own":    "apenas o sistema autônomo da sua conexão atual pode ser confiável",
+
+		"personal_token_invalid":            "o token de acesso pessoal é inválido ou expirou",
+		"personal_token_not_found":          "token de acesso pessoal não encontrado",
+		"personal_token_name_required":      "o nome do token de acesso pessoal é obrigatório",
+		"personal_token_name_length":        "o nome do token de acesso pessoal deve ter no máximo 100 caracteres",
+		"personal_token_scopes_required":    "pelo menos um escopo é obrigatório",
+		"personal_token_scope_invalid":      "um dos escopos é inválido",
+		"personal_token_expires_at_invalid": "a data de expiração deve estar no futuro e ser de no máximo um ano a partir de agora",
+		"personal_tokens_limit_reached":     "você atingiu o limite de tokens de acesso pessoal",
+		"insufficient_scope":                "este token não tem o escopo necessário para esta ação",
+
+		"role_required":             "o papel é obrigatório",
+		"role_invalid":              "o papel é inválido",
+		"role_already_assigned":     "o usuário já tem este papel",
+		"role_change_reason_length": "o motivo deve ter no máximo 500 caracteres",
+		"cant_change_own_role":      "você não pode alterar o seu próprio papel",
+		"cant_remove_last_admin":    "o último administrador não pode perder o papel",
+		"cant_ban_yourself":         "você não pode banir a si mesmo",
+		"cant_ban_user_role":        "você não pode banir usuários com o mesmo cargo ou um cargo superior",
+
+		"account_deletion_already_requested":        "a exclusão da sua conta já foi solicitada",
+		"account_deletion_not_requested":            "a exclusão da sua conta não foi solicitada",
+		"reauthentication_required":                 "entre novamente para confirmar esta ação",
+		"emails_account_deletion_scheduled_subject": "Sua conta será excluída",
+		"emails_account_deletion_scheduled_body":    "Recebemos seu pedido para excluir sua conta. Ela será excluída em %s, junto com todos os seus dados. Cancele a exclusão nas configurações da sua conta antes disso se quiser reativá-la.",
+		"emails_account_deleted_subject":            "Sua conta foi excluída",
+		"emails_account_deleted_body":               "Sua conta e todos os seus dados foram excluídos. As perguntas que você enviou para outros usuários foram mantidas de forma anônima.",
+
+		"data_export_in_progress":          "uma cópia dos seus dados já está sendo preparada",
+		"data_export_requested_recently":   "você pode solicitar uma cópia dos seus dados uma vez por dia",
+		"emails_data_export_ready_subject": "Seus dados estão prontos para download",
+		"emails_data_export_ready_body":    "A cópia dos seus dados que você solicitou está pronta. Baixe-a até %s: %s",
+
+		"nick_reserved":      "este nick é reservado, tente uma das sugestões",
+		"nick_mixed_scripts": "o nick não pode misturar letras de alfabetos diferentes",
+
+		"nick_changed_recently": "você alterou seu nick recentemente, tente novamente mais tarde",
+
+		"pagination_limit_invalid":  "o tamanho da página deve ser um número entre 1 e 100",
+		"pagination_cursor_invalid": "o link desta página é inválido, por favor, recarregue a lista",
+		"pagination_total_invalid":  "o parâmetro total deve ser true ou false",
+
+		"answers_visibility_invalid": "a visibilidade das respostas deve ser public, users ou private",
+		"answers_private":            "as respostas deste usuário são privadas",
+		"answers_sign_in_required":   "entre para ver as respostas deste usuário",
+		"answers_blocked":            "você não pode ver as respostas deste usuário",
+
+		"reaction_type_required": "a reação é obrigatória",
+		"reaction_type_invalid":  "esta reação não está disponível",
+		"reaction_not_replied":   "você só pode reagir a perguntas respondidas",
+		"reaction_blocked":       "você não pode reagir a esta resposta",
+
+		"follow_up_not_found":              "acompanhamento não encontrado",
+		"follow_up_not_sender":             "apenas quem enviou a pergunta pode fazer isso",
+		"follow_up_question_not_replied":   "você só pode acompanhar perguntas respondidas",
+		"follow_up_pending_reply":          "aguarde a resposta ao seu último acompanhamento antes de enviar outro",
+		"follow_ups_limit_reached":         "esta pergunta atingiu o número máximo de acompanhamentos",
+		"follow_up_already_replied":        "este acompanhamento já foi respondido",
+		"follow_up_hidden":                 "esta conversa foi ocultada por quem recebeu a pergunta",
+		"follow_up_blocked":                "você não pode acompanhar esta pergunta",
+		"emails_new_follow_up_subject":     "Você recebeu um acompanhamento de uma pergunta",
+		"emails_follow_up_replied_subject": "%s respondeu ao seu acompanhamento",
+
+		"media_corrupted":               "o arquivo não pôde ser lido, ele pode estar corrompido",
+		"audio_too_long":                "os áudios devem ter no máximo 1 minuto",
+		"reply_attachments_limit":       "as respostas podem ter no máximo 3 anexos",
+		"reply_audio_attachments_limit": "as respostas podem ter no máximo 1 áudio",
+
+		"poll_options_length":     "as enquetes devem ter entre 2 e 6 opções",
+		"poll_option_invalid":     "as opções da enquete devem ter entre 1 e 50 caracteres",
+		"poll_options_duplicated": "as opções da enquete devem ser diferentes",
+		"poll_not_found":          "enquete não encontrada",
+		"poll_already_sent":       "esta enquete já foi enviada para este usuário",
+		"poll_not_authorized":     "apenas quem enviou a enquete pode fazer isso",
+		"poll_choice_required":    "escolha uma opção para responder à enquete",
+		"poll_choice_invalid":     "a opção escolhida não existe",
+		"poll_reply_not_editable": "a resposta de uma enquete não pode ser editada, remova-a para escolher novamente",
+		"question_not_poll":       "esta pergunta não é uma enquete",
+		"poll_comment_length":     "comentário muito longo para a opção escolhida",
+
+		"inbox_min_account_age_invalid": "a idade mínima da conta deve estar entre 0 e 365 dias",
+		"inbox_daily_limit_invalid":     "o limite diário deve estar entre 0 e 1000 perguntas",
+		"inbox_paused_message_length":   "a mensagem de pausa deve ter no máximo 150 caracteres",
+		"inbox_paused":                  "este usuário não está recebendo perguntas no momento",
+		"inbox_anonymous_denied":        "este usuário não recebe perguntas anônimas",
+		"inbox_verified_email_required": "este usuário só recebe perguntas de usuários com email verificado",
+		"inbox_account_too_new":         "sua conta é muito nova para enviar perguntas a este usuário",
+		"inbox_daily_limit_reached":     "este usuário atingiu o limite de perguntas recebidas hoje, tente novamente mais tarde",
+
+		"search_query_required":    "o termo de busca é obrigatório",
+		"search_query_length":      "o termo de busca deve ter entre 2 e 100 caracteres",
+		"search_box_invalid":       "as caixas da busca devem ser received, sent ou replied",
+		"search_anonymous_invalid": "o filtro de anônimas deve ser true ou false",
+		"search_date_invalid":      "as datas da busca devem estar no formato AAAA-MM-DD",
+		"search_period_invalid":    "a data inicial da busca deve ser anterior à data final",
+
+		"draft_publish_at_past":           "respostas só podem ser agendadas para o futuro",
+		"draft_publish_at_too_far":        "respostas podem ser agendadas com até 30 dias de antecedência",
+		"draft_not_found":                 "esta pergunta não tem rascunho",
+		"emails_question_replied_subject": "%s respondeu à sua pergunta",
+
+		"cant_edit_reply_reached_limit": "você atingiu o limite de edições desta resposta do seu plano",
+		"reply_history_not_replied":     "esta pergunta ainda não foi respondida",
+		"reply_history_private":         "o histórico de edições desta resposta é privado",
+	}
+}
@@ -2,11 +2,20 @@ package i18n
 
 import (
 	"log"
+	"strings"
 
 	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/pkg/i18n/locales"
 	toolkitI18n "github.com/quessapp/toolkit/i18n"
 )
 
+// translations holds the keys owned by core that are not shipped by the toolkit yet.
+var translations = map[string]map[string]string{
+	"en-US": *locales.GetAmericanEnglishTranslations(),
+	"pt-BR": *locales.GetBrazilianPortugueseTranslation(),
+	"es-ES": *locales.GetSpanishTranslations(),
+}
+
 func getLang(handlerCtx *configs.HandlersCtx) string {
 	accept := handlerCtx.C.Get("Accept-Language")
 
@@ -18,10 +27,34 @@ func getLang(handlerCtx *configs.HandlersCtx) string {
 	return accept
 }
 
+// getTranslation looks up a key in the core translations.
+// Like the toolkit, it retries without the suffix after the first dot (.) when the key is not found.
+func getTranslation(lang, key string) string {
+	if translations[lang][key] == "" {
+		return translations[lang][strings.Split(key, ".")[0]]
+	}
+
+	return translations[lang][key]
+}
+
 // Translate translates re
 // It takes two parameters, a HandlerCtx and an key.
 // It returns a string with the translated key.
+// Keys owned by core are looked up first, falling back to the en-US core translation and then to the toolkit.
 func Translate(handlerCtx *configs.HandlersCtx, key string) string {
-	lang := getLang(handlerCtx)
+	return TranslateLocale(getLang(handlerCtx), key)
+}
+
+// TranslateLocale translates a key to the given locale, like the locale of an user.
+// It is used outside of requests, like on background jobs, where there is no Accept-Language header.
+func TranslateLocale(lang, key string) string {
+	if t := getTranslation(lang, key); t != "" {
+		return t
+	}
+
+	if t := getTranslation("en-US", key); t != "" {
+		return t
+	}
+
 	return toolkitI18n.Translate(lang, key)
 }
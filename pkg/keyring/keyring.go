@@ -0,0 +1,171 @@
+package keyring
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// KEY_USE is the "use" of the keys published on the JWKS.
+const KEY_USE = "sig"
+
+var (
+	ErrKeyNotFound  = errors.New("signing key not found")
+	ErrNoSigningKey = errors.New("no signing key")
+)
+
+// Keyring signs and verifies the JWTs issued by the API.
+//
+// Tokens are signed by the newest active key, with its ID on the "kid" header, and verified by any key that did not expire,
+// so keys can be rotated without invalidating the tokens already issued.
+// Keys that are not active yet, the next keys, only verify tokens and are published on the JWKS, so every instance and
+// client knows them before they sign any token.
+// Tokens without a "kid" are HS256 tokens signed with the secret, like the ones issued before the keyring.
+// When the keyring has no keys, the secret is also used to sign, which keeps HS256 as an option.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys map[string]*Key
+	// sorted are the keys from the oldest to the newest.
+	sorted []*Key
+	secret []byte
+}
+
+// New creates a keyring with the HS256 secret. If the secret is empty, tokens without a "kid" are rejected.
+func New(secret string) *Keyring {
+	return &Keyring{
+		keys:   map[string]*Key{},
+		secret: []byte(secret),
+	}
+}
+
+// SetKeys replaces the keys of the keyring. Expired keys are ignored.
+func (k *Keyring) SetKeys(keys []*Key) {
+	now := time.Now()
+	valid := map[string]*Key{}
+	sorted := []*Key{}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	for _, key := range keys {
+		if key.IsExpired(now) {
+			continue
+		}
+
+		valid[key.ID] = key
+		sorted = append(sorted, key)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys = valid
+	k.sorted = sorted
+}
+
+// SigningKey returns the newest active key, used to sign new tokens, or nil if tokens are signed with the HS256 secret.
+func (k *Keyring) SigningKey() *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+
+	for i := len(k.sorted) - 1; i >= 0; i-- {
+		if k.sorted[i].IsActive(now) {
+			return k.sorted[i]
+		}
+	}
+
+	return nil
+}
+
+// NextKey returns the newest key that is not active yet, or nil if there is none.
+func (k *Keyring) NextKey() *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+
+	if len(k.sorted) > 0 && !k.sorted[len(k.sorted)-1].IsActive(now) {
+		return k.sorted[len(k.sorted)-1]
+	}
+
+	return nil
+}
+
+// Sign signs the claims with the newest active key, or with the HS256 secret if the keyring has no active keys.
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	key := k.SigningKey()
+
+	if key == nil {
+		if len(k.secret) == 0 {
+			return "", ErrNoSigningKey
+		}
+
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.PrivateKey)
+}
+
+// Keyfunc returns the key to verify the token, see jwt.Keyfunc.
+// The algorithm of the token must be the algorithm of its key, so a public key is never used as an HMAC secret.
+func (k *Keyring) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, hasKeyID := t.Header["kid"].(string)
+
+	if !hasKeyID {
+		if len(k.secret) == 0 || t.Method.Alg() != HS256 {
+			return nil, ErrKeyNotFound
+		}
+
+		return k.secret, nil
+	}
+
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	k.mu.RUnlock()
+
+	if !ok || key.IsExpired(time.Now()) || t.Method.Alg() != key.Algorithm {
+		return nil, ErrKeyNotFound
+	}
+
+	return key.PublicKey(), nil
+}
+
+// Parse parses and verifies the token into the claims.
+func (k *Keyring) Parse(token string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(token, claims, k.Keyfunc, jwt.WithValidMethods([]string{HS256, RS256, EdDSA}))
+}
+
+// JWKS returns the public keys of the keyring. The HS256 secret is never published.
+func (k *Keyring) JWKS() oidc.JWKS {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks := oidc.JWKS{Keys: []oidc.JWK{}}
+
+	for _, key := range k.keys {
+		jwk, err := oidc.NewJWK(key.ID, key.Algorithm, KEY_USE, key.PublicKey())
+
+		if err != nil {
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
@@ -0,0 +1,139 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"time"
+)
+
+// Supported signing algorithms.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+const (
+	// RSA_KEY_SIZE is the size, in bits, of generated RSA keys.
+	RSA_KEY_SIZE = 2048
+	// KEY_ID_SIZE is the size, in bytes, of generated key IDs.
+	KEY_ID_SIZE = 12
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidPrivateKey    = errors.New("invalid private key")
+)
+
+// Key is an asymmetric signing key of the keyring.
+type Key struct {
+	// ID is the "kid" header of the tokens signed by the key.
+	ID        string
+	Algorithm string
+	// PrivateKey is a *rsa.PrivateKey or an ed25519.PrivateKey, according to the Algorithm.
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	// ActivatesAt is when the key starts signing tokens. Before that, it only verifies them.
+	ActivatesAt time.Time
+	// ExpiresAt is when the tokens signed by the key are no longer accepted.
+	ExpiresAt time.Time
+}
+
+// PublicKey returns the public key used to verify the tokens signed by the key.
+func (k *Key) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// IsExpired returns true if the key expired at the given time.
+func (k *Key) IsExpired(t time.Time) bool {
+	return !t.Before(k.ExpiresAt)
+}
+
+// IsActive returns true if the key signs tokens at the given time.
+func (k *Key) IsActive(t time.Time) bool {
+	return !t.Before(k.ActivatesAt) && !k.IsExpired(t)
+}
+
+// GenerateKey generates a new key for the algorithm, with a random ID, that is active right away and expires after expiresIn.
+func GenerateKey(algorithm string, expiresIn time.Duration) (*Key, error) {
+	var privateKey crypto.Signer
+	var err error
+
+	switch algorithm {
+	case RS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, RSA_KEY_SIZE)
+	case EdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, KEY_ID_SIZE)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	k := &Key{
+		ID:          base64.RawURLEncoding.EncodeToString(b),
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		CreatedAt:   now,
+		ActivatesAt: now,
+		ExpiresAt:   now.Add(expiresIn),
+	}
+
+	return k, nil
+}
+
+// MarshalPrivateKey encodes the private key of the key as a PKCS #8 PEM block.
+func MarshalPrivateKey(k *Key) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParsePrivateKey decodes a PKCS #8 PEM block created by MarshalPrivateKey.
+// It returns ErrInvalidPrivateKey if the key does not match the algorithm.
+func ParsePrivateKey(algorithm, encoded string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(encoded))
+
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == RS256 {
+			return key, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == EdDSA {
+			return key, nil
+		}
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
@@ -0,0 +1,298 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"time"
+)
+
+var errInvalidAudio = errors.New("invalid audio")
+
+// samplesDuration returns the duration of a number of samples at a sample rate. Both are read from the headers of the
+// files, so durations too long for a time.Duration are refused instead of overflowing.
+func samplesDuration(samples, sampleRate uint64) (time.Duration, error) {
+	hi, lo := bits.Mul64(samples, uint64(time.Second))
+
+	// the quotient would not fit in 64 bits
+	if hi >= sampleRate {
+		return 0, errInvalidAudio
+	}
+
+	nanoseconds, _ := bits.Div64(hi, lo, sampleRate)
+
+	if nanoseconds > math.MaxInt64 {
+		return 0, errInvalidAudio
+	}
+
+	return time.Duration(nanoseconds), nil
+}
+
+// wavDuration reads the duration of a WAV file from the byte rate of the "fmt " chunk and the size of the "data" chunk.
+func wavDuration(data []byte) (time.Duration, error) {
+	var byteRate, dataSize uint32
+
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := binary.LittleEndian.Uint32(data[pos+4 : pos+8])
+		pos += 8
+
+		switch id {
+		case "fmt ":
+			if pos+12 > len(data) {
+				return 0, errInvalidAudio
+			}
+
+			byteRate = binary.LittleEndian.Uint32(data[pos+8 : pos+12])
+		case "data":
+			dataSize = size
+		}
+
+		// chunks are padded to an even size
+		pos += int(size) + int(size%2)
+	}
+
+	if byteRate == 0 || dataSize == 0 {
+		return 0, errInvalidAudio
+	}
+
+	return samplesDuration(uint64(dataSize), uint64(byteRate))
+}
+
+// oggDuration reads the duration of an Ogg Opus or Ogg Vorbis file: the granule position of the last page is
+// the number of samples, at the rate given by the identification header of the first page.
+func oggDuration(data []byte) (time.Duration, error) {
+	var sampleRate, preSkip, lastGranule uint64
+
+	for pos := 0; pos+27 <= len(data); {
+		if string(data[pos:pos+4]) != "OggS" {
+			return 0, errInvalidAudio
+		}
+
+		granule := binary.LittleEndian.Uint64(data[pos+6 : pos+14])
+		segments := int(data[pos+26])
+
+		if pos+27+segments > len(data) {
+			return 0, errInvalidAudio
+		}
+
+		bodySize := 0
+
+		for _, size := range data[pos+27 : pos+27+segments] {
+			bodySize += int(size)
+		}
+
+		body := data[pos+27+segments:]
+
+		if len(body) > bodySize {
+			body = body[:bodySize]
+		}
+
+		if pos == 0 {
+			switch {
+			case len(body) >= 12 && string(body[0:8]) == "OpusHead":
+				// Opus granule positions are always at 48 kHz
+				sampleRate = 48000
+				preSkip = uint64(binary.LittleEndian.Uint16(body[10:12]))
+			case len(body) >= 16 && string(body[0:7]) == "\x01vorbis":
+				sampleRate = uint64(binary.LittleEndian.Uint32(body[12:16]))
+			default:
+				return 0, errInvalidAudio
+			}
+		}
+
+		// pages where no packet ends have no granule position
+		if granule != ^uint64(0) {
+			lastGranule = granule
+		}
+
+		pos += 27 + segments + bodySize
+	}
+
+	if sampleRate == 0 || lastGranule <= preSkip {
+		return 0, errInvalidAudio
+	}
+
+	return samplesDuration(lastGranule-preSkip, sampleRate)
+}
+
+// m4aDuration reads the duration of a M4A file from the movie header ("mvhd") inside the "moov" box.
+func m4aDuration(data []byte) (time.Duration, error) {
+	moov := findBox(data, "moov")
+
+	if moov == nil {
+		return 0, errInvalidAudio
+	}
+
+	mvhd := findBox(moov, "mvhd")
+
+	if len(mvhd) < 4 {
+		return 0, errInvalidAudio
+	}
+
+	var timescale, duration uint64
+
+	if mvhd[0] == 1 {
+		if len(mvhd) < 32 {
+			return 0, errInvalidAudio
+		}
+
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:24]))
+		duration = binary.BigEndian.Uint64(mvhd[24:32])
+	} else {
+		if len(mvhd) < 20 {
+			return 0, errInvalidAudio
+		}
+
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	}
+
+	if timescale == 0 {
+		return 0, errInvalidAudio
+	}
+
+	return samplesDuration(duration, timescale)
+}
+
+// findBox returns the content of the first box of the given type among the boxes of data, or nil if there is none.
+func findBox(data []byte, boxType string) []byte {
+	for pos := 0; pos+8 <= len(data); {
+		size := uint64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		header := uint64(8)
+
+		switch size {
+		case 0:
+			// the box goes to the end of the file
+			size = uint64(len(data) - pos)
+		case 1:
+			if pos+16 > len(data) {
+				return nil
+			}
+
+			size = binary.BigEndian.Uint64(data[pos+8 : pos+16])
+			header = 16
+		}
+
+		if size < header || uint64(pos)+size > uint64(len(data)) {
+			return nil
+		}
+
+		if string(data[pos+4:pos+8]) == boxType {
+			return data[uint64(pos)+header : uint64(pos)+size]
+		}
+
+		pos += int(size)
+	}
+
+	return nil
+}
+
+// Tables of the MPEG audio layer III frame headers, by MPEG version.
+var (
+	mp3Bitrates = map[bool][]uint64{
+		// MPEG 1
+		true: {0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		// MPEG 2 and 2.5
+		false: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	}
+	mp3SampleRates = map[byte][]uint64{
+		3: {44100, 48000, 32000},
+		2: {22050, 24000, 16000},
+		0: {11025, 12000, 8000},
+	}
+)
+
+// mp3Frame reads the MPEG audio layer III frame header at the start of data.
+// It returns the size of the frame, in bytes, and the number of samples and the sample rate of the frame.
+func mp3Frame(data []byte) (size, samples, sampleRate uint64, ok bool) {
+	if len(data) < 4 || data[0] != 0xff || data[1]&0xe0 != 0xe0 {
+		return 0, 0, 0, false
+	}
+
+	version := (data[1] >> 3) & 3
+	layer := (data[1] >> 1) & 3
+	bitrateIndex := data[2] >> 4
+	sampleRateIndex := (data[2] >> 2) & 3
+	padding := uint64((data[2] >> 1) & 1)
+
+	// version 1 is reserved, and only layer III is supported
+	if version == 1 || layer != 1 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return 0, 0, 0, false
+	}
+
+	isMPEG1 := version == 3
+	bitrate := mp3Bitrates[isMPEG1][bitrateIndex] * 1000
+	sampleRate = mp3SampleRates[version][sampleRateIndex]
+
+	samples = 576
+	slot := uint64(72)
+
+	if isMPEG1 {
+		samples = 1152
+		slot = 144
+	}
+
+	return slot*bitrate/sampleRate + padding, samples, sampleRate, true
+}
+
+// mp3Start returns where the audio frames start, after the ID3v2 tag if there is one.
+func mp3Start(data []byte) int {
+	if len(data) < 10 || string(data[0:3]) != "ID3" {
+		return 0
+	}
+
+	// the size of the tag is a synchsafe integer, 7 bits per byte
+	size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+	start := 10 + size
+
+	// footer
+	if data[5]&0x10 != 0 {
+		start += 10
+	}
+
+	return start
+}
+
+// isMP3 returns true if data starts with an ID3v2 tag or a MPEG audio layer III frame.
+func isMP3(data []byte) bool {
+	if len(data) >= 3 && string(data[0:3]) == "ID3" {
+		return true
+	}
+
+	_, _, _, ok := mp3Frame(data)
+
+	return ok
+}
+
+// mp3Duration sums the samples of all the frames, so variable bitrate files have the right duration.
+// Reading stops at the first bytes that are not a frame, like an ID3v1 tag at the end of the file.
+func mp3Duration(data []byte) (time.Duration, error) {
+	var duration time.Duration
+
+	pos := mp3Start(data)
+
+	for pos < len(data) {
+		size, samples, sampleRate, ok := mp3Frame(data[pos:])
+
+		if !ok || size < 4 {
+			break
+		}
+
+		frameDuration, err := samplesDuration(samples, sampleRate)
+
+		if err != nil {
+			return 0, err
+		}
+
+		duration += frameDuration
+		pos += int(size)
+	}
+
+	if duration == 0 {
+		return 0, errInvalidAudio
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,115 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"time"
+
+	// decoders of the image formats, used by image.DecodeConfig
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// Kinds of media.
+const (
+	KIND_IMAGE = "image"
+	KIND_AUDIO = "audio"
+)
+
+// Format is a media format recognised from the first bytes of the files.
+type Format struct {
+	MimeType  string
+	Extension string
+	Kind      string
+	// match returns true if the data starts like a file of the format.
+	match func(data []byte) bool
+	// duration returns the duration of audio files. It is nil for images.
+	duration func(data []byte) (time.Duration, error)
+}
+
+// FORMATS are the supported formats. Files are matched from the first to the last format.
+var FORMATS = []Format{
+	{MimeType: "image/jpeg", Extension: "jpg", Kind: KIND_IMAGE, match: hasPrefix("\xff\xd8\xff")},
+	{MimeType: "image/png", Extension: "png", Kind: KIND_IMAGE, match: hasPrefix("\x89PNG\r\n\x1a\n")},
+	{MimeType: "image/gif", Extension: "gif", Kind: KIND_IMAGE, match: func(data []byte) bool {
+		return hasPrefix("GIF87a")(data) || hasPrefix("GIF89a")(data)
+	}},
+	{MimeType: "audio/mpeg", Extension: "mp3", Kind: KIND_AUDIO, match: isMP3, duration: mp3Duration},
+	{MimeType: "audio/ogg", Extension: "ogg", Kind: KIND_AUDIO, match: hasPrefix("OggS"), duration: oggDuration},
+	{MimeType: "audio/wav", Extension: "wav", Kind: KIND_AUDIO, match: func(data []byte) bool {
+		return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE"
+	}, duration: wavDuration},
+	{MimeType: "audio/mp4", Extension: "m4a", Kind: KIND_AUDIO, match: func(data []byte) bool {
+		return len(data) >= 12 && string(data[4:8]) == "ftyp" && string(data[8:12]) == "M4A "
+	}, duration: m4aDuration},
+}
+
+// Info is what is known about a media file, found from its bytes.
+type Info struct {
+	Format
+	// Width and Height are the dimensions of images, in pixels.
+	Width  int
+	Height int
+	// Duration is the duration of audio files.
+	Duration time.Duration
+}
+
+// Sniff returns the format of the file from its first bytes. The Content-Type of uploads is chosen by the clients,
+// so it is never trusted.
+func Sniff(data []byte) (*Format, error) {
+	for _, f := range FORMATS {
+		if f.match(data) {
+			format := f
+
+			return &format, nil
+		}
+	}
+
+	return nil, errors.New(pkgErrors.FILE_TYPE_INVALID)
+}
+
+// Probe sniffs the format of the file and reads the dimensions of images or the duration of audio files.
+// Files that can't be read, like truncated files, return an error.
+func Probe(data []byte) (*Info, error) {
+	format, err := Sniff(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Format: *format}
+
+	if format.Kind == KIND_IMAGE {
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+
+		if err != nil || config.Width <= 0 || config.Height <= 0 {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		info.Width = config.Width
+		info.Height = config.Height
+
+		return info, nil
+	}
+
+	duration, err := format.duration(data)
+
+	if err != nil || duration <= 0 {
+		return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+	}
+
+	info.Duration = duration
+
+	return info, nil
+}
+
+// hasPrefix returns a match function for the formats that start with a signature.
+func hasPrefix(signature string) func(data []byte) bool {
+	return func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte(signature))
+	}
+}
@@ -0,0 +1,111 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// PNG_METADATA_CHUNKS are the chunks of PNG images removed by StripMetadata.
+var PNG_METADATA_CHUNKS = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"iTXt": true,
+	"zTXt": true,
+	"tIME": true,
+}
+
+// StripMetadata removes the metadata of JPEG and PNG images, like the EXIF data with the location where photos were
+// taken, since the attachments are public. The pixels are kept as they are, without decoding the image, so the EXIF
+// orientation is removed too and photos must be rotated by the clients before uploading them. Other files are returned
+// as they are.
+func StripMetadata(data []byte) ([]byte, error) {
+	switch {
+	case hasPrefix("\xff\xd8\xff")(data):
+		return stripJPEG(data)
+	case hasPrefix("\x89PNG\r\n\x1a\n")(data):
+		return stripPNG(data)
+	}
+
+	return data, nil
+}
+
+// stripJPEG removes the APP1 (EXIF and XMP), APP13 (IPTC) and comment segments of a JPEG image. The segments are
+// read until the start of the scan, and everything from it is kept as it is.
+func stripJPEG(data []byte) ([]byte, error) {
+	stripped := append([]byte{}, data[:2]...)
+	pos := 2
+
+	for {
+		if pos+2 > len(data) || data[pos] != 0xff {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		marker := data[pos+1]
+
+		switch {
+		// markers can be preceded by fill bytes
+		case marker == 0xff:
+			pos++
+			continue
+		// start of scan
+		case marker == 0xda:
+			return append(stripped, data[pos:]...), nil
+		// markers without a length
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			stripped = append(stripped, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+
+		if end < pos+4 || end > len(data) {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		if marker != 0xe1 && marker != 0xed && marker != 0xfe {
+			stripped = append(stripped, data[pos:end]...)
+		}
+
+		pos = end
+	}
+}
+
+// stripPNG removes the PNG_METADATA_CHUNKS of a PNG image. Everything after the IEND chunk is removed too.
+func stripPNG(data []byte) ([]byte, error) {
+	var stripped bytes.Buffer
+
+	stripped.Write(data[:8])
+
+	for pos := 8; ; {
+		if pos+12 > len(data) {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		// length, type, data and CRC
+		end := pos + 12 + int(binary.BigEndian.Uint32(data[pos:pos+4]))
+
+		if end < pos+12 || end > len(data) {
+			return nil, errors.New(pkgErrors.MEDIA_CORRUPTED)
+		}
+
+		chunkType := string(data[pos+4 : pos+8])
+
+		if !PNG_METADATA_CHUNKS[chunkType] {
+			stripped.Write(data[pos:end])
+		}
+
+		if chunkType == "IEND" {
+			return stripped.Bytes(), nil
+		}
+
+		pos = end
+	}
+}
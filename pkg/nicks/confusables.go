@@ -0,0 +1,78 @@
+package nicks
+
+// CONFUSABLES maps characters to the prototype they are confused with, from the confusables of UTS #39.
+// It is not the whole table: only the characters that look like the Latin letters and digits, which is what
+// impersonation of the nicks of other users needs. Nicks are lowercase, so only the lowercase forms are here.
+var CONFUSABLES = map[rune]string{
+	// digits
+	'0': "o",
+	'1': "l",
+	// Latin
+	'ı': "i",
+	'ɩ': "i",
+	'ɑ': "a",
+	'ɡ': "g",
+	'ʋ': "u",
+	'ꞵ': "b",
+	'm': "rn",
+	// Cyrillic
+	'а': "a",
+	'в': "b",
+	'е': "e",
+	'ԁ': "d",
+	'һ': "h",
+	'і': "i",
+	'ј': "j",
+	'к': "k",
+	'ӏ': "l",
+	'м': "rn",
+	'н': "h",
+	'о': "o",
+	'р': "p",
+	'ԛ': "q",
+	'г': "r",
+	'ѕ': "s",
+	'т': "t",
+	'ц': "u",
+	'ѵ': "v",
+	'ԝ': "w",
+	'х': "x",
+	'у': "y",
+	'с': "c",
+	'ь': "b",
+	// Greek
+	'α': "a",
+	'β': "b",
+	'ε': "e",
+	'η': "n",
+	'ι': "i",
+	'κ': "k",
+	'ν': "v",
+	'ο': "o",
+	'ρ': "p",
+	'σ': "o",
+	'τ': "t",
+	'υ': "u",
+	'χ': "x",
+	'γ': "y",
+	'ϲ': "c",
+	'ϳ': "j",
+}
+
+// LEET maps the digits used as letters to the letter, after the skeleton. "1" and "i" are both "l" on the skeleton.
+var LEET = map[rune]rune{
+	'i': 'l',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'8': 'b',
+	'9': 'g',
+}
+
+// ALLOWED_SCRIPT_COMBINATIONS are the scripts that can be mixed on a nick, see IsSingleScript.
+var ALLOWED_SCRIPT_COMBINATIONS = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
@@ -0,0 +1,116 @@
+package nicks
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize returns the canonical form of a nick: NFKC normalised, lowercased and with only letters, digits and
+// combining marks, in any script. Compatibility characters, like fullwidth letters or ligatures, become their
+// plain equivalents, so "Ｑｕｅｓｓ" is "quess".
+func Normalize(nick string) string {
+	nick = strings.ToLower(norm.NFKC.String(nick))
+
+	var b strings.Builder
+
+	for _, r := range nick {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+// Skeleton returns the confusable skeleton of a nick, based on the skeleton of UTS #39: nicks that look alike,
+// like "quess" with a Cyrillic "е" or "rnary" and "mary", have the same skeleton.
+// Unlike UTS #39, diacritics are removed too, so "josé" and "jose" are also the same nick.
+// Only the characters of CONFUSABLES are mapped, see its documentation.
+func Skeleton(nick string) string {
+	var b strings.Builder
+
+	for _, r := range norm.NFD.String(Normalize(nick)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if prototype, ok := CONFUSABLES[r]; ok {
+			b.WriteString(prototype)
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+// looseSkeleton returns the skeleton of a nick, also mapping the digits used as letters, like "4dm1n", and
+// ignoring the digits at the end, like "admin2024". It is only used to compare nicks with the reserved ones,
+// since it would block too many nicks on the uniqueness checks.
+func looseSkeleton(nick string) string {
+	nick = strings.TrimRightFunc(Normalize(nick), unicode.IsDigit)
+
+	var b strings.Builder
+
+	for _, r := range Skeleton(nick) {
+		if letter, ok := LEET[r]; ok {
+			b.WriteRune(letter)
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// scriptsOf returns the scripts of the letters of a nick. Common and inherited characters, like digits, have no script.
+func scriptsOf(nick string) map[string]bool {
+	scripts := map[string]bool{}
+
+	for _, r := range nick {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		for name, table := range unicode.Scripts {
+			if name != "Common" && name != "Inherited" && unicode.Is(table, r) {
+				scripts[name] = true
+				break
+			}
+		}
+	}
+
+	return scripts
+}
+
+// IsSingleScript returns true if the nick does not mix scripts, like Latin and Cyrillic letters, which is how most
+// look-alike nicks are made. The combinations used by Chinese, Japanese and Korean are allowed, as on the highly
+// restrictive level of UTS #39.
+func IsSingleScript(nick string) bool {
+	scripts := scriptsOf(nick)
+
+	if len(scripts) <= 1 {
+		return true
+	}
+
+	for _, allowed := range ALLOWED_SCRIPT_COMBINATIONS {
+		isAllowed := true
+
+		for script := range scripts {
+			if !allowed[script] {
+				isAllowed = false
+				break
+			}
+		}
+
+		if isAllowed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package nicks
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+const (
+	// MAX_LENGTH is the maximum number of characters of nicks.
+	MAX_LENGTH = 50
+	// SUGGESTIONS_COUNT is how many alternative nicks are suggested when a nick is not available.
+	SUGGESTIONS_COUNT = 3
+	// SUGGESTIONS_MAX_ATTEMPTS is how many nicks are tried to find the suggestions.
+	SUGGESTIONS_MAX_ATTEMPTS = 20
+)
+
+// DEFAULT_RESERVED are the nicks that are always reserved: the staff, the product and routes of the frontend.
+var DEFAULT_RESERVED = []string{
+	"admin", "administrator", "root", "system", "staff", "moderator", "mod", "support", "help", "security",
+	"official", "quess", "quessapp", "team", "api", "www", "mail", "me", "settings", "signin", "signup",
+	"null", "undefined", "anonymous",
+}
+
+// UnavailableError is returned when a nick can't be used, with available nicks to suggest to the user.
+// Error returns the translation key, so it can be handled like the other validation errors.
+type UnavailableError struct {
+	Key         string
+	Suggestions []string
+}
+
+func (e *UnavailableError) Error() string {
+	return e.Key
+}
+
+// Policy checks the nicks of new users and profile updates against the reserved nicks.
+// Reserved nicks are compared loosely, so look-alikes like "adm1n" or "аdmin" with a Cyrillic "а" are reserved too.
+type Policy struct {
+	reserved map[string]bool
+}
+
+// NewPolicy creates a policy that reserves the DEFAULT_RESERVED nicks and the given ones.
+func NewPolicy(reserved ...string) *Policy {
+	p := &Policy{reserved: map[string]bool{}}
+
+	for _, nick := range append(DEFAULT_RESERVED, reserved...) {
+		if skeleton := looseSkeleton(nick); skeleton != "" {
+			p.reserved[skeleton] = true
+		}
+	}
+
+	return p
+}
+
+// LoadReserved reads reserved nicks, one per line. Empty lines and lines starting with "#" are ignored.
+func LoadReserved(r io.Reader) ([]string, error) {
+	reserved := []string{}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		reserved = append(reserved, line)
+	}
+
+	return reserved, scanner.Err()
+}
+
+// LoadReservedFile reads reserved nicks from a file, see LoadReserved.
+func LoadReservedFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return LoadReserved(f)
+}
+
+// IsReserved returns true if the nick, or a nick that looks like it, is reserved.
+// A nil policy only reserves the DEFAULT_RESERVED nicks.
+func (p *Policy) IsReserved(nick string) bool {
+	if p == nil {
+		return NewPolicy().IsReserved(nick)
+	}
+
+	return p.reserved[looseSkeleton(nick)]
+}
+
+// Validate returns error if the normalised nick mixes scripts or is reserved. See Normalize.
+func (p *Policy) Validate(nick string) error {
+	if !IsSingleScript(nick) {
+		return errors.New(pkgErrors.NICK_MIXED_SCRIPTS)
+	}
+
+	if p.IsReserved(nick) {
+		return errors.New(pkgErrors.NICK_RESERVED)
+	}
+
+	return nil
+}
+
+// Suggest returns up to SUGGESTIONS_COUNT nicks like the given one, with a number at the end,
+// that are valid and that isAvailable accepts.
+func (p *Policy) Suggest(nick string, isAvailable func(nick string) bool) []string {
+	suggestions := []string{}
+	base := []rune(Normalize(nick))
+
+	if len(base) > MAX_LENGTH-4 {
+		base = base[:MAX_LENGTH-4]
+	}
+
+	// reserved nicks are reserved with any number at the end, so only a generic nick can be suggested
+	if p.IsReserved(string(base)) || !IsSingleScript(string(base)) {
+		base = []rune("user")
+	}
+
+	for i := 0; i < SUGGESTIONS_MAX_ATTEMPTS && len(suggestions) < SUGGESTIONS_COUNT; i++ {
+		suggestion := fmt.Sprintf("%s%d", string(base), rand.Intn(9990)+10)
+
+		if utf8.RuneCountInString(suggestion) < 3 || contains(suggestions, suggestion) || !isAvailable(suggestion) {
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
+
+// contains returns true if the value is in the slice.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// JWK is a JSON Web Key as defined by RFC 7517. Only the fields of RSA, EC and OKP (Ed25519, RFC 8037) public keys are supported.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC and OKP
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, the document served on the jwks_uri of a provider.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+	ErrInvalidKey         = errors.New("invalid key")
+)
+
+// NewJWK encodes a *rsa.PublicKey, an *ecdsa.PublicKey or an ed25519.PublicKey as a JWK.
+func NewJWK(keyID, algorithm, use string, publicKey interface{}) (JWK, error) {
+	k := JWK{KeyID: keyID, Algorithm: algorithm, Use: use}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		k.KeyType = "RSA"
+		k.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		k.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+
+		k.KeyType = "EC"
+		k.Curve = key.Curve.Params().Name
+		k.X = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size)))
+		k.Y = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		k.KeyType = "OKP"
+		k.Curve = "Ed25519"
+		k.X = base64.RawURLEncoding.EncodeToString(key)
+	default:
+		return JWK{}, ErrUnsupportedKeyType
+	}
+
+	return k, nil
+}
+
+// PublicKey decodes the JWK into a *rsa.PublicKey, an *ecdsa.PublicKey or an ed25519.PublicKey.
+func (k JWK) PublicKey() (interface{}, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+			return nil, ErrInvalidKey
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedKeyType
+		}
+
+		x, err := decodeBigInt(k.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, ErrInvalidKey
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, ErrUnsupportedKeyType
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, ErrUnsupportedKeyType
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer.
+func decodeBigInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, ErrInvalidKey
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
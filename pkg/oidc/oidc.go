@@ -0,0 +1,387 @@
+package oidc
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// DISCOVERY_PATH is appended to the issuer to find the provider metadata.
+	DISCOVERY_PATH = "/.well-known/openid-configuration"
+	// KEYS_REFRESH_INTERVAL is the minimum interval between JWKS fetches triggered by unknown key IDs,
+	// so tokens with random key IDs can't be used to flood the provider.
+	KEYS_REFRESH_INTERVAL = time.Minute
+	// REQUEST_TIMEOUT is the timeout of the requests made to the providers.
+	REQUEST_TIMEOUT = time.Second * 10
+)
+
+// SIGNING_ALGORITHMS are the ID token signing algorithms accepted. Symmetric algorithms are never accepted.
+var SIGNING_ALGORITHMS = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+var (
+	ErrProviderNotFound = errors.New("provider not found")
+	ErrInvalidDiscovery = errors.New("invalid provider discovery document")
+	ErrInvalidIDToken   = errors.New("invalid id token")
+	ErrKeyNotFound      = errors.New("signing key not found")
+)
+
+// ProviderConfig is the configuration of an OpenID Connect provider.
+type ProviderConfig struct {
+	// Name identifies the provider on routes and linked identities, like "google".
+	Name         string   `json:"name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	RedirectURL  string   `json:"redirectUrl"`
+	Scopes       []string `json:"scopes"`
+}
+
+// Discovery is the subset of the provider metadata used by the relying party.
+// See https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// TokenResponse is the response of the token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// IDTokenClaims are the claims of an ID token.
+type IDTokenClaims struct {
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Provider is an OpenID Connect provider. The discovery document and the signing keys are fetched on first use and cached.
+type Provider struct {
+	Config     ProviderConfig
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	discovery     *Discovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// Registry holds the configured providers by name.
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// ParseProviders parses the providers configuration, a JSON array of ProviderConfig.
+// An empty value means that no provider is configured.
+func ParseProviders(value string) ([]ProviderConfig, error) {
+	providers := []ProviderConfig{}
+
+	if strings.TrimSpace(value) == "" {
+		return providers, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &providers); err != nil {
+		return nil, err
+	}
+
+	for _, p := range providers {
+		if p.Name == "" || p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, fmt.Errorf("provider %q must have name, issuer, clientId and redirectUrl", p.Name)
+		}
+	}
+
+	return providers, nil
+}
+
+// NewRegistry creates a registry with the given providers.
+// If httpClient is nil, a client with REQUEST_TIMEOUT is used.
+func NewRegistry(providers []ProviderConfig, httpClient *http.Client) *Registry {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: REQUEST_TIMEOUT}
+	}
+
+	r := &Registry{providers: map[string]*Provider{}}
+
+	for _, config := range providers {
+		r.providers[config.Name] = &Provider{Config: config, httpClient: httpClient}
+	}
+
+	return r
+}
+
+// Get returns the provider with the given name.
+func (r *Registry) Get(name string) (*Provider, error) {
+	p, ok := r.providers[name]
+
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+
+	return p, nil
+}
+
+// Names returns the names of the configured providers, sorted.
+func (r *Registry) Names() []string {
+	names := []string{}
+
+	for name := range r.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Discover returns the provider metadata. It is fetched once and cached.
+// The issuer of the document must be the configured issuer.
+func (p *Provider) Discover() (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	discovery := &Discovery{}
+
+	if err := p.getJSON(strings.TrimSuffix(p.Config.Issuer, "/")+DISCOVERY_PATH, discovery); err != nil {
+		return nil, err
+	}
+
+	if discovery.Issuer != p.Config.Issuer || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, ErrInvalidDiscovery
+	}
+
+	p.discovery = discovery
+
+	return discovery, nil
+}
+
+// AuthCodeURL returns the URL of the provider authorization endpoint for the authorization code flow with PKCE.
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) (string, error) {
+	discovery, err := p.Discover()
+
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.Config.Scopes
+
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.Config.ClientID)
+	params.Set("redirect_uri", p.Config.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange exchanges an authorization code for tokens on the provider token endpoint.
+func (p *Provider) Exchange(code, codeVerifier string) (*TokenResponse, error) {
+	discovery, err := p.Discover()
+
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.Config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.Config.ClientID)
+
+	req, err := http.NewRequest(http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if p.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+
+	res, err := p.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("token endpoint returned %d: %s", res.StatusCode, body)
+	}
+
+	tokens := &TokenResponse{}
+
+	if err := json.NewDecoder(res.Body).Decode(tokens); err != nil {
+		return nil, err
+	}
+
+	if tokens.IDToken == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	return tokens, nil
+}
+
+// VerifyIDToken verifies the signature of the ID token with the provider JWKS and validates its claims:
+// issuer, audience, authorized party, expiration and nonce.
+func (p *Provider) VerifyIDToken(rawIDToken, nonce string) (*IDTokenClaims, error) {
+	discovery, err := p.Discover()
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &IDTokenClaims{}
+
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		return p.getKey(discovery.JWKSURI, kid)
+	}, jwt.WithValidMethods(SIGNING_ALGORITHMS))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) || !claims.VerifyAudience(p.Config.ClientID, true) || claims.ExpiresAt == nil || claims.Subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.Config.ClientID {
+		return nil, ErrInvalidIDToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, ErrInvalidIDToken
+	}
+
+	return claims, nil
+}
+
+// getKey returns the public key with the given key ID.
+// Unknown key IDs trigger a new fetch of the JWKS, at most once per KEYS_REFRESH_INTERVAL, to support key rotation.
+func (p *Provider) getKey(jwksURI, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetchedAt) < KEYS_REFRESH_INTERVAL {
+		return nil, ErrKeyNotFound
+	}
+
+	jwks := &JWKS{}
+
+	if err := p.getJSON(jwksURI, jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.PublicKey()
+
+		if err != nil {
+			continue
+		}
+
+		keys[k.KeyID] = key
+	}
+
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// findKey finds a cached key. Tokens without key ID are accepted only if the provider has a single key.
+func (p *Provider) findKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+
+	return key, ok
+}
+
+// getJSON makes a GET request and decodes the JSON response into v.
+func (p *Provider) getJSON(URL string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, URL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", URL, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
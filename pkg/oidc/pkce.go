@@ -0,0 +1,30 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// RANDOM_VALUE_SIZE is the size, in bytes, of generated states, nonces and code verifiers.
+const RANDOM_VALUE_SIZE = 32
+
+// GenerateRandomValue generates a random url-safe value to be used as state, nonce or PKCE code verifier.
+// RFC 7636 requires code verifiers to have between 43 and 128 characters, 32 bytes encode to 43.
+func GenerateRandomValue() (string, error) {
+	b := make([]byte, RANDOM_VALUE_SIZE)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the code verifier.
+// See https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,300 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// DEFAULT_LIMIT is the page size when the client does not choose one.
+	DEFAULT_LIMIT int64 = 30
+	// MAX_LIMIT is the largest page size a client can choose.
+	MAX_LIMIT int64 = 100
+	// CURSOR_KEY_INFO is the HKDF label of the key that signs the cursors, so it is never the key that encrypts data.
+	CURSOR_KEY_INFO = "cursor"
+)
+
+// Sort orders, by creation date, or by the key of listings ordered by a key, see Params.FilterByKey.
+const (
+	SORT_ASC  = "asc"
+	SORT_DESC = "desc"
+)
+
+// Position is where a document is on a listing. Listings are ordered by the creation date,
+// and by the ID between documents created at the same time, so every position is unique.
+type Position struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+	// Key is the value of the field that orders listings ordered by a key instead of the creation date, like the nick.
+	Key string
+}
+
+// cursor is the signed content of the cursors returned to the clients.
+type cursor struct {
+	CreatedAt time.Time          `json:"t"`
+	ID        primitive.ObjectID `json:"id"`
+	Key       string             `json:"k,omitempty"`
+	Sort      string             `json:"s"`
+	// Backward is true for cursors that go to the documents before the position.
+	Backward bool `json:"b,omitempty"`
+}
+
+// Params are the pagination options of a request.
+type Params struct {
+	// Limit is the page size, between 1 and MAX_LIMIT.
+	Limit int64
+	// Sort is SORT_ASC or SORT_DESC. When a cursor is given, it is the sort of the cursor.
+	Sort string
+	// WithTotal asks for the number of documents of the listing, which is slower on large listings.
+	WithTotal bool
+
+	cursor *cursor
+	key    []byte
+	scope  string
+}
+
+// Page holds the cursors to navigate from a page, and the total when asked.
+type Page struct {
+	// NextCursor goes to the documents after the page. It is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+	// PrevCursor goes to the documents before the page. It is empty on the first page.
+	PrevCursor string `json:"prevCursor,omitempty"`
+	// TotalCount is only set when asked, see Params.WithTotal.
+	TotalCount *int64 `json:"totalCount,omitempty"`
+}
+
+// Scope returns the scope of the cursors of a listing: the listing, like "questions:sent", and its owner, like the
+// ID of the authenticated user or the nick of a profile.
+func Scope(listing, owner string) string {
+	return listing + ":" + owner
+}
+
+// ParseParams parses the pagination query of a request: the limit, the cursor of a previous page, the sort and
+// if the total must be counted. Empty values use the defaults. Cursors are signed with a key derived from the key,
+// along with the scope of the listing, so clients can't forge them nor use them on another listing, see Scope.
+func ParseParams(key, scope, limit, encodedCursor, sort, total string) (*Params, error) {
+	p := &Params{Limit: DEFAULT_LIMIT, Sort: SORT_ASC, key: deriveKey(key), scope: scope}
+
+	if limit != "" {
+		n, err := strconv.ParseInt(limit, 10, 64)
+
+		if err != nil || n < 1 || n > MAX_LIMIT {
+			return nil, errors.New(pkgErrors.PAGINATION_LIMIT_INVALID)
+		}
+
+		p.Limit = n
+	}
+
+	if sort == SORT_DESC {
+		p.Sort = SORT_DESC
+	}
+
+	if total != "" {
+		withTotal, err := strconv.ParseBool(total)
+
+		if err != nil {
+			return nil, errors.New(pkgErrors.PAGINATION_TOTAL_INVALID)
+		}
+
+		p.WithTotal = withTotal
+	}
+
+	if encodedCursor != "" {
+		c, err := p.decode(encodedCursor)
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.cursor = c
+		p.Sort = c.Sort
+	}
+
+	return p, nil
+}
+
+// deriveKey returns the key that signs the cursors: the HKDF-SHA256 (RFC 5869) of the key, with no salt
+// and CURSOR_KEY_INFO as info. One block of the expand step is the 32 bytes of the key.
+func deriveKey(key string) []byte {
+	extract := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extract.Write([]byte(key))
+
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write([]byte(CURSOR_KEY_INFO))
+	expand.Write([]byte{1})
+
+	return expand.Sum(nil)
+}
+
+// sign returns the HMAC-SHA256 of the scope of the listing and the content of a cursor.
+func (p *Params) sign(content string) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(p.scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(content))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encode returns the signed cursor of a position.
+func (p *Params) encode(position Position, backward bool) string {
+	content, _ := json.Marshal(cursor{CreatedAt: position.CreatedAt, ID: position.ID, Key: position.Key, Sort: p.Sort, Backward: backward})
+	encoded := base64.RawURLEncoding.EncodeToString(content)
+
+	return encoded + "." + p.sign(encoded)
+}
+
+// decode verifies the signature of a cursor and returns its content.
+func (p *Params) decode(encodedCursor string) (*cursor, error) {
+	encoded, signature, _ := strings.Cut(encodedCursor, ".")
+
+	if !hmac.Equal([]byte(signature), []byte(p.sign(encoded))) {
+		return nil, errors.New(pkgErrors.PAGINATION_CURSOR_INVALID)
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return nil, errors.New(pkgErrors.PAGINATION_CURSOR_INVALID)
+	}
+
+	c := &cursor{}
+
+	if err := json.Unmarshal(content, c); err != nil || (c.Sort != SORT_ASC && c.Sort != SORT_DESC) {
+		return nil, errors.New(pkgErrors.PAGINATION_CURSOR_INVALID)
+	}
+
+	return c, nil
+}
+
+// isBackward returns true if the page is before the cursor.
+func (p *Params) isBackward() bool {
+	return p.cursor != nil && p.cursor.Backward
+}
+
+// queryOrder returns the order of the query: the sort of the listing, reversed for backward pages.
+func (p *Params) queryOrder() int {
+	order := 1
+
+	if p.Sort == SORT_DESC {
+		order = -1
+	}
+
+	if p.isBackward() {
+		order = -order
+	}
+
+	return order
+}
+
+// Filter adds the condition of the documents after the cursor, or before it for backward pages, to the filter of a listing.
+// The filter without the cursor is the one to count the total.
+func (p *Params) Filter(filter bson.D) bson.D {
+	if p.cursor == nil {
+		return filter
+	}
+
+	return p.after("createdAt", p.cursor.CreatedAt, filter)
+}
+
+// FilterByKey is Filter for listings ordered by a field other than the creation date, like the nick. The field must
+// be set on every document, and its value is the Key of the positions.
+func (p *Params) FilterByKey(field string, filter bson.D) bson.D {
+	if p.cursor == nil {
+		return filter
+	}
+
+	return p.after(field, p.cursor.Key, filter)
+}
+
+// after adds the condition of the documents after the value of the field of the cursor, and after the ID of the
+// cursor between documents with the same value. The filter is wrapped in an $and, so its own operators, like an
+// $or, are kept.
+func (p *Params) after(field string, value interface{}, filter bson.D) bson.D {
+	operator := "$gt"
+
+	if p.queryOrder() < 0 {
+		operator = "$lt"
+	}
+
+	return bson.D{
+		{Key: "$and", Value: bson.A{
+			bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: field, Value: bson.D{{Key: operator, Value: value}}}},
+				bson.D{
+					{Key: field, Value: value},
+					{Key: "_id", Value: bson.D{{Key: operator, Value: p.cursor.ID}}},
+				},
+			}}},
+			filter,
+		}},
+	}
+}
+
+// FindOptions returns the sort and the limit of the query. One more document than the limit is fetched,
+// to know if there are more documents after the page.
+func (p *Params) FindOptions() *options.FindOptions {
+	return p.FindOptionsByKey("createdAt")
+}
+
+// FindOptionsByKey is FindOptions for listings ordered by a field other than the creation date, see FilterByKey.
+func (p *Params) FindOptionsByKey(field string) *options.FindOptions {
+	order := p.queryOrder()
+
+	return options.Find().
+		SetSort(bson.D{{Key: field, Value: order}, {Key: "_id", Value: order}}).
+		SetLimit(p.Limit + 1)
+}
+
+// NewPage removes the extra document fetched by FindOptions, puts the documents of backward pages back in the order
+// of the listing and returns them with the cursors of the page. position returns the position of a document.
+func NewPage[T any](p *Params, documents []T, position func(T) Position) ([]T, *Page) {
+	hasMore := int64(len(documents)) > p.Limit
+
+	if hasMore {
+		documents = documents[:p.Limit]
+	}
+
+	if p.isBackward() {
+		for i, j := 0, len(documents)-1; i < j; i, j = i+1, j-1 {
+			documents[i], documents[j] = documents[j], documents[i]
+		}
+	}
+
+	page := &Page{}
+
+	if len(documents) == 0 {
+		return documents, page
+	}
+
+	// forward pages have documents before them if they came from a cursor, and backward pages always have documents after them
+	hasNext := hasMore
+	hasPrev := p.cursor != nil
+
+	if p.isBackward() {
+		hasNext = true
+		hasPrev = hasMore
+	}
+
+	if hasNext {
+		page.NextCursor = p.encode(position(documents[len(documents)-1]), false)
+	}
+
+	if hasPrev {
+		page.PrevCursor = p.encode(position(documents[0]), true)
+	}
+
+	return documents, page
+}
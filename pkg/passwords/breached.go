@@ -0,0 +1,89 @@
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HASH_PREFIX_LENGTH is the length of the SHA-1 prefixes of the k-anonymity ranges.
+const HASH_PREFIX_LENGTH = 5
+
+// BreachedSource returns the k-anonymity range of a SHA-1 hash prefix: the suffixes of the breached password
+// hashes that start with the prefix, and how many times each one was seen.
+// It is the model of the Have I Been Pwned range API, so a remote source can be used in place of a local corpus.
+type BreachedSource interface {
+	Range(prefix string) map[string]int
+}
+
+// Corpus is a BreachedSource loaded in memory from a local file.
+type Corpus struct {
+	ranges map[string]map[string]int
+}
+
+// LoadCorpus loads a corpus of breached password hashes. Each line is an uppercase SHA-1 hash,
+// optionally followed by ":" and the count, like the files of the Have I Been Pwned downloader:
+// the concatenation of every range response with its prefix. Invalid lines are ignored.
+func LoadCorpus(r io.Reader) (*Corpus, error) {
+	c := &Corpus{ranges: map[string]map[string]int{}}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		hash, count, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(hash)
+
+		if len(hash) != sha1.Size*2 {
+			continue
+		}
+
+		n, err := strconv.Atoi(count)
+
+		if err != nil {
+			n = 1
+		}
+
+		prefix := hash[:HASH_PREFIX_LENGTH]
+
+		if c.ranges[prefix] == nil {
+			c.ranges[prefix] = map[string]int{}
+		}
+
+		c.ranges[prefix][hash[HASH_PREFIX_LENGTH:]] = n
+	}
+
+	return c, scanner.Err()
+}
+
+// LoadCorpusFile loads a corpus from a file, see LoadCorpus.
+func LoadCorpusFile(path string) (*Corpus, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return LoadCorpus(f)
+}
+
+// Range returns the suffixes of the hashes that start with the prefix.
+func (c *Corpus) Range(prefix string) map[string]int {
+	return c.ranges[strings.ToUpper(prefix)]
+}
+
+// IsBreached returns true if the password is in the source.
+// Only the hash prefix is sent to the source, the password and its full hash never leave this function.
+func IsBreached(source BreachedSource, password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := source.Range(hash[:HASH_PREFIX_LENGTH])[hash[HASH_PREFIX_LENGTH:]]
+
+	return found
+}
@@ -0,0 +1,76 @@
+package passwords
+
+import (
+	"math"
+	"unicode"
+)
+
+// Sizes of the character classes used to estimate the entropy of a password.
+const (
+	LOWER_POOL_SIZE  = 26
+	UPPER_POOL_SIZE  = 26
+	DIGIT_POOL_SIZE  = 10
+	SYMBOL_POOL_SIZE = 33
+	OTHER_POOL_SIZE  = 100
+)
+
+// EstimateEntropy estimates the entropy, in bits, of a password.
+// Each character adds the bits of the character classes used by the password, but characters that repeat
+// or continue a sequence of the previous one (like "aa", "ab" or "21") add a single bit,
+// and characters that were already used add half of the bits.
+func EstimateEntropy(password string) float64 {
+	runes := []rune(password)
+
+	if len(runes) == 0 {
+		return 0
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+
+	pool := 0
+
+	for _, c := range []struct {
+		has  bool
+		size int
+	}{{hasLower, LOWER_POOL_SIZE}, {hasUpper, UPPER_POOL_SIZE}, {hasDigit, DIGIT_POOL_SIZE}, {hasSymbol, SYMBOL_POOL_SIZE}, {hasOther, OTHER_POOL_SIZE}} {
+		if c.has {
+			pool += c.size
+		}
+	}
+
+	bitsPerChar := math.Log2(float64(pool))
+	entropy := bitsPerChar
+	seen := map[rune]bool{runes[0]: true}
+
+	for i := 1; i < len(runes); i++ {
+		diff := runes[i] - runes[i-1]
+
+		switch {
+		case diff >= -1 && diff <= 1:
+			entropy += 1
+		case seen[runes[i]]:
+			entropy += bitsPerChar / 2
+		default:
+			entropy += bitsPerChar
+		}
+
+		seen[runes[i]] = true
+	}
+
+	return entropy
+}
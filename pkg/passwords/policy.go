@@ -0,0 +1,51 @@
+package passwords
+
+// UserInfo is the data of the user that must not be part of the password.
+type UserInfo struct {
+	Nick  string
+	Name  string
+	Email string
+}
+
+// Rule is a password policy rule. It returns an error with a translation key if the password does not satisfy it.
+type Rule interface {
+	Validate(password string, info UserInfo) error
+}
+
+// Policy is a set of rules that passwords must satisfy.
+type Policy struct {
+	Rules []Rule
+}
+
+// ValidationError holds the translation keys of every rule that the password did not satisfy.
+// Error returns the first key, so it can be handled like the other validation errors.
+type ValidationError struct {
+	Keys []string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Keys[0]
+}
+
+// NewPolicy creates a policy with the given rules.
+func NewPolicy(rules ...Rule) *Policy {
+	return &Policy{Rules: rules}
+}
+
+// Validate checks the password against every rule of the policy.
+// It returns a *ValidationError with all failed rules, or nil if the password satisfies the policy.
+func (p *Policy) Validate(password string, info UserInfo) error {
+	keys := []string{}
+
+	for _, rule := range p.Rules {
+		if err := rule.Validate(password, info); err != nil {
+			keys = append(keys, err.Error())
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Keys: keys}
+}
@@ -0,0 +1,92 @@
+package passwords
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// PERSONAL_INFO_MIN_LENGTH is the minimum length of the parts of the user info that are checked.
+// Shorter parts, like a two letters name, would block too many passwords.
+const PERSONAL_INFO_MIN_LENGTH = 3
+
+// LengthRule requires passwords to have at least Min characters.
+type LengthRule struct {
+	Min int
+}
+
+// EntropyRule requires passwords to have at least MinBits of estimated entropy, see EstimateEntropy.
+type EntropyRule struct {
+	MinBits float64
+}
+
+// PersonalInfoRule rejects passwords that contain the nick, the name or the email of the user.
+type PersonalInfoRule struct{}
+
+// BreachedRule rejects passwords found in a breached passwords source.
+type BreachedRule struct {
+	Source BreachedSource
+}
+
+func (r LengthRule) Validate(password string, info UserInfo) error {
+	if utf8.RuneCountInString(password) < r.Min {
+		return errors.New(pkgErrors.PASSWORD_TOO_SHORT)
+	}
+
+	return nil
+}
+
+func (r EntropyRule) Validate(password string, info UserInfo) error {
+	if EstimateEntropy(password) < r.MinBits {
+		return errors.New(pkgErrors.PASSWORD_TOO_WEAK)
+	}
+
+	return nil
+}
+
+func (r PersonalInfoRule) Validate(password string, info UserInfo) error {
+	password = strings.ToLower(password)
+
+	for _, part := range getPersonalInfoParts(info) {
+		if strings.Contains(password, part) {
+			return errors.New(pkgErrors.PASSWORD_CONTAINS_PERSONAL_INFO)
+		}
+	}
+
+	return nil
+}
+
+func (r BreachedRule) Validate(password string, info UserInfo) error {
+	if IsBreached(r.Source, password) {
+		return errors.New(pkgErrors.PASSWORD_BREACHED)
+	}
+
+	return nil
+}
+
+// getPersonalInfoParts returns the lowercase nick, the words of the name, and the email with the parts of its local part.
+func getPersonalInfoParts(info UserInfo) []string {
+	candidates := []string{info.Nick, info.Email}
+	candidates = append(candidates, strings.Fields(info.Name)...)
+
+	if local, _, found := strings.Cut(info.Email, "@"); found {
+		candidates = append(candidates, local)
+		candidates = append(candidates, strings.FieldsFunc(local, func(r rune) bool {
+			return strings.ContainsRune("._-+", r)
+		})...)
+	}
+
+	parts := []string{}
+
+	for _, c := range candidates {
+		c = strings.ToLower(strings.TrimSpace(c))
+
+		if utf8.RuneCountInString(c) >= PERSONAL_INFO_MIN_LENGTH {
+			parts = append(parts, c)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,136 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Languages of the MongoDB text search.
+const (
+	LANGUAGE_ENGLISH    = "english"
+	LANGUAGE_PORTUGUESE = "portuguese"
+	LANGUAGE_SPANISH    = "spanish"
+
+	DEFAULT_LANGUAGE = LANGUAGE_ENGLISH
+)
+
+// LANGUAGES maps the locales of the app to the languages of the MongoDB text search.
+var LANGUAGES = map[string]string{
+	"en-US": LANGUAGE_ENGLISH,
+	"pt-BR": LANGUAGE_PORTUGUESE,
+	"es-ES": LANGUAGE_SPANISH,
+}
+
+// SUFFIXES are the inflectional suffixes removed by Stem, by language, from the longest to the shortest.
+// They are a small subset of the Snowball stemmers used by MongoDB, enough to highlight the words that
+// MongoDB matched, like "replies" for "reply" or "perguntas" for "pergunta".
+var SUFFIXES = map[string][]string{
+	LANGUAGE_ENGLISH:    {"ing", "es", "ed", "e", "s"},
+	LANGUAGE_PORTUGUESE: {"amente", "mente", "coes", "cao", "ando", "endo", "indo", "ados", "adas", "idos", "idas", "ado", "ada", "ido", "ida", "oes", "ais", "es", "as", "os", "a", "o", "e", "s"},
+	LANGUAGE_SPANISH:    {"amente", "mente", "ciones", "cion", "ando", "iendo", "ados", "adas", "idos", "idas", "ado", "ada", "ido", "ida", "es", "as", "os", "a", "o", "e", "s"},
+}
+
+// STEM_MIN_LENGTH is the minimum length of the stems, so short words are kept whole.
+const STEM_MIN_LENGTH = 3
+
+// Language returns the language of the MongoDB text search for a locale of the app. Unknown locales are searched in
+// DEFAULT_LANGUAGE.
+func Language(locale string) string {
+	if language, ok := LANGUAGES[locale]; ok {
+		return language
+	}
+
+	return DEFAULT_LANGUAGE
+}
+
+// Fold returns the word lowercased and without diacritics, so "Pergunta" and "Ação" are "pergunta" and "acao".
+func Fold(word string) string {
+	var b strings.Builder
+
+	for _, r := range norm.NFD.String(strings.ToLower(word)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Stem returns the folded word without its longest inflectional suffix of the language.
+// In english, a final "y" becomes "i" too, so "reply", "replies" and "replied" have the same stem.
+func Stem(word, language string) string {
+	word = Fold(word)
+
+	for _, suffix := range SUFFIXES[language] {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= STEM_MIN_LENGTH {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+
+	if language == LANGUAGE_ENGLISH && strings.HasSuffix(word, "y") {
+		word = strings.TrimSuffix(word, "y") + "i"
+	}
+
+	return word
+}
+
+// word is a word of a text, with its position in runes.
+type word struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// words splits a text in its words: runs of letters, digits and combining marks.
+func words(text []rune) []word {
+	found := []word{}
+	start := -1
+
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r)
+
+		if isWord && start == -1 {
+			start = i
+		}
+
+		if !isWord && start != -1 {
+			found = append(found, word{Text: string(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+
+	if start != -1 {
+		found = append(found, word{Text: string(text[start:]), Start: start, End: len(text)})
+	}
+
+	return found
+}
+
+// Terms returns the stems of the words of a query, in the syntax of the MongoDB text search: the words of phrases
+// between quotes are terms too, and negated words, like "-word", are ignored.
+func Terms(query, language string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+
+	for _, field := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+
+		for _, w := range words([]rune(field)) {
+			stem := Stem(w.Text, language)
+
+			if !seen[stem] {
+				seen[stem] = true
+				terms = append(terms, stem)
+			}
+		}
+	}
+
+	return terms
+}
@@ -0,0 +1,96 @@
+package search
+
+import "strings"
+
+// SNIPPET_LENGTH is the maximum length of the snippets, in runes, without the ellipses.
+// SNIPPET_CONTEXT is how many runes of the text are kept before the first match.
+const (
+	SNIPPET_LENGTH  = 160
+	SNIPPET_CONTEXT = 40
+	ELLIPSIS        = "…"
+)
+
+// Range is a part of a snippet. Start and End are offsets in runes, End is exclusive.
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is a part of a text around the words that match a search, with the ranges of these words so clients
+// can highlight them.
+type Snippet struct {
+	Text       string  `json:"text"`
+	Highlights []Range `json:"highlights"`
+}
+
+// matches returns true if the word matches one of the terms: if their stems are the same or if the word starts
+// with the term, like "questionnaire" for "question". Terms shorter than STEM_MIN_LENGTH must be the whole word.
+func matches(w, language string, terms []string) bool {
+	stem := Stem(w, language)
+	folded := Fold(w)
+
+	for _, term := range terms {
+		if stem == term || folded == term || (len(term) >= STEM_MIN_LENGTH && strings.HasPrefix(folded, term)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Highlight returns a snippet of the text around its first word that matches one of the terms, as returned by Terms.
+// It returns nil if no word matches.
+func Highlight(text string, terms []string, language string) *Snippet {
+	runes := []rune(text)
+	matched := []Range{}
+
+	for _, w := range words(runes) {
+		if matches(w.Text, language, terms) {
+			matched = append(matched, Range{Start: w.Start, End: w.End})
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	start, end := 0, len(runes)
+
+	if len(runes) > SNIPPET_LENGTH {
+		start = matched[0].Start - SNIPPET_CONTEXT
+
+		if start < 0 {
+			start = 0
+		}
+
+		end = start + SNIPPET_LENGTH
+
+		if end > len(runes) {
+			end = len(runes)
+			start = end - SNIPPET_LENGTH
+		}
+	}
+
+	snippet := &Snippet{Text: string(runes[start:end]), Highlights: []Range{}}
+	offset := -start
+
+	if start > 0 {
+		snippet.Text = ELLIPSIS + snippet.Text
+		offset += len([]rune(ELLIPSIS))
+	}
+
+	if end < len(runes) {
+		snippet.Text += ELLIPSIS
+	}
+
+	for _, r := range matched {
+		// words cut by the ends of the snippet are not highlighted
+		if r.Start < start || r.End > end {
+			continue
+		}
+
+		snippet.Highlights = append(snippet.Highlights, Range{Start: r.Start + offset, End: r.End + offset})
+	}
+
+	return snippet
+}
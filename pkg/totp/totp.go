@@ -0,0 +1,111 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DIGITS is the number of digits of each generated code.
+	DIGITS = 6
+	// PERIOD is the time step, in seconds, that a code is valid for.
+	PERIOD = 30
+	// SECRET_SIZE is the size, in bytes, of generated secrets. RFC 4226 recommends 160 bits.
+	SECRET_SIZE = 20
+	// ALLOWED_SKEW is how many time steps before and after the current one are accepted to tolerate clock drift.
+	ALLOWED_SKEW = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a new random secret encoded as unpadded base32, the format expected by authenticator apps.
+func GenerateSecret() (string, error) {
+	b := make([]byte, SECRET_SIZE)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// Step returns the RFC 6238 time step for the given time.
+func Step(t time.Time) int64 {
+	return t.Unix() / PERIOD
+}
+
+// GenerateCode generates the code of the given base32 secret for the given time step (RFC 4226 HOTP with SHA-1).
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < DIGITS; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", DIGITS, value%mod), nil
+}
+
+// Validate checks the code against the secret at the given time, accepting ALLOWED_SKEW steps around it.
+// It returns the matched time step so callers can reject replays of an already used code.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+
+	if len(code) != DIGITS {
+		return 0, false
+	}
+
+	current := Step(t)
+
+	for i := -ALLOWED_SKEW; i <= ALLOWED_SKEW; i++ {
+		step := current + int64(i)
+		expected, err := GenerateCode(secret, step)
+
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// KeyURI builds the otpauth:// URI used by authenticator apps to register the secret, usually rendered as a QR code.
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func KeyURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(fmt.Sprintf("%s:%s", issuer, accountName))
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(DIGITS))
+	params.Set("period", fmt.Sprint(PERIOD))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
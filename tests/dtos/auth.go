@@ -204,3 +204,94 @@ func GetResetPasswordValidateDTOBatches(t *testing.T, resetPasswordData auth.Res
 		},
 	}
 }
+
+// GetSignInTwoFactorValidateDTOBatches returns a slice of BatchTest for SignInTwoFactorDTO testing Validate method.
+func GetSignInTwoFactorValidateDTOBatches(t *testing.T, signInTwoFactorData auth.SignInTwoFactorDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				signInTwoFactorData.ChallengeToken = ""
+				assert.ErrorContains(t, signInTwoFactorData.Validate(), "challenge_token_required")
+
+				signInTwoFactorData.ChallengeToken = tests.GenerateRandomString(64)
+				assert.NoError(t, signInTwoFactorData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				signInTwoFactorData.Code = ""
+				assert.ErrorContains(t, signInTwoFactorData.Validate(), "two_factor_code_required")
+
+				signInTwoFactorData.Code = "123456"
+				assert.NoError(t, signInTwoFactorData.Validate())
+			},
+		},
+	}
+}
+
+// GetVerifyEmailValidateDTOBatches returns a slice of BatchTest for VerifyEmailDTO testing Validate method.
+func GetVerifyEmailValidateDTOBatches(t *testing.T, verifyEmailData auth.VerifyEmailDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				verifyEmailData.Token = ""
+				assert.ErrorContains(t, verifyEmailData.Validate(), "verification_token_required")
+
+				verifyEmailData.Token = tests.GenerateRandomString(100)
+				assert.NoError(t, verifyEmailData.Validate())
+			},
+		},
+	}
+}
+
+// GetPasswordlessRequestDTOBatches returns a slice of BatchTest for PasswordlessRequestDTO testing Format and Validate methods.
+func GetPasswordlessRequestDTOBatches(t *testing.T, passwordlessRequestData auth.PasswordlessRequestDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				passwordlessRequestData.Email = "  Test-API@Example.com "
+				passwordlessRequestData.Format()
+				assert.Equal(t, "test-api@example.com", passwordlessRequestData.Email)
+				assert.NoError(t, passwordlessRequestData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				passwordlessRequestData.Email = ""
+				assert.ErrorContains(t, passwordlessRequestData.Validate(), "email_field_required")
+
+				passwordlessRequestData.Email = tests.GenerateRandomString(130)
+				assert.ErrorContains(t, passwordlessRequestData.Validate(), "email_format_invalid")
+			},
+		},
+	}
+}
+
+// GetSignInPasswordlessValidateDTOBatches returns a slice of BatchTest for SignInPasswordlessDTO testing Validate method.
+func GetSignInPasswordlessValidateDTOBatches(t *testing.T, signInPasswordlessData auth.SignInPasswordlessDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				signInPasswordlessData.DeviceToken = ""
+				assert.ErrorContains(t, signInPasswordlessData.Validate(), "device_token_required")
+
+				signInPasswordlessData.DeviceToken = tests.GenerateRandomString(64)
+				assert.NoError(t, signInPasswordlessData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				signInPasswordlessData.Token = ""
+				signInPasswordlessData.Code = ""
+				assert.ErrorContains(t, signInPasswordlessData.Validate(), "passwordless_code_required")
+
+				signInPasswordlessData.Code = "123456"
+				assert.NoError(t, signInPasswordlessData.Validate())
+
+				signInPasswordlessData.Code = ""
+				signInPasswordlessData.Token = tests.GenerateRandomString(64)
+				assert.NoError(t, signInPasswordlessData.Validate())
+			},
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetBanUserValidateDTOBatches returns a slice of BatchTest for BanUserDTO testing Validate method.
+func GetBanUserValidateDTOBatches(t *testing.T, banData bans.BanUserDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				banData.Reason = ""
+				assert.ErrorContains(t, banData.Validate(), "reason_field_required")
+
+				banData.Reason = tests.GenerateRandomString(bans.REASON_MAX_LENGTH + 1)
+				assert.ErrorContains(t, banData.Validate(), "ban_reason_length")
+
+				banData.Reason = tests.GenerateRandomString(bans.REASON_MAX_LENGTH)
+				assert.NoError(t, banData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				past := time.Now().Add(-time.Minute)
+				banData.ExpiresAt = &past
+				assert.ErrorContains(t, banData.Validate(), "ban_expires_at_invalid")
+
+				future := time.Now().Add(time.Hour)
+				banData.ExpiresAt = &future
+				assert.NoError(t, banData.Validate())
+
+				banData.ExpiresAt = nil
+				assert.NoError(t, banData.Validate())
+			},
+		},
+	}
+}
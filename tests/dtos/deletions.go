@@ -0,0 +1,48 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/internal/deletions"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetRequestDeletionValidateDTOBatches returns a slice of BatchTest for RequestDeletionDTO testing Validate method.
+func GetRequestDeletionValidateDTOBatches(t *testing.T, requestDeletionData deletions.RequestDeletionDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				u := &users.User{}
+
+				requestDeletionData.Password = ""
+				assert.ErrorContains(t, requestDeletionData.Validate(u), "password_field_required")
+
+				requestDeletionData.Password = "current-password"
+				assert.NoError(t, requestDeletionData.Validate(u))
+			},
+		},
+		{
+			OnRun: func() {
+				// users that never defined a password must sign in again instead
+				u := &users.User{PasswordNotSet: true}
+
+				requestDeletionData.Password = ""
+				assert.NoError(t, requestDeletionData.Validate(u))
+			},
+		},
+		{
+			OnRun: func() {
+				u := &users.User{TwoFactor: &users.TwoFactor{IsEnabled: true}}
+
+				requestDeletionData.Password = "current-password"
+				requestDeletionData.Code = ""
+				assert.ErrorContains(t, requestDeletionData.Validate(u), "two_factor_code_required")
+
+				requestDeletionData.Code = "123456"
+				assert.NoError(t, requestDeletionData.Validate(u))
+			},
+		},
+	}
+}
@@ -2,11 +2,20 @@ package dtos
 
 import (
 	"testing"
+	"time"
 
 	"github.com/quessapp/core-go/internal/auth"
+	"github.com/quessapp/core-go/internal/bans"
 	"github.com/quessapp/core-go/internal/blocks"
+	"github.com/quessapp/core-go/internal/deletions"
+	"github.com/quessapp/core-go/internal/identities"
+	"github.com/quessapp/core-go/internal/middlewares"
+	personaltokens "github.com/quessapp/core-go/internal/personal-tokens"
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/internal/reports"
+	"github.com/quessapp/core-go/internal/roles"
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
 	"github.com/quessapp/core-go/internal/users"
 	"github.com/quessapp/core-go/pkg/tests"
 )
@@ -59,6 +68,14 @@ func TestValidate(t *testing.T) {
 	createReplyQuestionValidateDTOBatches := GetCreateQuestionValidateDTOBatches(t, questions.CreateQuestionDTO{})
 	tests.RunBatchTests(createReplyQuestionValidateDTOBatches)
 
+	reactQuestionValidateDTOBatches := GetReactQuestionValidateDTOBatches(t, questions.ReactQuestionDTO{})
+	tests.RunBatchTests(reactQuestionValidateDTOBatches)
+
+	tests.RunBatchTests(GetCreatePollValidateDTOBatches(t, questions.CreateQuestionDTO{}))
+	tests.RunBatchTests(GetReplyPollValidateDTOBatches(t, questions.ReplyQuestionDTO{}))
+	tests.RunBatchTests(GetSearchValidateDTOBatches(t, questions.SearchQuestionsDTO{}))
+	tests.RunBatchTests(GetSaveReplyDraftValidateDTOBatches(t, questions.SaveReplyDraftDTO{}))
+
 	createReportValidateDTOBatches := GetCreateReportValidateDTOBatches(t, reports.CreateReportDTO{
 		Reason: "spam",
 		Type:   "question",
@@ -72,4 +89,68 @@ func TestValidate(t *testing.T) {
 		Locale: "en-US",
 	})
 	tests.RunBatchTests(updateUserProfileValidateDTOBatches)
+
+	tests.RunBatchTests(GetUpdateInboxValidateDTOBatches(t, users.UpdateInboxDTO{}))
+
+	signInTwoFactorValidateDTOBatches := GetSignInTwoFactorValidateDTOBatches(t, auth.SignInTwoFactorDTO{
+		ChallengeToken: "challenge",
+		Code:           "123456",
+	})
+	tests.RunBatchTests(signInTwoFactorValidateDTOBatches)
+
+	enrollTwoFactorValidateDTOBatches := GetEnrollTwoFactorValidateDTOBatches(t, twofactor.EnrollDTO{})
+	tests.RunBatchTests(enrollTwoFactorValidateDTOBatches)
+
+	reauthenticateTwoFactorValidateDTOBatches := GetReauthenticateTwoFactorValidateDTOBatches(t, twofactor.ReauthenticateDTO{
+		Password: "test123",
+		Code:     "123456",
+	})
+	tests.RunBatchTests(reauthenticateTwoFactorValidateDTOBatches)
+
+	verifyEmailValidateDTOBatches := GetVerifyEmailValidateDTOBatches(t, auth.VerifyEmailDTO{})
+	tests.RunBatchTests(verifyEmailValidateDTOBatches)
+
+	callbackValidateDTOBatches := GetCallbackValidateDTOBatches(t, identities.CallbackDTO{
+		Code:  "code",
+		State: "state",
+	})
+	tests.RunBatchTests(callbackValidateDTOBatches)
+
+	banUserValidateDTOBatches := GetBanUserValidateDTOBatches(t, bans.BanUserDTO{
+		Reason: "spam",
+	})
+	tests.RunBatchTests(banUserValidateDTOBatches)
+
+	passwordlessRequestDTOBatches := GetPasswordlessRequestDTOBatches(t, auth.PasswordlessRequestDTO{})
+	tests.RunBatchTests(passwordlessRequestDTOBatches)
+
+	signInPasswordlessValidateDTOBatches := GetSignInPasswordlessValidateDTOBatches(t, auth.SignInPasswordlessDTO{
+		DeviceToken: tests.GenerateRandomString(64),
+		Code:        "123456",
+	})
+	tests.RunBatchTests(signInPasswordlessValidateDTOBatches)
+
+	trustLocationValidateDTOBatches := GetTrustLocationValidateDTOBatches(t, trustedlocations.TrustLocationDTO{
+		CIDR: "177.10.0.0/16",
+	})
+	tests.RunBatchTests(trustLocationValidateDTOBatches)
+
+	nextMonth := time.Now().Add(time.Hour * 24 * 30)
+	createPersonalTokenValidateDTOBatches := GetCreatePersonalTokenValidateDTOBatches(t, personaltokens.CreatePersonalTokenDTO{
+		Name:      "auto-poster",
+		Scopes:    []string{middlewares.SCOPE_QUESTIONS_READ},
+		ExpiresAt: &nextMonth,
+	})
+	tests.RunBatchTests(createPersonalTokenValidateDTOBatches)
+
+	changeRoleValidateDTOBatches := GetChangeRoleValidateDTOBatches(t, roles.ChangeRoleDTO{
+		Role:   users.ROLE_MODERATOR,
+		Reason: "new moderator",
+	})
+	tests.RunBatchTests(changeRoleValidateDTOBatches)
+
+	requestDeletionValidateDTOBatches := GetRequestDeletionValidateDTOBatches(t, deletions.RequestDeletionDTO{
+		Password: "current-password",
+	})
+	tests.RunBatchTests(requestDeletionValidateDTOBatches)
 }
@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/internal/identities"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetCallbackValidateDTOBatches returns a slice of BatchTest for CallbackDTO testing Validate method.
+func GetCallbackValidateDTOBatches(t *testing.T, callbackData identities.CallbackDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				callbackData.Code = ""
+				assert.ErrorContains(t, callbackData.Validate(), "code_required")
+
+				callbackData.Code = tests.GenerateRandomString(40)
+				assert.NoError(t, callbackData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				callbackData.State = ""
+				assert.ErrorContains(t, callbackData.Validate(), "oidc_state_required")
+
+				callbackData.State = tests.GenerateRandomString(43)
+				assert.NoError(t, callbackData.Validate())
+			},
+		},
+	}
+}
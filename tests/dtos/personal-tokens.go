@@ -0,0 +1,59 @@
+package dtos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/internal/middlewares"
+	personaltokens "github.com/quessapp/core-go/internal/personal-tokens"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetCreatePersonalTokenValidateDTOBatches returns a slice of BatchTest for CreatePersonalTokenDTO testing Validate method.
+func GetCreatePersonalTokenValidateDTOBatches(t *testing.T, createPersonalTokenData personaltokens.CreatePersonalTokenDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				createPersonalTokenData.Name = ""
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_name_required")
+
+				createPersonalTokenData.Name = tests.GenerateRandomString(personaltokens.NAME_MAX_LENGTH + 1)
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_name_length")
+
+				createPersonalTokenData.Name = "auto-poster"
+				assert.NoError(t, createPersonalTokenData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				createPersonalTokenData.Scopes = []string{}
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_scopes_required")
+
+				createPersonalTokenData.Scopes = []string{middlewares.SCOPE_QUESTIONS_READ, "questions:delete"}
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_scope_invalid")
+
+				createPersonalTokenData.Scopes = middlewares.SCOPES
+				assert.NoError(t, createPersonalTokenData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				createPersonalTokenData.ExpiresAt = nil
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_expires_at_invalid")
+
+				past := time.Now().Add(-time.Hour)
+				createPersonalTokenData.ExpiresAt = &past
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_expires_at_invalid")
+
+				tooFar := time.Now().Add(personaltokens.MAX_EXPIRES_IN + time.Hour)
+				createPersonalTokenData.ExpiresAt = &tooFar
+				assert.ErrorContains(t, createPersonalTokenData.Validate(), "personal_token_expires_at_invalid")
+
+				nextMonth := time.Now().Add(time.Hour * 24 * 30)
+				createPersonalTokenData.ExpiresAt = &nextMonth
+				assert.NoError(t, createPersonalTokenData.Validate())
+			},
+		},
+	}
+}
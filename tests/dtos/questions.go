@@ -2,9 +2,11 @@ package dtos
 
 import (
 	"testing"
+	"time"
 
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,3 +66,179 @@ func GetCreateQuestionValidateDTOBatches(t *testing.T, createQuestionData questi
 		},
 	}
 }
+
+// GetReactQuestionValidateDTOBatches returns a slice of BatchTest for ReactQuestionDTO testing Validate method.
+func GetReactQuestionValidateDTOBatches(t *testing.T, reactQuestionData questions.ReactQuestionDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.ErrorContains(t, reactQuestionData.Validate(), "reaction_type_required")
+			},
+		},
+		{
+			OnRun: func() {
+				for _, reaction := range questions.REACTIONS {
+					reactQuestionData.Type = reaction
+					assert.NoError(t, reactQuestionData.Validate())
+				}
+
+				reactQuestionData.Type = "thumbsdown"
+				assert.ErrorContains(t, reactQuestionData.Validate(), "reaction_type_invalid")
+			},
+		},
+	}
+}
+
+// GetCreatePollValidateDTOBatches returns a slice of BatchTest for CreateQuestionDTO testing Validate method on polls.
+func GetCreatePollValidateDTOBatches(t *testing.T, createQuestionData questions.CreateQuestionDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				createQuestionData.Content = "cats or dogs?"
+
+				createQuestionData.PollOptions = []string{"cats"}
+				assert.ErrorContains(t, createQuestionData.Validate(), "poll_options_length")
+
+				createQuestionData.PollOptions = []string{"1", "2", "3", "4", "5", "6", "7"}
+				assert.ErrorContains(t, createQuestionData.Validate(), "poll_options_length")
+
+				createQuestionData.PollOptions = []string{"cats", "dogs"}
+				assert.NoError(t, createQuestionData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				createQuestionData.Content = "cats or dogs?"
+
+				createQuestionData.PollOptions = []string{"cats", " "}
+				assert.ErrorContains(t, createQuestionData.Validate(), "poll_option_invalid")
+
+				createQuestionData.PollOptions = []string{"cats", tests.GenerateRandomString(questions.POLL_OPTION_MAX_LENGTH + 1)}
+				assert.ErrorContains(t, createQuestionData.Validate(), "poll_option_invalid")
+
+				createQuestionData.PollOptions = []string{"cats", "Cats "}
+				assert.ErrorContains(t, createQuestionData.Validate(), "poll_options_duplicated")
+			},
+		},
+		{
+			OnRun: func() {
+				// the prompt of a poll sent again is the one of the poll
+				createQuestionData.Content = ""
+				createQuestionData.PollOptions = nil
+				createQuestionData.PollQuestionID = toolkitEntities.NewID()
+				assert.NoError(t, createQuestionData.Validate())
+			},
+		},
+	}
+}
+
+// GetReplyPollValidateDTOBatches returns a slice of BatchTest for ReplyQuestionDTO testing Validate method on polls.
+func GetReplyPollValidateDTOBatches(t *testing.T, replyQuestionData questions.ReplyQuestionDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// the comment on the choice is optional
+				choice := 1
+				replyQuestionData.Choice = &choice
+				assert.NoError(t, replyQuestionData.Validate())
+
+				replyQuestionData.Content = tests.GenerateRandomString(300)
+				assert.ErrorContains(t, replyQuestionData.Validate(), "content_field_length")
+			},
+		},
+	}
+}
+
+// GetSearchValidateDTOBatches returns a slice of BatchTest for SearchQuestionsDTO testing Validate method.
+func GetSearchValidateDTOBatches(t *testing.T, searchQuestionsData questions.SearchQuestionsDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_query_required")
+
+				searchQuestionsData.Query = "a"
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_query_length")
+
+				searchQuestionsData.Query = tests.GenerateRandomString(questions.SEARCH_QUERY_MAX_LENGTH + 1)
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_query_length")
+
+				searchQuestionsData.Query = "movies"
+				assert.NoError(t, searchQuestionsData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				searchQuestionsData.Query = "movies"
+
+				searchQuestionsData.In = []string{"received", "inbox"}
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_box_invalid")
+
+				searchQuestionsData.In = []string{"received", "replied"}
+				assert.NoError(t, searchQuestionsData.Validate())
+
+				searchQuestionsData.IsAnonymous = "yes"
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_anonymous_invalid")
+
+				searchQuestionsData.IsAnonymous = "true"
+				assert.NoError(t, searchQuestionsData.Validate())
+				assert.True(t, *searchQuestionsData.GetIsAnonymous())
+			},
+		},
+		{
+			OnRun: func() {
+				searchQuestionsData.Query = "movies"
+				searchQuestionsData.In = nil
+				searchQuestionsData.IsAnonymous = ""
+
+				searchQuestionsData.From = "01/02/2023"
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_date_invalid")
+
+				searchQuestionsData.From = "2023-02-10"
+				searchQuestionsData.To = "2023-02-01"
+				assert.ErrorContains(t, searchQuestionsData.Validate(), "search_period_invalid")
+
+				// the To day is included
+				searchQuestionsData.To = "2023-02-10"
+				assert.NoError(t, searchQuestionsData.Validate())
+
+				from, to := searchQuestionsData.GetPeriod()
+				assert.Equal(t, 24*time.Hour, to.Sub(*from))
+			},
+		},
+	}
+}
+
+// GetSaveReplyDraftValidateDTOBatches returns a slice of BatchTest for SaveReplyDraftDTO testing Validate method.
+func GetSaveReplyDraftValidateDTOBatches(t *testing.T, saveReplyDraftData questions.SaveReplyDraftDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// scheduled replies are published as they are, so drafts follow the rules of the replies
+				assert.ErrorContains(t, saveReplyDraftData.Validate(), "content_field_required")
+
+				saveReplyDraftData.Content = tests.GenerateRandomString(300)
+				assert.ErrorContains(t, saveReplyDraftData.Validate(), "content_field_length")
+
+				saveReplyDraftData.Content = "my reply"
+				assert.NoError(t, saveReplyDraftData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				saveReplyDraftData.Content = "my reply"
+
+				past := time.Now().Add(-time.Minute)
+				saveReplyDraftData.PublishAt = &past
+				assert.ErrorContains(t, saveReplyDraftData.Validate(), "draft_publish_at_past")
+
+				tooFar := time.Now().Add(questions.DRAFT_MAX_SCHEDULE + time.Hour)
+				saveReplyDraftData.PublishAt = &tooFar
+				assert.ErrorContains(t, saveReplyDraftData.Validate(), "draft_publish_at_too_far")
+
+				tomorrow := time.Now().Add(time.Hour * 24)
+				saveReplyDraftData.PublishAt = &tomorrow
+				assert.NoError(t, saveReplyDraftData.Validate())
+			},
+		},
+	}
+}
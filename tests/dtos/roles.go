@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/internal/roles"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetChangeRoleValidateDTOBatches returns a slice of BatchTest for ChangeRoleDTO testing Validate method.
+func GetChangeRoleValidateDTOBatches(t *testing.T, changeRoleData roles.ChangeRoleDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				changeRoleData.Role = ""
+				assert.ErrorContains(t, changeRoleData.Validate(), "role_required")
+
+				changeRoleData.Role = "owner"
+				assert.ErrorContains(t, changeRoleData.Validate(), "role_invalid")
+
+				for _, role := range users.ROLES {
+					changeRoleData.Role = role
+					assert.NoError(t, changeRoleData.Validate())
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				changeRoleData.Reason = ""
+				assert.ErrorContains(t, changeRoleData.Validate(), "reason_field_required")
+
+				changeRoleData.Reason = tests.GenerateRandomString(roles.REASON_MAX_LENGTH + 1)
+				assert.ErrorContains(t, changeRoleData.Validate(), "role_change_reason_length")
+
+				changeRoleData.Reason = "new moderator"
+				assert.NoError(t, changeRoleData.Validate())
+			},
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package dtos
+
+import (
+	"testing"
+
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTrustLocationValidateDTOBatches returns a slice of BatchTest for TrustLocationDTO testing Validate method.
+func GetTrustLocationValidateDTOBatches(t *testing.T, trustLocationData trustedlocations.TrustLocationDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				trustLocationData.CIDR = ""
+				trustLocationData.ASN = 0
+				assert.ErrorContains(t, trustLocationData.Validate(), "trusted_location_required")
+
+				trustLocationData.ASN = 15169
+				assert.NoError(t, trustLocationData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				trustLocationData.ASN = 0
+
+				trustLocationData.CIDR = "177.10.0.0"
+				assert.ErrorContains(t, trustLocationData.Validate(), "trusted_location_cidr_invalid")
+
+				trustLocationData.CIDR = "177.0.0.0/8"
+				assert.ErrorContains(t, trustLocationData.Validate(), "trusted_location_cidr_too_broad")
+
+				trustLocationData.CIDR = "2001::/16"
+				assert.ErrorContains(t, trustLocationData.Validate(), "trusted_location_cidr_too_broad")
+
+				trustLocationData.CIDR = "177.10.0.0/16"
+				assert.NoError(t, trustLocationData.Validate())
+
+				trustLocationData.CIDR = "2001:db8::/48"
+				assert.NoError(t, trustLocationData.Validate())
+			},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"testing"
+
+	twofactor "github.com/quessapp/core-go/internal/two-factor"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetEnrollTwoFactorValidateDTOBatches returns a slice of BatchTest for EnrollDTO testing Validate method.
+func GetEnrollTwoFactorValidateDTOBatches(t *testing.T, enrollData twofactor.EnrollDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				enrollData.Password = ""
+				assert.ErrorContains(t, enrollData.Validate(), "password_field_required")
+
+				enrollData.Password = tests.GenerateRandomString(300)
+				assert.ErrorContains(t, enrollData.Validate(), "password_field_length")
+
+				enrollData.Password = tests.GenerateRandomString(10)
+				assert.NoError(t, enrollData.Validate())
+			},
+		},
+	}
+}
+
+// GetReauthenticateTwoFactorValidateDTOBatches returns a slice of BatchTest for ReauthenticateDTO testing Validate method.
+func GetReauthenticateTwoFactorValidateDTOBatches(t *testing.T, reauthenticateData twofactor.ReauthenticateDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				reauthenticateData.Password = ""
+				assert.ErrorContains(t, reauthenticateData.Validate(), "password_field_required")
+
+				reauthenticateData.Password = tests.GenerateRandomString(10)
+				assert.NoError(t, reauthenticateData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				reauthenticateData.Code = ""
+				assert.ErrorContains(t, reauthenticateData.Validate(), "two_factor_code_required")
+
+				reauthenticateData.Code = "ABCDE-FGHJK"
+				assert.NoError(t, reauthenticateData.Validate())
+			},
+		},
+	}
+}
@@ -84,3 +84,41 @@ func GetUpdateUserProfileValidateDTOBatches(t *testing.T, updateProfileData user
 		},
 	}
 }
+
+// GetUpdateInboxValidateDTOBatches returns a slice of BatchTest for UpdateInboxDTO testing Validate method.
+func GetUpdateInboxValidateDTOBatches(t *testing.T, updateInboxData users.UpdateInboxDTO) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// the zero value receives every question
+				assert.NoError(t, updateInboxData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				updateInboxData.MinAccountAgeDays = -1
+				assert.ErrorContains(t, updateInboxData.Validate(), "inbox_min_account_age_invalid")
+
+				updateInboxData.MinAccountAgeDays = users.INBOX_MAX_MIN_ACCOUNT_AGE_DAYS + 1
+				assert.ErrorContains(t, updateInboxData.Validate(), "inbox_min_account_age_invalid")
+
+				updateInboxData.MinAccountAgeDays = 7
+				assert.NoError(t, updateInboxData.Validate())
+			},
+		},
+		{
+			OnRun: func() {
+				updateInboxData.DailyLimit = users.INBOX_MAX_DAILY_LIMIT + 1
+				assert.ErrorContains(t, updateInboxData.Validate(), "inbox_daily_limit_invalid")
+
+				updateInboxData.DailyLimit = 20
+				updateInboxData.IsPaused = true
+				updateInboxData.PausedMessage = tests.GenerateRandomString(users.INBOX_PAUSED_MESSAGE_MAX_LENGTH + 1)
+				assert.ErrorContains(t, updateInboxData.Validate(), "inbox_paused_message_length")
+
+				updateInboxData.PausedMessage = "on vacation, back next week"
+				assert.NoError(t, updateInboxData.Validate())
+			},
+		},
+	}
+}
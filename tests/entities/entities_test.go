@@ -3,6 +3,7 @@ package entities
 import (
 	"testing"
 
+	"github.com/quessapp/core-go/internal/lockouts"
 	"github.com/quessapp/core-go/internal/users"
 	"github.com/quessapp/core-go/pkg/tests"
 	"github.com/quessapp/core-go/tests/mocks"
@@ -25,3 +26,33 @@ func TestGetBasicInfos(t *testing.T) {
 	getBasicUserDataBatches := GetBasicUserDataBatches(t, mocks.NewUserMock())
 	tests.RunBatchTests(getBasicUserDataBatches)
 }
+
+func TestEmailToVerify(t *testing.T) {
+	emailToVerifyBatches := GetEmailToVerifyBatches(t, mocks.NewUserMock())
+	tests.RunBatchTests(emailToVerifyBatches)
+}
+
+func TestBlockDuration(t *testing.T) {
+	tests.RunBatchTests(GetBlockDurationBatches(t, lockouts.ACCOUNT_POLICY))
+	tests.RunBatchTests(GetBlockDurationBatches(t, lockouts.IP_POLICY))
+}
+
+func TestBlockedUntil(t *testing.T) {
+	tests.RunBatchTests(GetBlockedUntilBatches(t, lockouts.ACCOUNT_POLICY))
+}
+
+func TestTrustedLocationMatches(t *testing.T) {
+	tests.RunBatchTests(GetTrustedLocationMatchesBatches(t))
+}
+
+func TestHasPermissions(t *testing.T) {
+	tests.RunBatchTests(GetHasPermissionsBatches(t, mocks.NewUserMock()))
+}
+
+func TestGetSentByID(t *testing.T) {
+	tests.RunBatchTests(GetSentByIDBatches(t, *mocks.NewQuestionMock()))
+}
+
+func TestPollFallback(t *testing.T) {
+	tests.RunBatchTests(GetPollFallbackBatches(t))
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/internal/lockouts"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetBlockDurationBatches returns a slice of BatchTest for Policy testing BlockDuration method.
+func GetBlockDurationBatches(t *testing.T, policy lockouts.Policy) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Equal(t, time.Duration(0), policy.BlockDuration(0))
+				assert.Equal(t, time.Duration(0), policy.BlockDuration(policy.BackoffThreshold-1))
+			},
+		},
+		{
+			OnRun: func() {
+				// the delay doubles on each failure after the backoff threshold
+				assert.Equal(t, policy.BaseDelay, policy.BlockDuration(policy.BackoffThreshold))
+				assert.Equal(t, policy.BaseDelay*2, policy.BlockDuration(policy.BackoffThreshold+1))
+				assert.Equal(t, policy.BaseDelay*4, policy.BlockDuration(policy.BackoffThreshold+2))
+			},
+		},
+		{
+			OnRun: func() {
+				for failures := policy.BackoffThreshold; failures < policy.LockoutThreshold; failures++ {
+					assert.LessOrEqual(t, policy.BlockDuration(failures), policy.MaxDelay)
+				}
+
+				assert.Equal(t, policy.LockoutDuration, policy.BlockDuration(policy.LockoutThreshold))
+				assert.Equal(t, policy.LockoutDuration, policy.BlockDuration(policy.LockoutThreshold+10))
+			},
+		},
+	}
+}
+
+// GetBlockedUntilBatches returns a slice of BatchTest for Attempts testing BlockedUntil method.
+func GetBlockedUntilBatches(t *testing.T, policy lockouts.Policy) []tests.BatchTest {
+	now := time.Now()
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Nil(t, lockouts.Attempts{}.BlockedUntil(policy, now))
+				assert.Nil(t, lockouts.Attempts{Failures: 1, LastFailureAt: &now}.BlockedUntil(policy, now))
+			},
+		},
+		{
+			OnRun: func() {
+				attempts := lockouts.Attempts{Failures: policy.LockoutThreshold, LastFailureAt: &now}
+
+				blockedUntil := attempts.BlockedUntil(policy, now)
+				assert.NotNil(t, blockedUntil)
+				assert.Equal(t, now.Add(policy.LockoutDuration), *blockedUntil)
+
+				assert.Nil(t, attempts.BlockedUntil(policy, now.Add(policy.LockoutDuration)))
+			},
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/quessapp/core-go/internal/questions"
 	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +21,20 @@ func GetTestMapByAnonymousFieldsBatches(t *testing.T, questionData questions.Que
 				assert.Nil(t, q.SentBy)
 			},
 		},
+		{
+			OnRun: func() {
+				// the reactions and the attachments are kept on anonymous questions
+				questionData.IsAnonymous = true
+				questionData.Reactions = map[string]int64{questions.REACTION_HEART: 2}
+				questionData.MyReactions = []string{questions.REACTION_HEART}
+				questionData.Attachments = []questions.Attachment{{Kind: "image", URL: "https://cdn.quess.app/replies/1.png"}}
+				q := questionData.MapAnonymousFields()
+
+				assert.Equal(t, questionData.Reactions, q.Reactions)
+				assert.Equal(t, questionData.MyReactions, q.MyReactions)
+				assert.Equal(t, questionData.Attachments, q.Attachments)
+			},
+		},
 		{
 			OnRun: func() {
 				questionData.IsAnonymous = false
@@ -31,3 +46,42 @@ func GetTestMapByAnonymousFieldsBatches(t *testing.T, questionData questions.Que
 		},
 	}
 }
+
+// GetSentByIDBatches returns a slice of BatchTest for testing the GetSentByID method of the questions.Question struct.
+func GetSentByIDBatches(t *testing.T, questionData questions.Question) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				id := toolkitEntities.NewID()
+				questionData.SentBy = id
+				assert.Equal(t, id, questionData.GetSentByID())
+			},
+		},
+		{
+			OnRun: func() {
+				// questions of users that deleted their accounts have no sender
+				questionData.SentBy = nil
+				assert.True(t, toolkitEntities.IsZeroID(questionData.GetSentByID()))
+			},
+		},
+	}
+}
+
+// GetPollFallbackBatches returns a slice of BatchTest for testing the fallbacks of polls for the clients that don't know them.
+func GetPollFallbackBatches(t *testing.T) []tests.BatchTest {
+	poll := questions.Poll{Options: []string{"cats", "dogs"}}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Equal(t, "cats or dogs? (cats / dogs)", poll.FallbackContent("cats or dogs?"))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Equal(t, "dogs", poll.FallbackReply(1, ""))
+				assert.Equal(t, "cats\n\nthey sleep all day", poll.FallbackReply(0, "they sleep all day"))
+			},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"net"
+	"testing"
+
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/pkg/geoip"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTrustedLocationMatchesBatches returns a slice of BatchTest for TrustedLocation testing Matches method.
+func GetTrustedLocationMatchesBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				l := trustedlocations.TrustedLocation{Kind: trustedlocations.KIND_IP, Value: "177.10.20.30"}
+
+				assert.True(t, l.Matches(net.ParseIP("177.10.20.30"), nil))
+				assert.False(t, l.Matches(net.ParseIP("177.10.20.31"), nil))
+				assert.False(t, l.Matches(nil, nil))
+
+				l = trustedlocations.TrustedLocation{Kind: trustedlocations.KIND_IP, Value: "2001:db8::1"}
+
+				assert.True(t, l.Matches(net.ParseIP("2001:db8:0:0::1"), nil))
+			},
+		},
+		{
+			OnRun: func() {
+				l := trustedlocations.TrustedLocation{Kind: trustedlocations.KIND_CIDR, Value: "177.10.0.0/16"}
+
+				assert.True(t, l.Matches(net.ParseIP("177.10.0.1"), nil))
+				assert.True(t, l.Matches(net.ParseIP("177.10.255.255"), nil))
+				assert.False(t, l.Matches(net.ParseIP("177.11.0.1"), nil))
+				assert.False(t, l.Matches(net.ParseIP("2001:db8::1"), nil))
+			},
+		},
+		{
+			OnRun: func() {
+				l := trustedlocations.TrustedLocation{Kind: trustedlocations.KIND_ASN, Value: trustedlocations.FormatASN(15169), ASN: 15169}
+
+				assert.Equal(t, "AS15169", l.Value)
+				assert.True(t, l.Matches(net.ParseIP("8.8.8.8"), &geoip.ASN{Number: 15169}))
+				assert.False(t, l.Matches(net.ParseIP("8.8.8.8"), &geoip.ASN{Number: 13335}))
+				// the autonomous system of the IP is unknown
+				assert.False(t, l.Matches(net.ParseIP("8.8.8.8"), nil))
+			},
+		},
+	}
+}
@@ -78,3 +78,68 @@ func GetBasicUserDataBatches(t *testing.T, userData *users.User) []tests.BatchTe
 		},
 	}
 }
+
+// GetEmailToVerifyBatches returns a slice of BatchTest for User testing EmailToVerify method.
+func GetEmailToVerifyBatches(t *testing.T, userData *users.User) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				userData.Email = "caio@api.com"
+				userData.IsVerified = false
+				userData.PendingEmail = ""
+				assert.Equal(t, "caio@api.com", userData.EmailToVerify())
+
+				userData.IsVerified = true
+				assert.Equal(t, "", userData.EmailToVerify())
+			},
+		},
+		{
+			OnRun: func() {
+				userData.Email = "caio@api.com"
+				userData.PendingEmail = "new@api.com"
+
+				userData.IsVerified = true
+				assert.Equal(t, "new@api.com", userData.EmailToVerify())
+
+				userData.IsVerified = false
+				assert.Equal(t, "new@api.com", userData.EmailToVerify())
+			},
+		},
+	}
+}
+
+// GetHasPermissionsBatches returns a slice of BatchTest for testing the HasPermissions method of the users.User struct.
+func GetHasPermissionsBatches(t *testing.T, userData *users.User) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// users created before roles existed have no role
+				userData.Role = ""
+				assert.Equal(t, users.ROLE_USER, userData.GetRole())
+				assert.False(t, userData.HasPermissions(users.PERMISSION_USERS_BAN))
+			},
+		},
+		{
+			OnRun: func() {
+				userData.Role = users.ROLE_MODERATOR
+				assert.True(t, userData.HasPermissions(users.PERMISSION_USERS_BAN))
+				assert.True(t, userData.HasPermissions(users.PERMISSION_USERS_BAN, users.PERMISSION_REPORTS_REVIEW))
+				assert.False(t, userData.HasPermissions(users.PERMISSION_USERS_BAN, users.PERMISSION_ROLES_MANAGE))
+			},
+		},
+		{
+			OnRun: func() {
+				userData.Role = users.ROLE_ADMIN
+				assert.True(t, userData.HasPermissions(users.PERMISSION_USERS_BAN, users.PERMISSION_USERS_UNLOCK, users.PERMISSION_REPORTS_REVIEW, users.PERMISSION_ROLES_MANAGE))
+				assert.False(t, userData.HasPermissions())
+				assert.False(t, userData.HasPermissions("unknown:permission"))
+			},
+		},
+		{
+			OnRun: func() {
+				userData.Role = "unknown"
+				assert.False(t, userData.HasPermissions(users.PERMISSION_USERS_BAN))
+			},
+		},
+	}
+}
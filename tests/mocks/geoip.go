@@ -0,0 +1,254 @@
+package mocks
+
+import (
+	"math"
+	"net"
+	"sort"
+)
+
+// MaxMindDBMock builds MaxMind DB files in memory, so GeoIP lookups can be tested without the real databases.
+// Repeated strings of records are written once and referenced with pointers, like the real databases do.
+type MaxMindDBMock struct {
+	IPVersion  int
+	RecordSize int
+
+	nodes   [][2]mmdbRecordMock
+	data    []byte
+	strings map[string]int
+}
+
+type mmdbRecordMock struct {
+	// kind is 0 for an empty record, 1 for a node and 2 for data.
+	kind  int
+	value int
+}
+
+// NewMaxMindDBMock creates an empty database of the given IP version (4 or 6) and record size (24, 28 or 32).
+func NewMaxMindDBMock(ipVersion, recordSize int) *MaxMindDBMock {
+	return &MaxMindDBMock{
+		IPVersion:  ipVersion,
+		RecordSize: recordSize,
+		nodes:      [][2]mmdbRecordMock{{}},
+		strings:    map[string]int{},
+	}
+}
+
+// Insert inserts the record of a network in CIDR notation.
+func (m *MaxMindDBMock) Insert(cidr string, record map[string]any) {
+	_, network, err := net.ParseCIDR(cidr)
+
+	if err != nil {
+		panic(err)
+	}
+
+	address := []byte(network.IP.To16())
+	prefix, _ := network.Mask.Size()
+
+	if network.IP.To4() != nil {
+		if m.IPVersion == 4 {
+			address = []byte(network.IP.To4())
+		} else {
+			address = append(make([]byte, 12), network.IP.To4()...)
+			prefix += 96
+		}
+	}
+
+	offset := len(m.data)
+	m.data = append(m.data, m.encode(record, true)...)
+
+	node := 0
+
+	for i := 0; i < prefix; i++ {
+		bit := (address[i>>3] >> (7 - uint(i&7))) & 1
+
+		if i == prefix-1 {
+			m.nodes[node][bit] = mmdbRecordMock{kind: 2, value: offset}
+			break
+		}
+
+		if m.nodes[node][bit].kind != 1 {
+			m.nodes = append(m.nodes, [2]mmdbRecordMock{})
+			m.nodes[node][bit] = mmdbRecordMock{kind: 1, value: len(m.nodes) - 1}
+		}
+
+		node = m.nodes[node][bit].value
+	}
+}
+
+// Bytes returns the database file.
+func (m *MaxMindDBMock) Bytes() []byte {
+	nodeCount := len(m.nodes)
+	buf := []byte{}
+
+	for _, node := range m.nodes {
+		values := [2]uint32{}
+
+		for bit, record := range node {
+			switch record.kind {
+			case 0:
+				values[bit] = uint32(nodeCount)
+			case 1:
+				values[bit] = uint32(record.value)
+			case 2:
+				values[bit] = uint32(nodeCount + 16 + record.value)
+			}
+		}
+
+		left, right := values[0], values[1]
+
+		switch m.RecordSize {
+		case 24:
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+		case 28:
+			middle := byte((left>>20)&0xf0) | byte((right>>24)&0x0f)
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), middle, byte(right>>16), byte(right>>8), byte(right))
+		default:
+			buf = append(buf, byte(left>>24), byte(left>>16), byte(left>>8), byte(left), byte(right>>24), byte(right>>16), byte(right>>8), byte(right))
+		}
+	}
+
+	buf = append(buf, make([]byte, 16)...)
+	buf = append(buf, m.data...)
+	buf = append(buf, []byte("\xab\xcd\xefMaxMind.com")...)
+
+	metadata := map[string]any{
+		"binary_format_major_version": uint64(2),
+		"binary_format_minor_version": uint64(0),
+		"build_epoch":                 uint64(1700000000),
+		"database_type":               "Quess-Test",
+		"ip_version":                  uint64(m.IPVersion),
+		"languages":                   []any{"en"},
+		"node_count":                  uint64(nodeCount),
+		"record_size":                 uint64(m.RecordSize),
+	}
+
+	return append(buf, m.encode(metadata, false)...)
+}
+
+// encode encodes a value of the data section. Strings already written are replaced by pointers when usePointers is true.
+func (m *MaxMindDBMock) encode(value any, usePointers bool) []byte {
+	switch v := value.(type) {
+	case string:
+		if offset, ok := m.strings[v]; ok && usePointers {
+			return encodePointerMock(offset)
+		}
+
+		if usePointers {
+			m.strings[v] = len(m.data)
+		}
+
+		return append(encodeControlMock(2, len(v)), v...)
+	case float64:
+		bits := math.Float64bits(v)
+		b := []byte{}
+
+		for i := 7; i >= 0; i-- {
+			b = append(b, byte(bits>>(uint(i)*8)))
+		}
+
+		return append(encodeControlMock(3, 8), b...)
+	case uint64:
+		b := []byte{}
+
+		for n := v; n > 0; n >>= 8 {
+			b = append([]byte{byte(n)}, b...)
+		}
+
+		if v > math.MaxUint32 {
+			return append(encodeControlMock(9, len(b)), b...)
+		}
+
+		return append(encodeControlMock(6, len(b)), b...)
+	case bool:
+		if v {
+			return encodeControlMock(14, 1)
+		}
+
+		return encodeControlMock(14, 0)
+	case []any:
+		b := encodeControlMock(11, len(v))
+
+		for _, item := range v {
+			b = append(b, m.encodeAt(b, item, usePointers)...)
+		}
+
+		return b
+	case map[string]any:
+		keys := []string{}
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		b := encodeControlMock(7, len(v))
+
+		for _, key := range keys {
+			b = append(b, m.encodeAt(b, key, usePointers)...)
+			b = append(b, m.encodeAt(b, v[key], usePointers)...)
+		}
+
+		return b
+	}
+
+	panic("unsupported type")
+}
+
+// encodeAt encodes a value that is written after the already encoded bytes b,
+// so the offsets of the strings it contains are known.
+func (m *MaxMindDBMock) encodeAt(b []byte, value any, usePointers bool) []byte {
+	if !usePointers {
+		return m.encode(value, false)
+	}
+
+	// the offsets of new strings are relative to the end of the data section, so it is extended temporarily
+	start := len(m.data)
+	m.data = append(m.data, b...)
+	encoded := m.encode(value, true)
+	m.data = m.data[:start]
+
+	return encoded
+}
+
+func encodeControlMock(dataType, size int) []byte {
+	sizeBytes := []byte{}
+	sizeBits := size
+
+	switch {
+	case size >= 65821:
+		sizeBits = 31
+		size -= 65821
+		sizeBytes = []byte{byte(size >> 16), byte(size >> 8), byte(size)}
+	case size >= 285:
+		sizeBits = 30
+		size -= 285
+		sizeBytes = []byte{byte(size >> 8), byte(size)}
+	case size >= 29:
+		sizeBits = 29
+		sizeBytes = []byte{byte(size - 29)}
+	}
+
+	if dataType > 7 {
+		return append([]byte{byte(sizeBits), byte(dataType - 7)}, sizeBytes...)
+	}
+
+	return append([]byte{byte(dataType<<5 | sizeBits)}, sizeBytes...)
+}
+
+func encodePointerMock(offset int) []byte {
+	switch {
+	case offset < 2048:
+		return []byte{byte(1<<5 | offset>>8), byte(offset)}
+	case offset < 526336:
+		v := offset - 2048
+
+		return []byte{byte(1<<5 | 1<<3 | v>>16), byte(v >> 8), byte(v)}
+	case offset < 134744064:
+		v := offset - 526336
+
+		return []byte{byte(1<<5 | 2<<3 | v>>24), byte(v >> 16), byte(v >> 8), byte(v)}
+	default:
+		return []byte{byte(1<<5 | 3<<3), byte(offset >> 24), byte(offset >> 16), byte(offset >> 8), byte(offset)}
+	}
+}
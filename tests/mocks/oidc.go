@@ -0,0 +1,148 @@
+package mocks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// OIDCProviderMock is a local OpenID Connect provider. It serves the discovery document, the JWKS and the token endpoint.
+// Codes are issued with Authorize, which plays the role of the user signing in on the provider.
+type OIDCProviderMock struct {
+	Server   *httptest.Server
+	ClientID string
+	Key      *rsa.PrivateKey
+	KeyID    string
+
+	mu    sync.Mutex
+	codes map[string]oidcCodeMock
+}
+
+type oidcCodeMock struct {
+	claims        jwt.MapClaims
+	codeChallenge string
+}
+
+// NewOIDCProviderMock starts a local OpenID Connect provider. It must be closed with Server.Close.
+func NewOIDCProviderMock(clientID string) *OIDCProviderMock {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		panic(err)
+	}
+
+	m := &OIDCProviderMock{
+		ClientID: clientID,
+		Key:      key,
+		KeyID:    "mock-key",
+		codes:    map[string]oidcCodeMock{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(oidc.DISCOVERY_PATH, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidc.Discovery{
+			Issuer:                m.Server.URL,
+			AuthorizationEndpoint: m.Server.URL + "/authorize",
+			TokenEndpoint:         m.Server.URL + "/token",
+			JWKSURI:               m.Server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidc.JWKS{Keys: []oidc.JWK{{
+			KeyType:   "RSA",
+			KeyID:     m.KeyID,
+			Use:       "sig",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(m.Key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.Key.E)).Bytes()),
+		}}})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		m.mu.Lock()
+		code, ok := m.codes[r.PostForm.Get("code")]
+		delete(m.codes, r.PostForm.Get("code"))
+		m.mu.Unlock()
+
+		if !ok || oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != code.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(oidc.TokenResponse{
+			AccessToken: "access-token",
+			TokenType:   "Bearer",
+			IDToken:     m.SignIDToken(code.claims),
+			ExpiresIn:   3600,
+		})
+	})
+
+	m.Server = httptest.NewServer(mux)
+
+	return m
+}
+
+// Config returns the provider configuration of the mock.
+func (m *OIDCProviderMock) Config(name string) oidc.ProviderConfig {
+	return oidc.ProviderConfig{
+		Name:         name,
+		Issuer:       m.Server.URL,
+		ClientID:     m.ClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:3000/callback",
+	}
+}
+
+// Claims returns valid ID token claims for the given subject and nonce.
+func (m *OIDCProviderMock) Claims(subject, nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            m.Server.URL,
+		"sub":            subject,
+		"aud":            m.ClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          subject + "@quess.app",
+		"email_verified": true,
+	}
+}
+
+// Authorize issues a code for the given claims and PKCE code challenge, like the provider does after the user signs in.
+func (m *OIDCProviderMock) Authorize(claims jwt.MapClaims, codeChallenge string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	code := base64.RawURLEncoding.EncodeToString(big.NewInt(time.Now().UnixNano()).Bytes())
+	m.codes[code] = oidcCodeMock{claims: claims, codeChallenge: codeChallenge}
+
+	return code
+}
+
+// SignIDToken signs the claims with the provider key.
+func (m *OIDCProviderMock) SignIDToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = m.KeyID
+
+	signed, err := token.SignedString(m.Key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return signed
+}
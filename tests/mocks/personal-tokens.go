@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"errors"
+	"strings"
+
+	personaltokens "github.com/quessapp/core-go/internal/personal-tokens"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+)
+
+// PersonalTokenVerifierMock verifies personal access tokens from memory, see configs.PersonalTokenVerifier.
+type PersonalTokenVerifierMock struct {
+	// Tokens maps each token to its owner and scopes.
+	Tokens map[string]PersonalTokenMock
+}
+
+// PersonalTokenMock is the owner and the scopes of a mocked personal access token.
+type PersonalTokenMock struct {
+	UserID string
+	Scopes []string
+}
+
+// IsPersonalToken returns true if the token has the personal access tokens prefix.
+func (v *PersonalTokenVerifierMock) IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, personaltokens.TOKEN_PREFIX)
+}
+
+// Verify returns the owner and the scopes of a known token.
+func (v *PersonalTokenVerifierMock) Verify(token, ip string) (string, []string, error) {
+	t, ok := v.Tokens[token]
+
+	if !ok {
+		return "", nil, errors.New(pkgErrors.PERSONAL_TOKEN_INVALID)
+	}
+
+	return t.UserID, t.Scopes, nil
+}
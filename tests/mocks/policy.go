@@ -0,0 +1,16 @@
+package mocks
+
+import "github.com/quessapp/core-go/internal/users"
+
+// PolicyMock checks permissions of users kept in memory, see configs.Policy.
+type PolicyMock struct {
+	// Users maps the ID of each user to the user.
+	Users map[string]users.User
+}
+
+// HasPermissions returns true if the role of a known user grants all the given permissions.
+func (p *PolicyMock) HasPermissions(userID string, permissions ...string) bool {
+	u, ok := p.Users[userID]
+
+	return ok && u.HasPermissions(permissions...)
+}
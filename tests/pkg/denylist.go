@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/denylist"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/redis/go-redis/v9"
+)
+
+// GetDenylistBatches returns a slice of BatchTest for testing the denylist with the given cache.
+func GetDenylistBatches(t *testing.T, cache *redis.Client) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				d := denylist.New(cache)
+
+				assert.False(t, d.IsRevoked("token", "user", time.Now()))
+
+				d.RevokeToken("token", time.Now().Add(time.Hour))
+
+				assert.True(t, d.IsRevoked("token", "user", time.Now()))
+				assert.False(t, d.IsRevoked("another", "user", time.Now()))
+				assert.False(t, d.IsRevoked("", "user", time.Now()))
+			},
+		},
+		{
+			OnRun: func() {
+				d := denylist.New(cache)
+
+				// tokens that already expired are not stored
+				d.RevokeToken("expired", time.Now().Add(-time.Second))
+				assert.False(t, d.IsRevoked("expired", "user", time.Now()))
+			},
+		},
+		{
+			OnRun: func() {
+				d := denylist.New(cache)
+				issuedAt := time.Now().Add(-time.Minute)
+				revokedAt := time.Now()
+
+				d.RevokeUserTokens("user", time.Hour)
+
+				assert.True(t, d.IsRevoked("token", "user", issuedAt))
+				assert.False(t, d.IsRevoked("token", "another", issuedAt))
+				// tokens issued after the watermark are valid, but not the ones issued in the same second
+				assert.False(t, d.IsRevoked("token", "user", time.Now().Add(time.Second)))
+				assert.True(t, d.IsRevoked("token", "user", revokedAt))
+			},
+		},
+		{
+			OnRun: func() {
+				d := denylist.New(cache)
+				d.RevokeToken("token", time.Now().Add(time.Hour))
+				d.RevokeUserTokens("user", time.Hour)
+
+				assert.True(t, d.IsClaimsRevoked(jwt.MapClaims{"jti": "token", "id": "another", "iat": float64(time.Now().Unix() + 1)}))
+				assert.True(t, d.IsClaimsRevoked(jwt.MapClaims{"jti": "another", "id": "user", "iat": float64(time.Now().Unix() - 60)}))
+				assert.False(t, d.IsClaimsRevoked(jwt.MapClaims{"jti": "another", "id": "user", "iat": float64(time.Now().Unix() + 1)}))
+				// tokens issued before the denylist have no "jti" nor "iat"
+				assert.True(t, d.IsClaimsRevoked(jwt.MapClaims{"id": "user"}))
+				assert.False(t, d.IsClaimsRevoked(jwt.MapClaims{"id": "another"}))
+			},
+		},
+	}
+}
@@ -0,0 +1,198 @@
+package pkg
+
+import (
+	"net"
+	"testing"
+
+	"github.com/quessapp/core-go/pkg/geoip"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCityDBMock creates a City database with a network in the US and another in Brazil.
+func newCityDBMock(ipVersion, recordSize int) []byte {
+	db := mocks.NewMaxMindDBMock(ipVersion, recordSize)
+
+	db.Insert("8.8.8.0/24", map[string]any{
+		"country": map[string]any{
+			"iso_code": "US",
+			"names":    map[string]any{"en": "United States", "pt-BR": "Estados Unidos"},
+		},
+		"city":     map[string]any{"names": map[string]any{"en": "Mountain View"}},
+		"postal":   map[string]any{"code": "94035"},
+		"location": map[string]any{"latitude": 37.386, "longitude": -122.0838},
+		"subdivisions": []any{
+			map[string]any{"iso_code": "CA", "names": map[string]any{"en": "California"}},
+		},
+	})
+	db.Insert("177.0.0.0/12", map[string]any{
+		"country": map[string]any{
+			"iso_code": "BR",
+			"names":    map[string]any{"en": "Brazil", "pt-BR": "Brasil"},
+		},
+		"city": map[string]any{"names": map[string]any{"en": "São Paulo"}},
+	})
+
+	if ipVersion == 6 {
+		db.Insert("2001:4860::/32", map[string]any{
+			"country": map[string]any{
+				"iso_code": "US",
+				"names":    map[string]any{"en": "United States"},
+			},
+		})
+	}
+
+	return db.Bytes()
+}
+
+// GetGeoIPReaderBatches returns a slice of BatchTest for testing the MaxMind DB reader with every record size and IP version.
+func GetGeoIPReaderBatches(t *testing.T) []tests.BatchTest {
+	batches := []tests.BatchTest{}
+
+	for _, ipVersion := range []int{4, 6} {
+		for _, recordSize := range []int{24, 28, 32} {
+			ipVersion, recordSize := ipVersion, recordSize
+
+			batches = append(batches, tests.BatchTest{
+				OnRun: func() {
+					r, err := geoip.NewReader(newCityDBMock(ipVersion, recordSize))
+
+					assert.NoError(t, err)
+					assert.Equal(t, uint(ipVersion), r.Metadata.IPVersion)
+					assert.Equal(t, uint(recordSize), r.Metadata.RecordSize)
+					assert.Equal(t, "Quess-Test", r.Metadata.DatabaseType)
+
+					record, prefix, err := r.Lookup(net.ParseIP("8.8.8.8"))
+
+					assert.NoError(t, err)
+					assert.Equal(t, 24, prefix)
+					assert.Equal(t, "US", record["country"].(map[string]any)["iso_code"])
+
+					record, prefix, err = r.Lookup(net.ParseIP("177.15.1.1"))
+
+					assert.NoError(t, err)
+					assert.Equal(t, 12, prefix)
+					assert.Equal(t, "BR", record["country"].(map[string]any)["iso_code"])
+
+					record, _, err = r.Lookup(net.ParseIP("1.1.1.1"))
+
+					assert.NoError(t, err)
+					assert.Nil(t, record)
+
+					record, _, err = r.Lookup(net.ParseIP("2001:4860:4860::8888"))
+
+					assert.NoError(t, err)
+
+					if ipVersion == 6 {
+						assert.NotNil(t, record)
+					} else {
+						assert.Nil(t, record)
+					}
+				},
+			})
+		}
+	}
+
+	batches = append(batches, tests.BatchTest{
+		OnRun: func() {
+			_, err := geoip.NewReader([]byte("not a database"))
+			assert.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+
+			// the metadata is found, but the search tree is truncated
+			b := newCityDBMock(4, 24)
+			_, err = geoip.NewReader(b[len(b)-300:])
+			assert.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+		},
+	})
+
+	return batches
+}
+
+// GetGeoIPResolverBatches returns a slice of BatchTest for testing the resolution of locations and autonomous systems.
+func GetGeoIPResolverBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				city, err := geoip.NewReader(newCityDBMock(6, 28))
+				assert.NoError(t, err)
+
+				r := geoip.NewResolver(city, nil)
+
+				location := r.Location("8.8.8.8")
+
+				assert.NotNil(t, location)
+				assert.Equal(t, "US", location.CountryCode)
+				assert.Equal(t, "United States", location.CountryName)
+				assert.Equal(t, "Mountain View", location.City)
+				assert.Equal(t, "California", location.State)
+				assert.Equal(t, "94035", location.Postal)
+				assert.Equal(t, 37.386, location.Latitude)
+				assert.Equal(t, -122.0838, location.Longitude)
+				assert.Equal(t, "8.8.8.8", location.IPv4)
+
+				location = r.Location("177.10.0.1")
+
+				assert.Equal(t, "Brazil", location.CountryName)
+				assert.Equal(t, "São Paulo", location.City)
+				assert.Empty(t, location.State)
+
+				assert.Nil(t, r.Location("1.1.1.1"))
+				assert.Nil(t, r.Location("invalid"))
+
+				// the ASN database is not loaded
+				assert.Nil(t, r.ASN("8.8.8.8"))
+			},
+		},
+		{
+			OnRun: func() {
+				db := mocks.NewMaxMindDBMock(6, 24)
+				db.Insert("8.8.8.0/24", map[string]any{
+					"autonomous_system_number":       uint64(15169),
+					"autonomous_system_organization": "GOOGLE",
+				})
+				db.Insert("2001:4860::/32", map[string]any{
+					"autonomous_system_number":       uint64(15169),
+					"autonomous_system_organization": "GOOGLE",
+				})
+
+				asn, err := geoip.NewReader(db.Bytes())
+				assert.NoError(t, err)
+
+				r := geoip.NewResolver(nil, asn)
+
+				a := r.ASN("8.8.8.8")
+
+				assert.NotNil(t, a)
+				assert.Equal(t, uint(15169), a.Number)
+				assert.Equal(t, "GOOGLE", a.Organization)
+				assert.Equal(t, "8.8.8.0/24", a.Network)
+
+				a = r.ASN("2001:4860:4860::8888")
+
+				assert.NotNil(t, a)
+				assert.Equal(t, "2001:4860::/32", a.Network)
+
+				assert.Nil(t, r.ASN("1.1.1.1"))
+				assert.Nil(t, r.Location("8.8.8.8"))
+			},
+		},
+		{
+			OnRun: func() {
+				// resolvers without databases, or nil, resolve nothing
+				var nilResolver *geoip.Resolver
+
+				assert.Nil(t, nilResolver.Location("8.8.8.8"))
+				assert.Nil(t, nilResolver.ASN("8.8.8.8"))
+
+				r, err := geoip.LoadResolver("", "")
+
+				assert.NoError(t, err)
+				assert.Nil(t, r.Location("8.8.8.8"))
+
+				_, err = geoip.LoadResolver("/does/not/exist.mmdb", "")
+				assert.Error(t, err)
+			},
+		},
+	}
+}
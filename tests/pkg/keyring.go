@@ -0,0 +1,245 @@
+package pkg
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// getKeyringClaims returns the claims of an user token that expires in one hour.
+func getKeyringClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"id":  "646df30ec5c3f0f0d6bb0b4d",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+// GetKeyringHS256Batches returns a slice of BatchTest for testing the keyring without keys, signing with the HS256 secret.
+func GetKeyringHS256Batches(t *testing.T) []tests.BatchTest {
+	kr := keyring.New("secret")
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				token, err := kr.Sign(getKeyringClaims())
+				assert.NoError(t, err)
+
+				parsed, err := kr.Parse(token, &jwt.MapClaims{})
+				assert.NoError(t, err)
+				assert.Equal(t, keyring.HS256, parsed.Method.Alg())
+				assert.NotContains(t, parsed.Header, "kid")
+			},
+		},
+		{
+			OnRun: func() {
+				// tokens issued before the keyring
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, getKeyringClaims()).SignedString([]byte("secret"))
+
+				_, err := kr.Parse(token, &jwt.MapClaims{})
+				assert.NoError(t, err)
+
+				token, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, getKeyringClaims()).SignedString([]byte("another"))
+
+				_, err = kr.Parse(token, &jwt.MapClaims{})
+				assert.Error(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Empty(t, kr.JWKS().Keys)
+
+				_, err := keyring.New("").Sign(getKeyringClaims())
+				assert.ErrorIs(t, err, keyring.ErrNoSigningKey)
+			},
+		},
+	}
+}
+
+// GetKeyringRotationBatches returns a slice of BatchTest for testing the keyring with asymmetric keys and their rotation.
+func GetKeyringRotationBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				for _, algorithm := range []string{keyring.RS256, keyring.EdDSA} {
+					key, err := keyring.GenerateKey(algorithm, time.Hour)
+					assert.NoError(t, err)
+
+					kr := keyring.New("")
+					kr.SetKeys([]*keyring.Key{key})
+
+					token, err := kr.Sign(getKeyringClaims())
+					assert.NoError(t, err)
+
+					parsed, err := kr.Parse(token, &jwt.MapClaims{})
+					assert.NoError(t, err)
+					assert.Equal(t, algorithm, parsed.Method.Alg())
+					assert.Equal(t, key.ID, parsed.Header["kid"])
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				oldKey, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				oldKey.CreatedAt = oldKey.CreatedAt.Add(-time.Minute)
+
+				kr := keyring.New("")
+				kr.SetKeys([]*keyring.Key{oldKey})
+
+				oldToken, _ := kr.Sign(getKeyringClaims())
+
+				newKey, _ := keyring.GenerateKey(keyring.EdDSA, time.Hour)
+				kr.SetKeys([]*keyring.Key{newKey, oldKey})
+
+				assert.Equal(t, newKey.ID, kr.SigningKey().ID)
+
+				// tokens signed by the previous key are still valid
+				_, err := kr.Parse(oldToken, &jwt.MapClaims{})
+				assert.NoError(t, err)
+
+				// until the key expires
+				oldKey.ExpiresAt = time.Now().Add(-time.Second)
+
+				_, err = kr.Parse(oldToken, &jwt.MapClaims{})
+				assert.Error(t, err)
+
+				kr.SetKeys([]*keyring.Key{newKey, oldKey})
+				assert.Len(t, kr.JWKS().Keys, 1)
+			},
+		},
+		{
+			OnRun: func() {
+				activeKey, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				activeKey.CreatedAt = activeKey.CreatedAt.Add(-time.Minute)
+
+				nextKey, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				nextKey.ActivatesAt = time.Now().Add(time.Minute)
+
+				kr := keyring.New("")
+				kr.SetKeys([]*keyring.Key{activeKey, nextKey})
+
+				// the next key is published but does not sign tokens yet
+				assert.Equal(t, activeKey.ID, kr.SigningKey().ID)
+				assert.Equal(t, nextKey.ID, kr.NextKey().ID)
+				assert.Len(t, kr.JWKS().Keys, 2)
+
+				// tokens signed by the next key on other instances are already valid
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, getKeyringClaims())
+				token.Header["kid"] = nextKey.ID
+				signed, _ := token.SignedString(nextKey.PrivateKey)
+
+				_, err := kr.Parse(signed, &jwt.MapClaims{})
+				assert.NoError(t, err)
+
+				// until it activates
+				nextKey.ActivatesAt = time.Now().Add(-time.Second)
+
+				assert.Equal(t, nextKey.ID, kr.SigningKey().ID)
+				assert.Nil(t, kr.NextKey())
+			},
+		},
+		{
+			OnRun: func() {
+				key, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				kr := keyring.New("secret")
+				kr.SetKeys([]*keyring.Key{key})
+
+				// unknown keys are rejected
+				another, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, getKeyringClaims())
+				token.Header["kid"] = another.ID
+				signed, _ := token.SignedString(another.PrivateKey)
+
+				_, err := kr.Parse(signed, &jwt.MapClaims{})
+				assert.Error(t, err)
+
+				// the public key can't be used as an HMAC secret
+				publicKey, _ := x509.MarshalPKIXPublicKey(key.PublicKey())
+				token = jwt.NewWithClaims(jwt.SigningMethodHS256, getKeyringClaims())
+				token.Header["kid"] = key.ID
+				signed, _ = token.SignedString(publicKey)
+
+				_, err = kr.Parse(signed, &jwt.MapClaims{})
+				assert.Error(t, err)
+
+				// tokens without a key ID still verify with the secret
+				signed, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, getKeyringClaims()).SignedString([]byte("secret"))
+
+				_, err = kr.Parse(signed, &jwt.MapClaims{})
+				assert.NoError(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				rsaKey, _ := keyring.GenerateKey(keyring.RS256, time.Hour)
+				edKey, _ := keyring.GenerateKey(keyring.EdDSA, time.Hour)
+
+				kr := keyring.New("secret")
+				kr.SetKeys([]*keyring.Key{rsaKey, edKey})
+
+				jwks := kr.JWKS()
+				assert.Len(t, jwks.Keys, 2)
+
+				for _, jwk := range jwks.Keys {
+					publicKey, err := jwk.PublicKey()
+					assert.NoError(t, err)
+					assert.Equal(t, keyring.KEY_USE, jwk.Use)
+
+					switch jwk.KeyID {
+					case rsaKey.ID:
+						assert.Equal(t, keyring.RS256, jwk.Algorithm)
+						assert.Equal(t, rsaKey.PublicKey(), publicKey)
+					case edKey.ID:
+						assert.Equal(t, keyring.EdDSA, jwk.Algorithm)
+						assert.Equal(t, edKey.PublicKey(), publicKey)
+					default:
+						t.Errorf("unexpected key %s", jwk.KeyID)
+					}
+				}
+			},
+		},
+	}
+}
+
+// GetKeyringPrivateKeyBatches returns a slice of BatchTest for testing MarshalPrivateKey and ParsePrivateKey.
+func GetKeyringPrivateKeyBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				for _, algorithm := range []string{keyring.RS256, keyring.EdDSA} {
+					key, _ := keyring.GenerateKey(algorithm, time.Hour)
+
+					encoded, err := keyring.MarshalPrivateKey(key)
+					assert.NoError(t, err)
+
+					privateKey, err := keyring.ParsePrivateKey(algorithm, encoded)
+					assert.NoError(t, err)
+					assert.Equal(t, key.PrivateKey, privateKey)
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				key, _ := keyring.GenerateKey(keyring.EdDSA, time.Hour)
+				encoded, _ := keyring.MarshalPrivateKey(key)
+
+				_, err := keyring.ParsePrivateKey(keyring.RS256, encoded)
+				assert.ErrorIs(t, err, keyring.ErrInvalidPrivateKey)
+
+				_, err = keyring.ParsePrivateKey(keyring.EdDSA, "invalid")
+				assert.ErrorIs(t, err, keyring.ErrInvalidPrivateKey)
+			},
+		},
+		{
+			OnRun: func() {
+				_, err := keyring.GenerateKey(keyring.HS256, time.Hour)
+				assert.ErrorIs(t, err, keyring.ErrUnsupportedAlgorithm)
+			},
+		},
+	}
+}
@@ -0,0 +1,293 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/media"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPNG returns a PNG image with the given dimensions.
+func newPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+
+	assert.Nil(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+
+	return buf.Bytes()
+}
+
+// newWAV returns a mono 8 kHz 16 bits WAV file with the given number of seconds of silence.
+func newWAV(seconds int) []byte {
+	var buf bytes.Buffer
+
+	dataSize := uint32(8000 * 2 * seconds)
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36)+dataSize)
+	buf.WriteString("WAVEfmt ")
+	// size of the chunk, PCM, channels, sample rate, byte rate, block align, bits per sample
+	for _, field := range []any{uint32(16), uint16(1), uint16(1), uint32(8000), uint32(16000), uint16(2), uint16(16)} {
+		binary.Write(&buf, binary.LittleEndian, field)
+	}
+
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, dataSize)
+	buf.Write(make([]byte, dataSize))
+
+	return buf.Bytes()
+}
+
+// newMP3 returns a MPEG 1 layer III file, 128 kbps at 44.1 kHz, with the given number of frames after an ID3v2 tag.
+func newMP3(frames int) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("ID3\x03\x00\x00\x00\x00\x00\x0a")
+	buf.Write(make([]byte, 10))
+
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+		buf.Write(frame)
+	}
+
+	// ID3v1 tag
+	buf.WriteString("TAG")
+	buf.Write(make([]byte, 125))
+
+	return buf.Bytes()
+}
+
+// newOggPage returns an Ogg page with a single packet.
+func newOggPage(granule uint64, packet []byte) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("OggS\x00\x00")
+	binary.Write(&buf, binary.LittleEndian, granule)
+	buf.Write(make([]byte, 12))
+	buf.WriteByte(1)
+	buf.WriteByte(byte(len(packet)))
+	buf.Write(packet)
+
+	return buf.Bytes()
+}
+
+// newOpus returns an Ogg Opus file with the given number of seconds after the pre-skip.
+func newOpus(seconds int) []byte {
+	preSkip := uint16(312)
+	head := make([]byte, 19)
+	copy(head, "OpusHead\x01\x01")
+	binary.LittleEndian.PutUint16(head[10:12], preSkip)
+	binary.LittleEndian.PutUint32(head[12:16], 48000)
+
+	data := newOggPage(0, head)
+	data = append(data, newOggPage(0, []byte("OpusTags"))...)
+	data = append(data, newOggPage(^uint64(0), make([]byte, 100))...)
+
+	return append(data, newOggPage(uint64(48000*seconds)+uint64(preSkip), make([]byte, 100))...)
+}
+
+// newBox returns an ISO base media box.
+func newBox(boxType string, content []byte) []byte {
+	box := make([]byte, 4, 8+len(content))
+	binary.BigEndian.PutUint32(box, uint32(8+len(content)))
+	box = append(box, boxType...)
+
+	return append(box, content...)
+}
+
+// newM4A returns a M4A file with the given duration in its movie header.
+func newM4A(seconds int) []byte {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:20], uint32(seconds*1000))
+
+	data := newBox("ftyp", []byte("M4A \x00\x00\x00\x00M4A "))
+	data = append(data, newBox("free", make([]byte, 8))...)
+
+	return append(data, newBox("moov", newBox("mvhd", mvhd))...)
+}
+
+// newJPEG returns a JPEG image with an EXIF segment and a comment, like the photos of phones.
+func newJPEG(t *testing.T) []byte {
+	var buf bytes.Buffer
+
+	assert.Nil(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 8)), nil))
+
+	exif := []byte("\xff\xe1\x00\x10Exif\x00\x00GPS-DATA")
+	comment := []byte("\xff\xfe\x00\x09comment")
+
+	data := append([]byte{}, buf.Bytes()[:2]...)
+	data = append(data, exif...)
+	data = append(data, comment...)
+
+	return append(data, buf.Bytes()[2:]...)
+}
+
+// newM4AWithDuration returns a M4A file with a version 1 movie header, which has a 64 bits duration.
+func newM4AWithDuration(duration uint64, timescale uint32) []byte {
+	mvhd := make([]byte, 112)
+	mvhd[0] = 1
+	binary.BigEndian.PutUint32(mvhd[20:24], timescale)
+	binary.BigEndian.PutUint64(mvhd[24:32], duration)
+
+	data := newBox("ftyp", []byte("M4A \x00\x00\x00\x00M4A "))
+
+	return append(data, newBox("moov", newBox("mvhd", mvhd))...)
+}
+
+// GetMediaSniffBatches returns a slice of BatchTest for testing the sniffing of the formats from the bytes.
+func GetMediaSniffBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				files := map[string][]byte{
+					"image/png":  newPNG(t, 1, 1),
+					"image/jpeg": {0xff, 0xd8, 0xff, 0xe0},
+					"image/gif":  []byte("GIF89a"),
+					"audio/wav":  newWAV(1),
+					"audio/mpeg": newMP3(1),
+					"audio/ogg":  newOpus(1),
+					"audio/mp4":  newM4A(1),
+				}
+
+				for mimeType, data := range files {
+					format, err := media.Sniff(data)
+
+					assert.Nil(t, err)
+					assert.Equal(t, mimeType, format.MimeType)
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				for _, data := range [][]byte{nil, []byte("<svg></svg>"), []byte("%PDF-1.4"), []byte("RIFF\x00\x00\x00\x00AVI ")} {
+					_, err := media.Sniff(data)
+
+					assert.Equal(t, pkgErrors.FILE_TYPE_INVALID, err.Error())
+				}
+			},
+		},
+	}
+}
+
+// GetMediaProbeBatches returns a slice of BatchTest for testing the dimensions of images and the duration of audio files.
+func GetMediaProbeBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				info, err := media.Probe(newPNG(t, 64, 32))
+
+				assert.Nil(t, err)
+				assert.Equal(t, media.KIND_IMAGE, info.Kind)
+				assert.Equal(t, "png", info.Extension)
+				assert.Equal(t, 64, info.Width)
+				assert.Equal(t, 32, info.Height)
+			},
+		},
+		{
+			OnRun: func() {
+				durations := map[string]time.Duration{}
+
+				for name, data := range map[string][]byte{"wav": newWAV(2), "ogg": newOpus(3), "m4a": newM4A(5), "mp3": newMP3(100)} {
+					info, err := media.Probe(data)
+
+					assert.Nil(t, err)
+					assert.Equal(t, media.KIND_AUDIO, info.Kind)
+					assert.Equal(t, name, info.Extension)
+
+					durations[name] = info.Duration
+				}
+
+				assert.Equal(t, 2*time.Second, durations["wav"])
+				assert.Equal(t, 3*time.Second, durations["ogg"])
+				assert.Equal(t, 5*time.Second, durations["m4a"])
+				// 100 frames of 1152 samples at 44.1 kHz
+				assert.Equal(t, 2612, int(durations["mp3"].Milliseconds()))
+			},
+		},
+		{
+			OnRun: func() {
+				png := newPNG(t, 10, 10)
+				wav := newWAV(1)
+
+				for _, data := range [][]byte{png[:20], wav[:30], newOpus(0), newM4A(0), []byte("ID3")} {
+					_, err := media.Probe(data)
+
+					assert.Equal(t, pkgErrors.MEDIA_CORRUPTED, err.Error())
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				// durations read from the headers that overflow are refused, instead of wrapping to a short duration
+				for _, data := range [][]byte{newM4AWithDuration(1<<63, 1), newM4AWithDuration(^uint64(0), 1000), newM4AWithDuration(20_000_000_000, 1)} {
+					_, err := media.Probe(data)
+
+					assert.Equal(t, pkgErrors.MEDIA_CORRUPTED, err.Error())
+				}
+
+				info, err := media.Probe(newM4AWithDuration(20_000_000_000, 1_000_000))
+
+				assert.Nil(t, err)
+				assert.Equal(t, 20_000*time.Second, info.Duration)
+			},
+		},
+	}
+}
+
+// GetMediaMetadataBatches returns a slice of BatchTest for testing the removal of the metadata of images.
+func GetMediaMetadataBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				stripped, err := media.StripMetadata(newJPEG(t))
+
+				assert.Nil(t, err)
+				assert.NotContains(t, string(stripped), "GPS-DATA")
+				assert.NotContains(t, string(stripped), "comment")
+
+				info, err := media.Probe(stripped)
+
+				assert.Nil(t, err)
+				assert.Equal(t, 16, info.Width)
+			},
+		},
+		{
+			OnRun: func() {
+				img := newPNG(t, 4, 4)
+				text := newBox("tEXt", []byte("Location\x00here"))
+				// the CRC is not checked
+				text = append(text, 0, 0, 0, 0)
+				binary.BigEndian.PutUint32(text, uint32(len("Location\x00here")))
+
+				// after the IHDR chunk
+				data := append(append(append([]byte{}, img[:33]...), text...), img[33:]...)
+				stripped, err := media.StripMetadata(data)
+
+				assert.Nil(t, err)
+				assert.Equal(t, img, stripped)
+			},
+		},
+		{
+			OnRun: func() {
+				wav := newWAV(1)
+				stripped, err := media.StripMetadata(wav)
+
+				assert.Nil(t, err)
+				assert.Equal(t, wav, stripped)
+
+				_, err = media.StripMetadata(newJPEG(t)[:10])
+
+				assert.Equal(t, pkgErrors.MEDIA_CORRUPTED, err.Error())
+			},
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/nicks"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetNickNormalizeBatches returns a slice of BatchTest for testing the normalisation and the skeletons of nicks.
+func GetNickNormalizeBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Equal(t, "foobaruser", nicks.Normalize("FOOBAR_USER"))
+				assert.Equal(t, "quess", nicks.Normalize("Ｑｕｅｓｓ"))
+				assert.Equal(t, "josé", nicks.Normalize("José"))
+				assert.Equal(t, "мария", nicks.Normalize("Мария!"))
+				assert.Equal(t, "たなか", nicks.Normalize("たなか"))
+			},
+		},
+		{
+			OnRun: func() {
+				// Cyrillic "е"
+				assert.Equal(t, nicks.Skeleton("quess"), nicks.Skeleton("quеss"))
+				assert.Equal(t, nicks.Skeleton("mary"), nicks.Skeleton("rnary"))
+				assert.Equal(t, nicks.Skeleton("jose"), nicks.Skeleton("josé"))
+				assert.Equal(t, nicks.Skeleton("bob"), nicks.Skeleton("b0b"))
+				assert.NotEqual(t, nicks.Skeleton("quess"), nicks.Skeleton("guess"))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.True(t, nicks.IsSingleScript("quess"))
+				assert.True(t, nicks.IsSingleScript("мария"))
+				assert.True(t, nicks.IsSingleScript("tanaka田中たなか"))
+				assert.False(t, nicks.IsSingleScript("quеss"))
+			},
+		},
+	}
+}
+
+// GetNickPolicyBatches returns a slice of BatchTest for testing the reserved nicks and the suggestions.
+func GetNickPolicyBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				policy := nicks.NewPolicy("quessbot")
+
+				for _, nick := range []string{"admin", "adm1n", "4dmin", "admin2024", "аdmin", "quessbot", "qu3ssb0t"} {
+					assert.True(t, policy.IsReserved(nick), nick)
+				}
+
+				for _, nick := range []string{"adrian", "administrative", "quesser"} {
+					assert.False(t, policy.IsReserved(nick), nick)
+				}
+
+				assert.ErrorContains(t, policy.Validate("adm1n"), pkgErrors.NICK_RESERVED)
+				assert.ErrorContains(t, policy.Validate("quеss"), pkgErrors.NICK_MIXED_SCRIPTS)
+				assert.NoError(t, policy.Validate("quesser"))
+
+				// a nil policy reserves the default nicks
+				var nilPolicy *nicks.Policy
+				assert.True(t, nilPolicy.IsReserved("support"))
+			},
+		},
+		{
+			OnRun: func() {
+				reserved, err := nicks.LoadReserved(strings.NewReader("# staff\nceo\n\n  founder \n"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ceo", "founder"}, reserved)
+			},
+		},
+		{
+			OnRun: func() {
+				policy := nicks.NewPolicy()
+
+				suggestions := policy.Suggest("quesser", func(nick string) bool { return true })
+				assert.Len(t, suggestions, nicks.SUGGESTIONS_COUNT)
+
+				for _, suggestion := range suggestions {
+					assert.True(t, strings.HasPrefix(suggestion, "quesser"))
+					assert.NoError(t, policy.Validate(suggestion))
+				}
+
+				// reserved nicks can't be suggested with a number at the end
+				for _, suggestion := range policy.Suggest("admin", func(nick string) bool { return true }) {
+					assert.False(t, policy.IsReserved(suggestion), suggestion)
+				}
+
+				assert.Empty(t, policy.Suggest("quesser", func(nick string) bool { return false }))
+			},
+		},
+	}
+}
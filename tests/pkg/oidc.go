@@ -0,0 +1,171 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/oidc"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/tests/mocks"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GetOIDCAuthorizationCodeFlowBatches returns a slice of BatchTest for testing the authorization code flow against a mock provider.
+func GetOIDCAuthorizationCodeFlowBatches(t *testing.T, mock *mocks.OIDCProviderMock, registry *oidc.Registry) []tests.BatchTest {
+	provider, _ := registry.Get("mock")
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				_, err := registry.Get("unknown")
+				assert.ErrorIs(t, err, oidc.ErrProviderNotFound)
+				assert.Equal(t, []string{"mock"}, registry.Names())
+			},
+		},
+		{
+			OnRun: func() {
+				verifier, _ := oidc.GenerateRandomValue()
+				state, _ := oidc.GenerateRandomValue()
+				nonce, _ := oidc.GenerateRandomValue()
+
+				authCodeURL, err := provider.AuthCodeURL(state, nonce, oidc.CodeChallenge(verifier))
+				assert.NoError(t, err)
+
+				parsed, err := url.Parse(authCodeURL)
+				assert.NoError(t, err)
+
+				query := parsed.Query()
+				assert.Equal(t, mock.Server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+				assert.Equal(t, "code", query.Get("response_type"))
+				assert.Equal(t, mock.ClientID, query.Get("client_id"))
+				assert.Equal(t, state, query.Get("state"))
+				assert.Equal(t, nonce, query.Get("nonce"))
+				assert.Equal(t, "S256", query.Get("code_challenge_method"))
+				assert.Equal(t, "openid email profile", query.Get("scope"))
+
+				code := mock.Authorize(mock.Claims("subject", nonce), query.Get("code_challenge"))
+
+				tokens, err := provider.Exchange(code, verifier)
+				assert.NoError(t, err)
+
+				claims, err := provider.VerifyIDToken(tokens.IDToken, nonce)
+				assert.NoError(t, err)
+				assert.Equal(t, "subject", claims.Subject)
+				assert.Equal(t, "subject@quess.app", claims.Email)
+				assert.True(t, claims.EmailVerified)
+
+				// codes can't be used twice
+				_, err = provider.Exchange(code, verifier)
+				assert.Error(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				verifier, _ := oidc.GenerateRandomValue()
+				code := mock.Authorize(mock.Claims("subject", "nonce"), oidc.CodeChallenge(verifier))
+
+				// the code verifier must match the code challenge
+				_, err := provider.Exchange(code, verifier+"a")
+				assert.Error(t, err)
+			},
+		},
+	}
+}
+
+// GetOIDCVerifyIDTokenBatches returns a slice of BatchTest for testing VerifyIDToken.
+func GetOIDCVerifyIDTokenBatches(t *testing.T, mock *mocks.OIDCProviderMock, registry *oidc.Registry) []tests.BatchTest {
+	provider, _ := registry.Get("mock")
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				_, err := provider.VerifyIDToken(mock.SignIDToken(mock.Claims("subject", "nonce")), "another-nonce")
+				assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+			},
+		},
+		{
+			OnRun: func() {
+				claims := mock.Claims("subject", "nonce")
+				claims["aud"] = "another-client"
+
+				_, err := provider.VerifyIDToken(mock.SignIDToken(claims), "nonce")
+				assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+
+				// with multiple audiences, the authorized party must be the client
+				claims["aud"] = []string{mock.ClientID, "another-client"}
+
+				_, err = provider.VerifyIDToken(mock.SignIDToken(claims), "nonce")
+				assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+
+				claims["azp"] = mock.ClientID
+
+				_, err = provider.VerifyIDToken(mock.SignIDToken(claims), "nonce")
+				assert.NoError(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				claims := mock.Claims("subject", "nonce")
+				claims["iss"] = "https://another-issuer.com"
+
+				_, err := provider.VerifyIDToken(mock.SignIDToken(claims), "nonce")
+				assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+			},
+		},
+		{
+			OnRun: func() {
+				claims := mock.Claims("subject", "nonce")
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+				_, err := provider.VerifyIDToken(mock.SignIDToken(claims), "nonce")
+				assert.Error(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				// tokens signed by another key are rejected
+				key, _ := rsa.GenerateKey(rand.Reader, 2048)
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, mock.Claims("subject", "nonce"))
+				token.Header["kid"] = mock.KeyID
+				signed, _ := token.SignedString(key)
+
+				_, err := provider.VerifyIDToken(signed, "nonce")
+				assert.Error(t, err)
+
+				// symmetric algorithms are never accepted
+				signed, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, mock.Claims("subject", "nonce")).SignedString([]byte(mock.ClientID))
+
+				_, err = provider.VerifyIDToken(signed, "nonce")
+				assert.Error(t, err)
+			},
+		},
+	}
+}
+
+// GetOIDCParseProvidersBatches returns a slice of BatchTest for testing ParseProviders.
+func GetOIDCParseProvidersBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				providers, err := oidc.ParseProviders("")
+				assert.NoError(t, err)
+				assert.Empty(t, providers)
+
+				providers, err = oidc.ParseProviders(`[{"name":"google","issuer":"https://accounts.google.com","clientId":"id","redirectUrl":"http://localhost"}]`)
+				assert.NoError(t, err)
+				assert.Equal(t, "google", providers[0].Name)
+
+				_, err = oidc.ParseProviders(`[{"name":"google"}]`)
+				assert.Error(t, err)
+
+				_, err = oidc.ParseProviders(`{`)
+				assert.Error(t, err)
+			},
+		},
+	}
+}
@@ -0,0 +1,206 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/pagination"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const paginationKey = "pagination-test-key"
+
+var paginationScope = pagination.Scope("questions:sent", primitive.NewObjectID().Hex())
+
+// newPositions returns n positions ordered by creation date, one second apart.
+func newPositions(n int) []pagination.Position {
+	createdAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	positions := []pagination.Position{}
+
+	for i := 0; i < n; i++ {
+		positions = append(positions, pagination.Position{
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+			ID:        primitive.NewObjectID(),
+		})
+	}
+
+	return positions
+}
+
+func positionOf(p pagination.Position) pagination.Position {
+	return p
+}
+
+// GetPaginationParamsBatches returns a slice of BatchTest for testing the parsing of the pagination query.
+func GetPaginationParamsBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				params, err := pagination.ParseParams(paginationKey, paginationScope, "", "", "", "")
+
+				assert.Nil(t, err)
+				assert.Equal(t, pagination.DEFAULT_LIMIT, params.Limit)
+				assert.Equal(t, pagination.SORT_ASC, params.Sort)
+				assert.False(t, params.WithTotal)
+				assert.Equal(t, pagination.DEFAULT_LIMIT+1, *params.FindOptions().Limit)
+			},
+		},
+		{
+			OnRun: func() {
+				params, err := pagination.ParseParams(paginationKey, paginationScope, "50", "", pagination.SORT_DESC, "true")
+
+				assert.Nil(t, err)
+				assert.Equal(t, int64(50), params.Limit)
+				assert.Equal(t, pagination.SORT_DESC, params.Sort)
+				assert.True(t, params.WithTotal)
+			},
+		},
+		{
+			OnRun: func() {
+				for _, limit := range []string{"0", "101", "-1", "ten"} {
+					_, err := pagination.ParseParams(paginationKey, paginationScope, limit, "", "", "")
+
+					assert.Equal(t, pkgErrors.PAGINATION_LIMIT_INVALID, err.Error())
+				}
+
+				_, err := pagination.ParseParams(paginationKey, paginationScope, "", "", "", "maybe")
+
+				assert.Equal(t, pkgErrors.PAGINATION_TOTAL_INVALID, err.Error())
+			},
+		},
+		{
+			OnRun: func() {
+				for _, cursor := range []string{"foo", "foo.bar", "."} {
+					_, err := pagination.ParseParams(paginationKey, paginationScope, "", cursor, "", "")
+
+					assert.Equal(t, pkgErrors.PAGINATION_CURSOR_INVALID, err.Error())
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				// cursors signed with another key are refused
+				params, _ := pagination.ParseParams("another-key", paginationScope, "2", "", "", "")
+				_, page := pagination.NewPage(params, newPositions(3), positionOf)
+
+				_, err := pagination.ParseParams(paginationKey, paginationScope, "2", page.NextCursor, "", "")
+
+				assert.Equal(t, pkgErrors.PAGINATION_CURSOR_INVALID, err.Error())
+			},
+		},
+		{
+			OnRun: func() {
+				// cursors of another listing, or of the same listing of another user, are refused
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", "", "")
+				_, page := pagination.NewPage(params, newPositions(3), positionOf)
+
+				for _, scope := range []string{pagination.Scope("questions:received", primitive.NewObjectID().Hex()), pagination.Scope("questions:sent", primitive.NewObjectID().Hex())} {
+					_, err := pagination.ParseParams(paginationKey, scope, "2", page.NextCursor, "", "")
+
+					assert.Equal(t, pkgErrors.PAGINATION_CURSOR_INVALID, err.Error())
+				}
+
+				_, err := pagination.ParseParams(paginationKey, paginationScope, "2", page.NextCursor, "", "")
+
+				assert.Nil(t, err)
+			},
+		},
+	}
+}
+
+// GetPaginationPageBatches returns a slice of BatchTest for testing the cursors of the pages.
+func GetPaginationPageBatches(t *testing.T) []tests.BatchTest {
+	positions := newPositions(5)
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// first page: one more document than the limit was fetched
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", "", "")
+				documents, page := pagination.NewPage(params, positions[:3], positionOf)
+
+				assert.Equal(t, positions[:2], documents)
+				assert.NotEmpty(t, page.NextCursor)
+				assert.Empty(t, page.PrevCursor)
+				assert.Nil(t, page.TotalCount)
+				assert.Equal(t, params.Filter(bson.D{}), bson.D{})
+			},
+		},
+		{
+			OnRun: func() {
+				// the next page keeps the sort of the cursor, and is filtered after it
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", pagination.SORT_DESC, "")
+				_, page := pagination.NewPage(params, positions[:3], positionOf)
+
+				params, err := pagination.ParseParams(paginationKey, paginationScope, "2", page.NextCursor, pagination.SORT_ASC, "")
+
+				assert.Nil(t, err)
+				assert.Equal(t, pagination.SORT_DESC, params.Sort)
+
+				filter := params.Filter(bson.D{{Key: "sendTo", Value: "foo"}})
+
+				// the filter is wrapped, so its own $or is not overwritten by the one of the cursor
+				assert.Len(t, filter, 1)
+				assert.Equal(t, "$and", filter[0].Key)
+				assert.Equal(t, bson.D{{Key: "sendTo", Value: "foo"}}, filter[0].Value.(bson.A)[1])
+				assert.Equal(t, bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}, params.FindOptions().Sort)
+			},
+		},
+		{
+			OnRun: func() {
+				// last page: no next cursor, the previous cursor goes back
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", "", "")
+				_, page := pagination.NewPage(params, positions[:3], positionOf)
+
+				params, _ = pagination.ParseParams(paginationKey, paginationScope, "2", page.NextCursor, "", "")
+				documents, page := pagination.NewPage(params, positions[2:4], positionOf)
+
+				assert.Equal(t, positions[2:4], documents)
+				assert.Empty(t, page.NextCursor)
+				assert.NotEmpty(t, page.PrevCursor)
+
+				// backward pages are queried in the reverse order, and put back in the order of the listing
+				params, err := pagination.ParseParams(paginationKey, paginationScope, "2", page.PrevCursor, "", "")
+
+				assert.Nil(t, err)
+				assert.Equal(t, bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}, params.FindOptions().Sort)
+
+				documents, page = pagination.NewPage(params, []pagination.Position{positions[1], positions[0]}, positionOf)
+
+				assert.Equal(t, positions[:2], documents)
+				assert.NotEmpty(t, page.NextCursor)
+				assert.Empty(t, page.PrevCursor)
+			},
+		},
+		{
+			OnRun: func() {
+				// listings ordered by a key keep the key of the position on the cursor
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", "", "")
+				_, page := pagination.NewPage(params, []pagination.Position{{ID: primitive.NewObjectID(), Key: "a"}, {ID: primitive.NewObjectID(), Key: "b"}, {ID: primitive.NewObjectID(), Key: "c"}}, positionOf)
+
+				params, err := pagination.ParseParams(paginationKey, paginationScope, "2", page.NextCursor, "", "")
+
+				assert.Nil(t, err)
+				assert.Equal(t, bson.D{{Key: "nick", Value: 1}, {Key: "_id", Value: 1}}, params.FindOptionsByKey("nick").Sort)
+
+				after := params.FilterByKey("nick", bson.D{})[0].Value.(bson.A)[0].(bson.D)[0].Value.(bson.A)[0]
+
+				assert.Equal(t, bson.D{{Key: "nick", Value: bson.D{{Key: "$gt", Value: "b"}}}}, after)
+			},
+		},
+		{
+			OnRun: func() {
+				params, _ := pagination.ParseParams(paginationKey, paginationScope, "2", "", "", "")
+				documents, page := pagination.NewPage(params, []pagination.Position{}, positionOf)
+
+				assert.Empty(t, documents)
+				assert.Empty(t, page.NextCursor)
+				assert.Empty(t, page.PrevCursor)
+			},
+		},
+	}
+}
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/passwords"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// getSHA1 returns the uppercase SHA-1 hash of a password, like the breached passwords files.
+func getSHA1(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// GetPasswordEntropyBatches returns a slice of BatchTest for testing the entropy estimator.
+func GetPasswordEntropyBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Zero(t, passwords.EstimateEntropy(""))
+			},
+		},
+		{
+			OnRun: func() {
+				// repeated and sequential characters add a single bit each
+				assert.Less(t, passwords.EstimateEntropy("aaaaaaaaaaaa"), 20.0)
+				assert.Less(t, passwords.EstimateEntropy("abcdefghijkl"), 20.0)
+				assert.Less(t, passwords.EstimateEntropy("12345678"), 20.0)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Less(t, passwords.EstimateEntropy("password"), 36.0)
+				assert.Greater(t, passwords.EstimateEntropy("Tr0ub4dor&3"), 60.0)
+			},
+		},
+		{
+			OnRun: func() {
+				// more character classes and more characters mean more entropy
+				assert.Greater(t, passwords.EstimateEntropy("Password"), passwords.EstimateEntropy("password"))
+				assert.Greater(t, passwords.EstimateEntropy("horse battery staple"), passwords.EstimateEntropy("horse battery"))
+			},
+		},
+	}
+}
+
+// GetPasswordPolicyBatches returns a slice of BatchTest for testing the password policy and its rules.
+func GetPasswordPolicyBatches(t *testing.T) []tests.BatchTest {
+	corpus, _ := passwords.LoadCorpus(strings.NewReader(getSHA1("correct horse battery staple") + ":3861493\n"))
+
+	policy := passwords.NewPolicy(
+		passwords.LengthRule{Min: 8},
+		passwords.EntropyRule{MinBits: 36},
+		passwords.PersonalInfoRule{},
+		passwords.BreachedRule{Source: corpus},
+	)
+
+	info := passwords.UserInfo{Nick: "johndoe", Name: "John Doe", Email: "john.doe@quess.app"}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.NoError(t, policy.Validate("Tr0ub4dor&3", info))
+				assert.NoError(t, policy.Validate("purple monkey dishwasher", info))
+			},
+		},
+		{
+			OnRun: func() {
+				err := policy.Validate("aaa", info)
+
+				var policyErr *passwords.ValidationError
+				assert.ErrorAs(t, err, &policyErr)
+				assert.Equal(t, []string{pkgErrors.PASSWORD_TOO_SHORT, pkgErrors.PASSWORD_TOO_WEAK}, policyErr.Keys)
+				assert.EqualError(t, err, pkgErrors.PASSWORD_TOO_SHORT)
+			},
+		},
+		{
+			OnRun: func() {
+				for _, password := range []string{"JohnDoe!2023#x", "xX-john-Xx-2023!", "Doe's-secret-992", "j0hn.doe@quess.app!"} {
+					err := policy.Validate(password, info)
+
+					var policyErr *passwords.ValidationError
+					assert.ErrorAs(t, err, &policyErr, password)
+					assert.Equal(t, []string{pkgErrors.PASSWORD_CONTAINS_PERSONAL_INFO}, policyErr.Keys, password)
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				// parts shorter than PERSONAL_INFO_MIN_LENGTH are not checked
+				assert.NoError(t, policy.Validate("Tr0ub4dor&3-al", passwords.UserInfo{Nick: "al", Name: "Al", Email: "al@quess.app"}))
+			},
+		},
+		{
+			OnRun: func() {
+				err := policy.Validate("correct horse battery staple", info)
+				assert.EqualError(t, err, pkgErrors.PASSWORD_BREACHED)
+			},
+		},
+		{
+			OnRun: func() {
+				err := policy.Validate("johndoe", info)
+
+				var policyErr *passwords.ValidationError
+				assert.ErrorAs(t, err, &policyErr)
+				assert.Equal(t, []string{pkgErrors.PASSWORD_TOO_SHORT, pkgErrors.PASSWORD_TOO_WEAK, pkgErrors.PASSWORD_CONTAINS_PERSONAL_INFO}, policyErr.Keys)
+			},
+		},
+	}
+}
+
+// GetBreachedCorpusBatches returns a slice of BatchTest for testing the breached passwords corpus.
+func GetBreachedCorpusBatches(t *testing.T) []tests.BatchTest {
+	hash := getSHA1("password")
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				corpus, err := passwords.LoadCorpus(strings.NewReader(strings.Join([]string{
+					hash + ":9545824",
+					strings.ToLower(getSHA1("123456")),
+					"invalid line",
+					"",
+				}, "\r\n")))
+				assert.NoError(t, err)
+
+				assert.True(t, passwords.IsBreached(corpus, "password"))
+				assert.True(t, passwords.IsBreached(corpus, "123456"))
+				assert.False(t, passwords.IsBreached(corpus, "Password"))
+				assert.Equal(t, map[string]int{hash[passwords.HASH_PREFIX_LENGTH:]: 9545824}, corpus.Range(hash[:passwords.HASH_PREFIX_LENGTH]))
+			},
+		},
+		{
+			OnRun: func() {
+				_, err := passwords.LoadCorpusFile("does-not-exist.txt")
+				assert.Error(t, err)
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/pkg/oidc"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/tests/mocks"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestTOTP(t *testing.T) {
+	tests.RunBatchTests(GetTOTPGenerateCodeBatches(t))
+	tests.RunBatchTests(GetTOTPValidateBatches(t))
+	tests.RunBatchTests(GetTOTPKeyURIBatches(t))
+}
+
+func TestOIDC(t *testing.T) {
+	mock := mocks.NewOIDCProviderMock("quess")
+	defer mock.Server.Close()
+
+	registry := oidc.NewRegistry([]oidc.ProviderConfig{mock.Config("mock")}, nil)
+
+	tests.RunBatchTests(GetOIDCAuthorizationCodeFlowBatches(t, mock, registry))
+	tests.RunBatchTests(GetOIDCVerifyIDTokenBatches(t, mock, registry))
+	tests.RunBatchTests(GetOIDCParseProvidersBatches(t))
+}
+
+func TestPasswords(t *testing.T) {
+	tests.RunBatchTests(GetPasswordEntropyBatches(t))
+	tests.RunBatchTests(GetPasswordPolicyBatches(t))
+	tests.RunBatchTests(GetBreachedCorpusBatches(t))
+}
+
+func TestKeyring(t *testing.T) {
+	tests.RunBatchTests(GetKeyringHS256Batches(t))
+	tests.RunBatchTests(GetKeyringRotationBatches(t))
+	tests.RunBatchTests(GetKeyringPrivateKeyBatches(t))
+}
+
+func TestDenylist(t *testing.T) {
+	tests.RunBatchTests(GetDenylistBatches(t, nil))
+
+	// the in-memory entries are used when the cache is not available
+	unavailable := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer unavailable.Close()
+
+	tests.RunBatchTests(GetDenylistBatches(t, unavailable))
+}
+
+func TestGeoIP(t *testing.T) {
+	tests.RunBatchTests(GetGeoIPReaderBatches(t))
+	tests.RunBatchTests(GetGeoIPResolverBatches(t))
+}
+
+func TestNicks(t *testing.T) {
+	tests.RunBatchTests(GetNickNormalizeBatches(t))
+	tests.RunBatchTests(GetNickPolicyBatches(t))
+}
+
+func TestPagination(t *testing.T) {
+	tests.RunBatchTests(GetPaginationParamsBatches(t))
+	tests.RunBatchTests(GetPaginationPageBatches(t))
+}
+
+func TestMedia(t *testing.T) {
+	tests.RunBatchTests(GetMediaSniffBatches(t))
+	tests.RunBatchTests(GetMediaProbeBatches(t))
+	tests.RunBatchTests(GetMediaMetadataBatches(t))
+}
+
+func TestSearch(t *testing.T) {
+	tests.RunBatchTests(GetSearchTermsBatches(t))
+	tests.RunBatchTests(GetSearchHighlightBatches(t))
+}
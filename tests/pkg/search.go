@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quessapp/core-go/pkg/search"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetSearchTermsBatches returns a slice of BatchTest for testing the languages and the stems of the search queries.
+func GetSearchTermsBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Equal(t, search.LANGUAGE_PORTUGUESE, search.Language("pt-BR"))
+				assert.Equal(t, search.LANGUAGE_SPANISH, search.Language("es-ES"))
+				assert.Equal(t, search.LANGUAGE_ENGLISH, search.Language("en-US"))
+				assert.Equal(t, search.DEFAULT_LANGUAGE, search.Language(""))
+			},
+		},
+		{
+			OnRun: func() {
+				for _, word := range []string{"Replying", "replies", "replied", "reply"} {
+					assert.Equal(t, "repli", search.Stem(word, search.LANGUAGE_ENGLISH))
+				}
+
+				assert.Equal(t, "pergunt", search.Stem("Perguntas", search.LANGUAGE_PORTUGUESE))
+				assert.Equal(t, "pergunt", search.Stem("pergunta", search.LANGUAGE_PORTUGUESE))
+				assert.Equal(t, "informa", search.Stem("Información", search.LANGUAGE_SPANISH))
+				// short words are kept whole
+				assert.Equal(t, "gas", search.Stem("gas", search.LANGUAGE_ENGLISH))
+			},
+		},
+		{
+			OnRun: func() {
+				terms := search.Terms(`"favorite movies" -horror Movie`, search.LANGUAGE_ENGLISH)
+
+				assert.Equal(t, []string{"favorit", "movi"}, terms)
+			},
+		},
+	}
+}
+
+// GetSearchHighlightBatches returns a slice of BatchTest for testing the snippets of the search results.
+func GetSearchHighlightBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				terms := search.Terms("ação", search.LANGUAGE_PORTUGUESE)
+				snippet := search.Highlight("Qual é a sua Ação favorita?", terms, search.LANGUAGE_PORTUGUESE)
+
+				assert.NotNil(t, snippet)
+				assert.Equal(t, "Qual é a sua Ação favorita?", snippet.Text)
+				assert.Equal(t, []search.Range{{Start: 13, End: 17}}, snippet.Highlights)
+			},
+		},
+		{
+			OnRun: func() {
+				terms := search.Terms("movies", search.LANGUAGE_ENGLISH)
+
+				assert.Nil(t, search.Highlight("What is your favorite book?", terms, search.LANGUAGE_ENGLISH))
+				assert.Nil(t, search.Highlight("", terms, search.LANGUAGE_ENGLISH))
+			},
+		},
+		{
+			OnRun: func() {
+				terms := search.Terms("movie", search.LANGUAGE_ENGLISH)
+				text := strings.Repeat("word ", 100) + "movies " + strings.Repeat("word ", 100)
+				snippet := search.Highlight(text, terms, search.LANGUAGE_ENGLISH)
+
+				assert.NotNil(t, snippet)
+				assert.True(t, strings.HasPrefix(snippet.Text, search.ELLIPSIS))
+				assert.True(t, strings.HasSuffix(snippet.Text, search.ELLIPSIS))
+				assert.Len(t, []rune(snippet.Text), search.SNIPPET_LENGTH+2)
+				assert.Len(t, snippet.Highlights, 1)
+
+				highlight := snippet.Highlights[0]
+				assert.Equal(t, "movies", string([]rune(snippet.Text)[highlight.Start:highlight.End]))
+			},
+		},
+	}
+}
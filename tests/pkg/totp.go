@@ -0,0 +1,115 @@
+package pkg
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA-1 secret from RFC 6238 appendix B, encoded as base32.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// GetTOTPGenerateCodeBatches returns a slice of BatchTest for testing GenerateCode against the RFC 6238 test vectors.
+func GetTOTPGenerateCodeBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// RFC vectors have 8 digits, we generate the last 6
+				vectors := map[int64]string{
+					59:          "287082",
+					1111111109:  "081804",
+					1111111111:  "050471",
+					1234567890:  "005924",
+					2000000000:  "279037",
+					20000000000: "353130",
+				}
+
+				for unix, expected := range vectors {
+					code, err := totp.GenerateCode(rfcSecret, totp.Step(time.Unix(unix, 0)))
+
+					assert.NoError(t, err)
+					assert.Equal(t, expected, code)
+				}
+			},
+		},
+		{
+			OnRun: func() {
+				_, err := totp.GenerateCode("not base32!", 1)
+				assert.Error(t, err)
+			},
+		},
+	}
+}
+
+// GetTOTPValidateBatches returns a slice of BatchTest for testing Validate.
+func GetTOTPValidateBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				now := time.Unix(1111111109, 0)
+
+				step, ok := totp.Validate(rfcSecret, "081804", now)
+				assert.True(t, ok)
+				assert.Equal(t, totp.Step(now), step)
+
+				// previous and next steps are accepted to tolerate clock drift
+				_, ok = totp.Validate(rfcSecret, "081804", now.Add(time.Second*totp.PERIOD))
+				assert.True(t, ok)
+
+				_, ok = totp.Validate(rfcSecret, "081804", now.Add(-time.Second*totp.PERIOD))
+				assert.True(t, ok)
+
+				_, ok = totp.Validate(rfcSecret, "081804", now.Add(time.Second*totp.PERIOD*3))
+				assert.False(t, ok)
+			},
+		},
+		{
+			OnRun: func() {
+				now := time.Unix(1111111109, 0)
+
+				_, ok := totp.Validate(rfcSecret, "000000", now)
+				assert.False(t, ok)
+
+				_, ok = totp.Validate(rfcSecret, "81804", now)
+				assert.False(t, ok)
+
+				_, ok = totp.Validate(rfcSecret, "", now)
+				assert.False(t, ok)
+			},
+		},
+		{
+			OnRun: func() {
+				secret, err := totp.GenerateSecret()
+				assert.NoError(t, err)
+
+				code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+				assert.NoError(t, err)
+
+				_, ok := totp.Validate(secret, code, time.Now())
+				assert.True(t, ok)
+			},
+		},
+	}
+}
+
+// GetTOTPKeyURIBatches returns a slice of BatchTest for testing KeyURI.
+func GetTOTPKeyURIBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				uri := totp.KeyURI("Quess API", "foobar", rfcSecret)
+
+				assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Quess%20API:foobar?"))
+				assert.Contains(t, uri, "secret="+rfcSecret)
+				assert.Contains(t, uri, "issuer=Quess+API")
+				assert.Contains(t, uri, "digits=6")
+				assert.Contains(t, uri, "period=30")
+			},
+		},
+	}
+}
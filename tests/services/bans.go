@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/internal/bans"
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetBanActorBatches returns a slice of BatchTest for testing which users moderators and admins can ban.
+func GetBanActorBatches(t *testing.T) []tests.BatchTest {
+	user := &users.User{ID: toolkitEntities.NewID()}
+	moderator := &users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_MODERATOR}
+	otherModerator := &users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_MODERATOR}
+	admin := &users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_ADMIN}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// moderators can't ban admins nor other moderators
+				assert.EqualError(t, bans.CanModerate(moderator, admin), pkgErrors.CANT_BAN_USER_ROLE)
+				assert.EqualError(t, bans.CanModerate(moderator, otherModerator), pkgErrors.CANT_BAN_USER_ROLE)
+				assert.NoError(t, bans.CanModerate(moderator, user))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.NoError(t, bans.CanModerate(admin, moderator))
+				assert.NoError(t, bans.CanModerate(admin, user))
+				assert.EqualError(t, bans.CanModerate(admin, &users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_ADMIN}), pkgErrors.CANT_BAN_USER_ROLE)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.EqualError(t, bans.IsNotBanningYourself(admin, admin.ID), pkgErrors.CANT_BAN_YOURSELF)
+				assert.NoError(t, bans.IsNotBanningYourself(user, admin.ID))
+			},
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/deletions"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetAccountDeletionBatches returns a slice of BatchTest for testing the grace period and the reauthentication of account deletions.
+func GetAccountDeletionBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				cfg := &configs.Conf{}
+				assert.Equal(t, deletions.DEFAULT_GRACE_PERIOD, deletions.GetGracePeriod(cfg))
+
+				cfg.AccountDeletion.GracePeriod = 48
+				assert.Equal(t, time.Hour*48, deletions.GetGracePeriod(cfg))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.NoError(t, deletions.IsRecentSignIn(time.Now().Add(-time.Minute)))
+				assert.ErrorContains(t, deletions.IsRecentSignIn(time.Now().Add(-deletions.REAUTHENTICATION_MAX_AGE-time.Minute)), "reauthentication_required")
+
+				// tokens without "iat" can't prove a recent sign-in
+				assert.ErrorContains(t, deletions.IsRecentSignIn(time.Time{}), "reauthentication_required")
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/internal/exports"
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetDataExportBatches returns a slice of BatchTest for testing the request interval and the archive of data exports.
+func GetDataExportBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.NoError(t, exports.CanRequestExport(&exports.Export{}))
+
+				lastExport := &exports.Export{ID: toolkitEntities.NewID(), Status: exports.STATUS_PROCESSING, CreatedAt: time.Now().Add(-time.Hour * 48)}
+				assert.ErrorContains(t, exports.CanRequestExport(lastExport), "data_export_in_progress")
+
+				lastExport.Status = exports.STATUS_READY
+				assert.NoError(t, exports.CanRequestExport(lastExport))
+
+				lastExport.CreatedAt = time.Now().Add(-time.Hour)
+				assert.ErrorContains(t, exports.CanRequestExport(lastExport), "data_export_requested_recently")
+
+				// failed exports can be requested again right away
+				lastExport.Status = exports.STATUS_FAILED
+				assert.NoError(t, exports.CanRequestExport(lastExport))
+			},
+		},
+		{
+			OnRun: func() {
+				content := tests.GenerateRandomString(20)
+
+				archive := &exports.Archive{
+					Profile: &users.User{ID: toolkitEntities.NewID(), Nick: "quesser", Name: "<script>alert(1)</script>"},
+					ReceivedQuestions: []questions.Question{
+						*questions.Question{ID: toolkitEntities.NewID(), Content: content, SentBy: toolkitEntities.NewID(), IsAnonymous: true}.MapAnonymousFields(),
+					},
+					GeneratedAt: time.Now(),
+				}
+
+				buf := new(bytes.Buffer)
+				assert.NoError(t, exports.WriteArchive(buf, archive))
+
+				zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				assert.NoError(t, err)
+
+				files := map[string]string{}
+
+				for _, f := range zr.File {
+					r, err := f.Open()
+					assert.NoError(t, err)
+
+					data, err := io.ReadAll(r)
+					assert.NoError(t, err)
+
+					files[f.Name] = string(data)
+				}
+
+				for _, name := range []string{"profile.json", "questions-sent.json", "questions-received.json", "blocks.json", "reports.json", "sessions.json", "trusted-ips.json", "index.html"} {
+					assert.Contains(t, files, name)
+				}
+
+				assert.Contains(t, files["questions-received.json"], content)
+				assert.NotContains(t, files["questions-received.json"], "sentBy")
+
+				// the index is escaped
+				assert.Contains(t, files["index.html"], content)
+				assert.NotContains(t, files["index.html"], "<script>")
+			},
+		},
+	}
+}
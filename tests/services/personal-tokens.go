@@ -0,0 +1,136 @@
+package services
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	personaltokens "github.com/quessapp/core-go/internal/personal-tokens"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/denylist"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/tests/mocks"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// newScopedApp creates an app with a route for each scope, a route without scopes,
+// and a personal access token with the questions:read scope.
+func newScopedApp(userID toolkitEntities.ID) (*fiber.App, *configs.AppCtx) {
+	AppCtx := &configs.AppCtx{
+		App:      fiber.New(),
+		Keyring:  keyring.New("secret"),
+		Denylist: denylist.New(nil),
+		PersonalTokens: &mocks.PersonalTokenVerifierMock{
+			Tokens: map[string]mocks.PersonalTokenMock{
+				personaltokens.TOKEN_PREFIX + "read": {UserID: userID.Hex(), Scopes: []string{middlewares.SCOPE_QUESTIONS_READ}},
+			},
+		},
+	}
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendString(users.GetUserByToken(&configs.HandlersCtx{C: c, AppCtx: *AppCtx}).ID.Hex())
+	}
+
+	AppCtx.App.Get("/read", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_READ), handler)
+	AppCtx.App.Get("/reply", middlewares.AuthMiddleware(AppCtx, middlewares.SCOPE_QUESTIONS_REPLY), handler)
+	AppCtx.App.Get("/unscoped", middlewares.AuthMiddleware(AppCtx), handler)
+
+	return AppCtx.App, AppCtx
+}
+
+// requestScopedApp requests a route of the app with the given bearer token, and returns the status code and the body.
+func requestScopedApp(t *testing.T, app *fiber.App, path, token string) (int, string) {
+	req := httptest.NewRequest("GET", path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+
+	body := make([]byte, 256)
+	n, _ := res.Body.Read(body)
+
+	return res.StatusCode, string(body[:n])
+}
+
+// GetAuthMiddlewareBatches returns a slice of BatchTest for testing AuthMiddleware with JWTs and personal access tokens.
+func GetAuthMiddlewareBatches(t *testing.T) []tests.BatchTest {
+	userID := toolkitEntities.NewID()
+	app, AppCtx := newScopedApp(userID)
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// personal access tokens can only be used on routes that declare the scopes they were granted
+				status, body := requestScopedApp(t, app, "/read", personaltokens.TOKEN_PREFIX+"read")
+				assert.Equal(t, fiber.StatusOK, status)
+				assert.Equal(t, userID.Hex(), body)
+
+				status, _ = requestScopedApp(t, app, "/reply", personaltokens.TOKEN_PREFIX+"read")
+				assert.Equal(t, fiber.StatusForbidden, status)
+
+				status, _ = requestScopedApp(t, app, "/unscoped", personaltokens.TOKEN_PREFIX+"read")
+				assert.Equal(t, fiber.StatusForbidden, status)
+
+				status, _ = requestScopedApp(t, app, "/read", personaltokens.TOKEN_PREFIX+"unknown")
+				assert.Equal(t, fiber.StatusUnauthorized, status)
+			},
+		},
+		{
+			OnRun: func() {
+				// JWTs of signed in users have every scope
+				token, err := AppCtx.Keyring.Sign(jwt.MapClaims{
+					"id":  userID.Hex(),
+					"typ": middlewares.TOKEN_TYPE_ACCESS,
+					"iat": time.Now().Unix(),
+					"exp": time.Now().Add(time.Minute).Unix(),
+				})
+				assert.NoError(t, err)
+
+				for _, path := range []string{"/read", "/reply", "/unscoped"} {
+					status, body := requestScopedApp(t, app, path, token)
+					assert.Equal(t, fiber.StatusOK, status)
+					assert.Equal(t, userID.Hex(), body)
+				}
+
+				// refresh tokens and tokens without a type don't authenticate requests
+				for _, tokenType := range []string{middlewares.TOKEN_TYPE_REFRESH, ""} {
+					token, _ := AppCtx.Keyring.Sign(jwt.MapClaims{
+						"id":  userID.Hex(),
+						"typ": tokenType,
+						"iat": time.Now().Unix(),
+						"exp": time.Now().Add(time.Minute).Unix(),
+					})
+
+					status, _ := requestScopedApp(t, app, "/read", token)
+					assert.Equal(t, fiber.StatusUnauthorized, status)
+				}
+
+				status, _ := requestScopedApp(t, app, "/read", "invalid")
+				assert.Equal(t, fiber.StatusForbidden, status)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.True(t, middlewares.HasScopes([]string{"a", "b"}, []string{"b"}))
+				assert.True(t, middlewares.HasScopes([]string{"a", "b"}, []string{"a", "b"}))
+				assert.False(t, middlewares.HasScopes([]string{"a"}, []string{"a", "b"}))
+				assert.False(t, middlewares.HasScopes([]string{"a"}, []string{}))
+			},
+		},
+		{
+			OnRun: func() {
+				v := personaltokens.NewVerifier(nil, nil)
+
+				assert.True(t, v.IsPersonalToken(personaltokens.TOKEN_PREFIX+"abc"))
+				assert.False(t, v.IsPersonalToken("eyJhbGciOiJIUzI1NiJ9.e30.abc"))
+			},
+		},
+	}
+}
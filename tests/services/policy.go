@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/middlewares"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/denylist"
+	"github.com/quessapp/core-go/pkg/keyring"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/quessapp/core-go/tests/mocks"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// newPolicyApp creates an app with two admin route groups under the same path, each requiring a different permission,
+// like the bans and the roles routes.
+func newPolicyApp(policy *mocks.PolicyMock) *configs.AppCtx {
+	AppCtx := &configs.AppCtx{
+		App:      fiber.New(),
+		Keyring:  keyring.New("secret"),
+		Denylist: denylist.New(nil),
+		Policy:   policy,
+	}
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendString(c.Params("id"))
+	}
+
+	bans := AppCtx.App.Group("/admin/users/:id/ban", middlewares.JWTMiddleware(AppCtx), middlewares.PolicyMiddleware(AppCtx, users.PERMISSION_USERS_BAN))
+	bans.Get("/", handler)
+
+	roles := AppCtx.App.Group("/admin/users/:id/role", middlewares.JWTMiddleware(AppCtx), middlewares.PolicyMiddleware(AppCtx, users.PERMISSION_ROLES_MANAGE))
+	roles.Get("/", handler)
+
+	return AppCtx
+}
+
+// signUserToken signs a JWT of the given user with the keyring of the app.
+func signUserToken(t *testing.T, AppCtx *configs.AppCtx, userID toolkitEntities.ID) string {
+	token, err := AppCtx.Keyring.Sign(jwt.MapClaims{
+		"id":  userID.Hex(),
+		"typ": middlewares.TOKEN_TYPE_ACCESS,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	assert.NoError(t, err)
+
+	return token
+}
+
+// GetPolicyMiddlewareBatches returns a slice of BatchTest for testing PolicyMiddleware with users of each role.
+func GetPolicyMiddlewareBatches(t *testing.T) []tests.BatchTest {
+	user := users.User{ID: toolkitEntities.NewID()}
+	moderator := users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_MODERATOR}
+	admin := users.User{ID: toolkitEntities.NewID(), Role: users.ROLE_ADMIN}
+
+	AppCtx := newPolicyApp(&mocks.PolicyMock{
+		Users: map[string]users.User{
+			user.ID.Hex():      user,
+			moderator.ID.Hex(): moderator,
+			admin.ID.Hex():     admin,
+		},
+	})
+	targetID := toolkitEntities.NewID().Hex()
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// the permission of a group does not leak into the other groups under the same path
+				status, body := requestScopedApp(t, AppCtx.App, "/admin/users/"+targetID+"/ban", signUserToken(t, AppCtx, moderator.ID))
+				assert.Equal(t, fiber.StatusOK, status)
+				assert.Equal(t, targetID, body)
+
+				status, _ = requestScopedApp(t, AppCtx.App, "/admin/users/"+targetID+"/role", signUserToken(t, AppCtx, moderator.ID))
+				assert.Equal(t, fiber.StatusForbidden, status)
+			},
+		},
+		{
+			OnRun: func() {
+				for _, path := range []string{"/ban", "/role"} {
+					status, _ := requestScopedApp(t, AppCtx.App, "/admin/users/"+targetID+path, signUserToken(t, AppCtx, admin.ID))
+					assert.Equal(t, fiber.StatusOK, status)
+
+					status, _ = requestScopedApp(t, AppCtx.App, "/admin/users/"+targetID+path, signUserToken(t, AppCtx, user.ID))
+					assert.Equal(t, fiber.StatusForbidden, status)
+
+					// users that don't exist anymore have no permissions
+					status, _ = requestScopedApp(t, AppCtx.App, "/admin/users/"+targetID+path, signUserToken(t, AppCtx, toolkitEntities.NewID()))
+					assert.Equal(t, fiber.StatusForbidden, status)
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/internal/questions"
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/media"
+	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetAnswersVisibilityBatches returns a slice of BatchTest for testing who can see the timeline of answers of an user.
+func GetAnswersVisibilityBatches(t *testing.T) []tests.BatchTest {
+	owner := &users.User{ID: toolkitEntities.NewID()}
+	viewerID := toolkitEntities.NewID()
+	visitorID := toolkitEntities.ID{}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// users that never chose have public answers
+				assert.Equal(t, users.ANSWERS_VISIBILITY_PUBLIC, owner.GetAnswersVisibility())
+				assert.NoError(t, questions.CanSeeAnswers(owner, viewerID))
+				assert.NoError(t, questions.CanSeeAnswers(owner, visitorID))
+			},
+		},
+		{
+			OnRun: func() {
+				owner.AnswersVisibility = users.ANSWERS_VISIBILITY_USERS
+				assert.NoError(t, questions.CanSeeAnswers(owner, viewerID))
+				assert.EqualError(t, questions.CanSeeAnswers(owner, visitorID), pkgErrors.ANSWERS_SIGN_IN_REQUIRED)
+			},
+		},
+		{
+			OnRun: func() {
+				owner.AnswersVisibility = users.ANSWERS_VISIBILITY_PRIVATE
+				assert.EqualError(t, questions.CanSeeAnswers(owner, viewerID), pkgErrors.ANSWERS_PRIVATE)
+				assert.EqualError(t, questions.CanSeeAnswers(owner, visitorID), pkgErrors.ANSWERS_PRIVATE)
+				assert.NoError(t, questions.CanSeeAnswers(owner, owner.ID))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.NoError(t, questions.IsBlockedFromAnswers(false))
+				assert.EqualError(t, questions.IsBlockedFromAnswers(true), pkgErrors.ANSWERS_BLOCKED)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.NoError(t, users.UpdatePreferencesDTO{EnableAPPEmails: true, EnableAPPPushNotifications: true}.Validate())
+				assert.NoError(t, users.UpdatePreferencesDTO{EnableAPPEmails: true, EnableAPPPushNotifications: true, AnswersVisibility: users.ANSWERS_VISIBILITY_PRIVATE}.Validate())
+				assert.ErrorContains(t, users.UpdatePreferencesDTO{EnableAPPEmails: true, EnableAPPPushNotifications: true, AnswersVisibility: "friends"}.Validate(), pkgErrors.ANSWERS_VISIBILITY_INVALID)
+			},
+		},
+	}
+}
+
+// GetFollowUpBatches returns a slice of BatchTest for testing the rules of the threads of follow-ups.
+func GetFollowUpBatches(t *testing.T) []tests.BatchTest {
+	senderID := toolkitEntities.NewID()
+	repliedAt := time.Now()
+
+	newThread := func(size int) []questions.FollowUp {
+		thread := []questions.FollowUp{}
+
+		for i := 0; i < size; i++ {
+			thread = append(thread, questions.FollowUp{ID: toolkitEntities.NewID(), RepliedAt: &repliedAt})
+		}
+
+		return thread
+	}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				q := &questions.Question{SentBy: senderID}
+
+				assert.NoError(t, questions.IsFollowUpSender(q, senderID))
+				assert.EqualError(t, questions.IsFollowUpSender(q, toolkitEntities.NewID()), pkgErrors.FOLLOW_UP_NOT_SENDER)
+
+				// the sender deleted the account
+				q.SentBy = nil
+				assert.EqualError(t, questions.IsFollowUpSender(q, senderID), pkgErrors.FOLLOW_UP_NOT_SENDER)
+			},
+		},
+		{
+			OnRun: func() {
+				q := &questions.Question{}
+				assert.EqualError(t, questions.CanFollowUp(q, newThread(0)), pkgErrors.FOLLOW_UP_QUESTION_NOT_REPLIED)
+
+				q.IsReplied = true
+				assert.NoError(t, questions.CanFollowUp(q, newThread(0)))
+				assert.NoError(t, questions.CanFollowUp(q, newThread(questions.FOLLOW_UPS_MAX_DEPTH-1)))
+				assert.EqualError(t, questions.CanFollowUp(q, newThread(questions.FOLLOW_UPS_MAX_DEPTH)), pkgErrors.FOLLOW_UPS_LIMIT_REACHED)
+
+				q.IsHiddenByReceiver = true
+				assert.EqualError(t, questions.CanFollowUp(q, newThread(0)), pkgErrors.FOLLOW_UP_HIDDEN)
+			},
+		},
+		{
+			OnRun: func() {
+				q := &questions.Question{IsReplied: true}
+				thread := newThread(2)
+
+				thread[1].RepliedAt = nil
+				assert.EqualError(t, questions.CanFollowUp(q, thread), pkgErrors.FOLLOW_UP_PENDING_REPLY)
+				assert.NoError(t, questions.CanReplyFollowUp(&thread[1]))
+				assert.EqualError(t, questions.CanReplyFollowUp(&thread[0]), pkgErrors.FOLLOW_UP_ALREADY_REPLIED)
+
+				thread[1].IsHiddenByReceiver = true
+				assert.EqualError(t, questions.CanFollowUp(q, thread), pkgErrors.FOLLOW_UP_HIDDEN)
+				assert.EqualError(t, questions.CanReplyFollowUp(&thread[1]), pkgErrors.FOLLOW_UP_HIDDEN)
+
+				// a concurrent follow-up took the depth, so the thread is pending reply
+				assert.NoError(t, questions.WasFollowUpCreated(true))
+				assert.EqualError(t, questions.WasFollowUpCreated(false), pkgErrors.FOLLOW_UP_PENDING_REPLY)
+			},
+		},
+		{
+			OnRun: func() {
+				assert.NoError(t, questions.CreateFollowUpDTO{Content: "and why?"}.Validate())
+				assert.ErrorContains(t, questions.CreateFollowUpDTO{}.Validate(), "content_field_required")
+				assert.ErrorContains(t, questions.ReplyFollowUpDTO{Content: tests.GenerateRandomString(questions.FOLLOW_UP_MAX_LENGTH + 1)}.Validate(), "content_field_length")
+			},
+		},
+	}
+}
+
+// GetAttachmentsBatches returns a slice of BatchTest for testing the limits of the attachments of replies.
+func GetAttachmentsBatches(t *testing.T) []tests.BatchTest {
+	image := questions.Attachment{Kind: media.KIND_IMAGE}
+	audio := questions.Attachment{Kind: media.KIND_AUDIO}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Nil(t, questions.ReachedAttachmentsLimit([]questions.Attachment{}))
+				assert.Nil(t, questions.ReachedAttachmentsLimit([]questions.Attachment{image, image, audio}))
+			},
+		},
+		{
+			OnRun: func() {
+				err := questions.ReachedAttachmentsLimit([]questions.Attachment{image, image, image, image})
+
+				assert.Equal(t, pkgErrors.REPLY_ATTACHMENTS_LIMIT, err.Error())
+
+				err = questions.ReachedAttachmentsLimit([]questions.Attachment{audio, audio})
+
+				assert.Equal(t, pkgErrors.REPLY_AUDIO_ATTACHMENTS_LIMIT, err.Error())
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Nil(t, questions.ReachedAttachmentSizeLimit(questions.ATTACHMENT_MAX_SIZE))
+				assert.Equal(t, pkgErrors.MAX_FILE_SIZE, questions.ReachedAttachmentSizeLimit(questions.ATTACHMENT_MAX_SIZE+1).Error())
+
+				assert.Nil(t, questions.IsAudioTooLong(questions.ATTACHMENT_MAX_DURATION))
+				assert.Equal(t, pkgErrors.AUDIO_TOO_LONG, questions.IsAudioTooLong(questions.ATTACHMENT_MAX_DURATION+time.Second).Error())
+			},
+		},
+		{
+			OnRun: func() {
+				// replies that lose the race to a concurrent reply are refused, so their attachments are deleted
+				assert.NoError(t, questions.WasQuestionReplied(true))
+				assert.EqualError(t, questions.WasQuestionReplied(false), pkgErrors.QUESTION_ALREADY_REPLIED)
+			},
+		},
+	}
+}
+
+// GetPollChoiceBatches returns a slice of BatchTest for testing the choice of the replies to poll questions.
+func GetPollChoiceBatches(t *testing.T) []tests.BatchTest {
+	poll := &questions.Question{Poll: &questions.Poll{Options: []string{"cats", "dogs"}}}
+	text := &questions.Question{}
+	choice := func(i int) *int { return &i }
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				assert.Nil(t, questions.IsValidPollChoice(poll, choice(0)))
+				assert.Nil(t, questions.IsValidPollChoice(poll, choice(1)))
+				assert.Nil(t, questions.IsValidPollChoice(text, nil))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Equal(t, pkgErrors.POLL_CHOICE_REQUIRED, questions.IsValidPollChoice(poll, nil).Error())
+				assert.Equal(t, pkgErrors.POLL_CHOICE_INVALID, questions.IsValidPollChoice(poll, choice(2)).Error())
+				assert.Equal(t, pkgErrors.POLL_CHOICE_INVALID, questions.IsValidPollChoice(poll, choice(-1)).Error())
+				assert.Equal(t, pkgErrors.QUESTION_NOT_POLL, questions.IsValidPollChoice(text, choice(0)).Error())
+			},
+		},
+		{
+			OnRun: func() {
+				// the fallback reply, the option and the comment, must fit in the replies of the clients that don't know polls
+				long := &questions.Question{Poll: &questions.Poll{Options: []string{"cats", tests.GenerateRandomString(questions.POLL_OPTION_MAX_LENGTH)}}}
+				maxComment := questions.POLL_REPLY_MAX_LENGTH - questions.POLL_OPTION_MAX_LENGTH - len(questions.POLL_REPLY_SEPARATOR)
+
+				assert.Nil(t, questions.IsPollCommentTooLong(long, choice(1), tests.GenerateRandomString(maxComment)))
+				assert.Equal(t, pkgErrors.POLL_COMMENT_LENGTH, questions.IsPollCommentTooLong(long, choice(1), tests.GenerateRandomString(maxComment+1)).Error())
+				assert.Nil(t, questions.IsPollCommentTooLong(long, choice(0), tests.GenerateRandomString(maxComment+1)))
+				assert.Nil(t, questions.IsPollCommentTooLong(text, nil, tests.GenerateRandomString(250)))
+			},
+		},
+		{
+			OnRun: func() {
+				assert.Equal(t, pkgErrors.POLL_REPLY_NOT_EDITABLE, questions.IsPollReplyEditable(poll).Error())
+				assert.Nil(t, questions.IsPollReplyEditable(text))
+			},
+		},
+	}
+}
+
+// GetInboxBatches returns a slice of BatchTest for testing the inbox settings enforced on new questions.
+func GetInboxBatches(t *testing.T) []tests.BatchTest {
+	monthAgo := time.Now().Add(-time.Hour * 24 * 30)
+	yesterday := time.Now().Add(-time.Hour * 24)
+	sender := &users.User{ID: toolkitEntities.NewID(), CreatedAt: &monthAgo}
+	newSender := &users.User{ID: toolkitEntities.NewID(), CreatedAt: &yesterday, IsVerified: true}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// users that never changed the inbox receive every question
+				receiver := &users.User{ID: toolkitEntities.NewID()}
+
+				assert.Nil(t, questions.IsInboxPaused(receiver))
+				assert.Nil(t, questions.IsAnonymousDenied(receiver, true))
+				assert.Nil(t, questions.IsSenderVerifiedForInbox(receiver, sender))
+				assert.Nil(t, questions.IsSenderOldEnoughForInbox(receiver, newSender))
+				assert.Nil(t, questions.ReachedInboxDailyLimit(receiver, 10000))
+			},
+		},
+		{
+			OnRun: func() {
+				receiver := &users.User{Inbox: &users.Inbox{IsPaused: true, PausedMessage: "on vacation"}}
+				err := questions.IsInboxPaused(receiver)
+
+				var pausedErr *questions.InboxPausedError
+
+				assert.ErrorAs(t, err, &pausedErr)
+				assert.Equal(t, pkgErrors.INBOX_PAUSED, err.Error())
+				assert.Equal(t, "on vacation", pausedErr.Message)
+			},
+		},
+		{
+			OnRun: func() {
+				receiver := &users.User{Inbox: &users.Inbox{DenyAnonymous: true, RequireVerifiedEmail: true, MinAccountAgeDays: 7, DailyLimit: 5}}
+
+				assert.Equal(t, pkgErrors.INBOX_ANONYMOUS_DENIED, questions.IsAnonymousDenied(receiver, true).Error())
+				assert.Nil(t, questions.IsAnonymousDenied(receiver, false))
+
+				assert.Equal(t, pkgErrors.INBOX_VERIFIED_EMAIL_REQUIRED, questions.IsSenderVerifiedForInbox(receiver, sender).Error())
+				assert.Nil(t, questions.IsSenderVerifiedForInbox(receiver, newSender))
+
+				assert.Equal(t, pkgErrors.INBOX_ACCOUNT_TOO_NEW, questions.IsSenderOldEnoughForInbox(receiver, newSender).Error())
+				assert.Nil(t, questions.IsSenderOldEnoughForInbox(receiver, sender))
+
+				assert.Nil(t, questions.ReachedInboxDailyLimit(receiver, 4))
+				assert.Equal(t, pkgErrors.INBOX_DAILY_LIMIT_REACHED, questions.ReachedInboxDailyLimit(receiver, 5).Error())
+			},
+		},
+		{
+			OnRun: func() {
+				// old accounts without a creation date use the date of their ID
+				receiver := &users.User{Inbox: &users.Inbox{MinAccountAgeDays: 7}}
+				legacySender := &users.User{ID: toolkitEntities.NewID()}
+
+				assert.Equal(t, pkgErrors.INBOX_ACCOUNT_TOO_NEW, questions.IsSenderOldEnoughForInbox(receiver, legacySender).Error())
+			},
+		},
+		{
+			OnRun: func() {
+				// the profiles only show what the senders need to know before asking
+				inbox := users.Inbox{DenyAnonymous: true, RequireVerifiedEmail: true, MinAccountAgeDays: 7, IsPaused: true, PausedMessage: "on vacation", DailyLimit: 5}
+
+				assert.Equal(t, &users.Inbox{DenyAnonymous: true, IsPaused: true, PausedMessage: "on vacation"}, inbox.Public())
+			},
+		},
+	}
+}
+
+// GetReplyHistoryBatches returns a slice of BatchTest for testing the edit limits of the plans and who can see the history of the replies.
+func GetReplyHistoryBatches(t *testing.T) []tests.BatchTest {
+	receiver := &users.User{ID: toolkitEntities.NewID()}
+	senderID := toolkitEntities.NewID()
+	viewerID := toolkitEntities.NewID()
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				q := &questions.Question{}
+				assert.Equal(t, 0, q.CountReplyEdits())
+
+				// the first edit adds the original reply and the new content
+				q.IsEdited = true
+				q.RepliesHistory = make([]questions.ReplyHistory, questions.REPLY_EDITS_LIMITS[users.PLAN_FREE])
+				assert.Nil(t, questions.ReachedLimitToEditReply(q, users.PLAN_FREE))
+
+				q.RepliesHistory = append(q.RepliesHistory, questions.ReplyHistory{})
+				assert.Equal(t, pkgErrors.CANT_EDIT_REPLY_REACHED_LIMIT, questions.ReachedLimitToEditReply(q, users.PLAN_FREE).Error())
+				assert.Nil(t, questions.ReachedLimitToEditReply(q, users.PLAN_PRO))
+
+				assert.Equal(t, users.PLAN_PRO, users.User{IsPRO: true}.GetPlan())
+				assert.Equal(t, users.PLAN_FREE, users.User{}.GetPlan())
+			},
+		},
+		{
+			OnRun: func() {
+				// legacy edits added the content of the question and the new reply, without flagging the reply as edited
+				history := []questions.ReplyHistory{}
+
+				for _, content := range []string{"first", "second", "third"} {
+					history = append(history, questions.ReplyHistory{Content: "the question"}, questions.ReplyHistory{Content: content})
+				}
+
+				q := &questions.Question{RepliesHistory: history}
+
+				assert.True(t, q.IsLegacyReplyHistory())
+				assert.False(t, q.HasOriginalReply())
+				assert.Equal(t, 3, q.CountReplyEdits())
+				assert.Nil(t, questions.ReachedLimitToEditReply(q, users.PLAN_FREE))
+
+				versions := q.GetReplyVersions()
+
+				assert.Len(t, versions, 3)
+				assert.Equal(t, "first", versions[0].Content)
+				assert.Equal(t, "third", versions[2].Content)
+
+				// rebuilt histories only have the edited versions
+				rebuilt := &questions.Question{RepliesHistory: versions, IsEdited: true, IsOriginalReplyMissing: true}
+
+				assert.False(t, rebuilt.IsLegacyReplyHistory())
+				assert.Equal(t, 3, rebuilt.CountReplyEdits())
+				assert.Equal(t, versions, rebuilt.GetReplyVersions())
+			},
+		},
+		{
+			OnRun: func() {
+				q := &questions.Question{SendTo: receiver.ID, SentBy: senderID, IsReplied: true}
+
+				// the receiver and the sender can always see the history
+				assert.Nil(t, questions.CanSeeReplyHistory(q, receiver, receiver.ID, false))
+				assert.Nil(t, questions.CanSeeReplyHistory(q, receiver, senderID, false))
+				assert.Equal(t, pkgErrors.REPLY_HISTORY_PRIVATE, questions.CanSeeReplyHistory(q, receiver, viewerID, false).Error())
+
+				assert.Nil(t, questions.HasReplyHistory(q))
+				assert.Equal(t, pkgErrors.REPLY_HISTORY_NOT_REPLIED, questions.HasReplyHistory(&questions.Question{}).Error())
+			},
+		},
+		{
+			OnRun: func() {
+				public := &users.User{ID: toolkitEntities.NewID(), IsReplyHistoryPublic: true}
+				q := &questions.Question{SendTo: public.ID, SentBy: senderID, IsReplied: true}
+
+				assert.Nil(t, questions.CanSeeReplyHistory(q, public, viewerID, false))
+				assert.Equal(t, pkgErrors.REPLY_HISTORY_PRIVATE, questions.CanSeeReplyHistory(q, public, viewerID, true).Error())
+
+				// the history follows the visibility of the answers
+				public.AnswersVisibility = users.ANSWERS_VISIBILITY_PRIVATE
+				assert.Equal(t, pkgErrors.ANSWERS_PRIVATE, questions.CanSeeReplyHistory(q, public, viewerID, false).Error())
+
+				q.IsHiddenByReceiver = true
+				assert.Equal(t, pkgErrors.REPLY_HISTORY_PRIVATE, questions.CanSeeReplyHistory(q, public, viewerID, false).Error())
+			},
+		},
+	}
+}
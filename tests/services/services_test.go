@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/pkg/tests"
+)
+
+func TestEmailVerificationToken(t *testing.T) {
+	cfg := &configs.Conf{
+		Crypto: configs.CryptoConfig{Key: "0123456789abcdef0123456789abcdef"},
+	}
+
+	emailVerificationTokenBatches := GetEmailVerificationTokenBatches(t, cfg)
+	tests.RunBatchTests(emailVerificationTokenBatches)
+}
+
+func TestTrustLocation(t *testing.T) {
+	tests.RunBatchTests(GetTrustLocationBatches(t))
+}
+
+func TestNickChange(t *testing.T) {
+	tests.RunBatchTests(GetNickChangeBatches(t))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tests.RunBatchTests(GetAuthMiddlewareBatches(t))
+}
+
+func TestPolicyMiddleware(t *testing.T) {
+	tests.RunBatchTests(GetPolicyMiddlewareBatches(t))
+}
+
+func TestAccountDeletion(t *testing.T) {
+	tests.RunBatchTests(GetAccountDeletionBatches(t))
+}
+
+func TestDataExport(t *testing.T) {
+	tests.RunBatchTests(GetDataExportBatches(t))
+}
+
+func TestAnswersVisibility(t *testing.T) {
+	tests.RunBatchTests(GetAnswersVisibilityBatches(t))
+}
+
+func TestFollowUps(t *testing.T) {
+	tests.RunBatchTests(GetFollowUpBatches(t))
+}
+
+func TestAttachments(t *testing.T) {
+	tests.RunBatchTests(GetAttachmentsBatches(t))
+}
+
+func TestPollChoice(t *testing.T) {
+	tests.RunBatchTests(GetPollChoiceBatches(t))
+}
+
+func TestInbox(t *testing.T) {
+	tests.RunBatchTests(GetInboxBatches(t))
+}
+
+func TestReplyHistory(t *testing.T) {
+	tests.RunBatchTests(GetReplyHistoryBatches(t))
+}
+
+func TestBanActor(t *testing.T) {
+	tests.RunBatchTests(GetBanActorBatches(t))
+}
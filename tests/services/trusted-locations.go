@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	trustedlocations "github.com/quessapp/core-go/internal/trusted-locations"
+	"github.com/quessapp/core-go/internal/users"
+	pkgErrors "github.com/quessapp/core-go/pkg/errors"
+	"github.com/quessapp/core-go/pkg/geoip"
+	"github.com/quessapp/core-go/pkg/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTrustLocationBatches returns a slice of BatchTest for testing the checks done before trusting a location.
+func GetTrustLocationBatches(t *testing.T) []tests.BatchTest {
+	user := &users.User{}
+	userWithTwoFactor := &users.User{TwoFactor: &users.TwoFactor{IsEnabled: true}}
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				// users with two-factor authentication enabled must confirm with a code
+				assert.EqualError(t, trustedlocations.IsTwoFactorCodeProvided(userWithTwoFactor, ""), pkgErrors.TWO_FACTOR_CODE_REQUIRED)
+				assert.EqualError(t, trustedlocations.IsTwoFactorCodeProvided(userWithTwoFactor, "  "), pkgErrors.TWO_FACTOR_CODE_REQUIRED)
+				assert.NoError(t, trustedlocations.IsTwoFactorCodeProvided(userWithTwoFactor, "123456"))
+				assert.NoError(t, trustedlocations.IsTwoFactorCodeProvided(user, ""))
+			},
+		},
+		{
+			OnRun: func() {
+				// only the autonomous system of the request IP can be trusted
+				asn := &geoip.ASN{Number: 15169, Organization: "Google LLC"}
+
+				assert.NoError(t, trustedlocations.IsOwnASN(asn, 15169))
+				assert.EqualError(t, trustedlocations.IsOwnASN(asn, 16509), pkgErrors.TRUSTED_LOCATION_ASN_NOT_OWN)
+				assert.EqualError(t, trustedlocations.IsOwnASN(nil, 15169), pkgErrors.TRUSTED_LOCATION_ASN_NOT_OWN)
+			},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quessapp/core-go/configs"
+	"github.com/quessapp/core-go/internal/users"
+	"github.com/quessapp/core-go/pkg/tests"
+	toolkitEntities "github.com/quessapp/toolkit/entities"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GetEmailVerificationTokenBatches returns a slice of BatchTest for testing CreateEmailVerificationToken and ParseEmailVerificationToken.
+func GetEmailVerificationTokenBatches(t *testing.T, cfg *configs.Conf) []tests.BatchTest {
+	userID := toolkitEntities.NewID()
+
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				token, err := users.CreateEmailVerificationToken(cfg, userID, "caio@api.com")
+				assert.NoError(t, err)
+
+				claims, err := users.ParseEmailVerificationToken(cfg, token)
+				assert.NoError(t, err)
+				assert.Equal(t, userID, claims.ID)
+				assert.Equal(t, "caio@api.com", claims.Email)
+			},
+		},
+		{
+			OnRun: func() {
+				token, err := users.CreateEmailVerificationToken(cfg, userID, "caio@api.com")
+				assert.NoError(t, err)
+
+				// tokens signed with another key are rejected
+				_, err = users.ParseEmailVerificationToken(&configs.Conf{Crypto: configs.CryptoConfig{Key: "another"}}, token)
+				assert.Error(t, err)
+
+				_, err = users.ParseEmailVerificationToken(cfg, token+"a")
+				assert.Error(t, err)
+			},
+		},
+		{
+			OnRun: func() {
+				// access tokens are not verification tokens, even when signed with the same key
+				accessToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"id":  userID,
+					"exp": time.Now().Add(time.Hour).Unix(),
+				}).SignedString([]byte(cfg.Crypto.Key))
+
+				_, err := users.ParseEmailVerificationToken(cfg, accessToken)
+				assert.ErrorContains(t, err, "verification_token_invalid")
+			},
+		},
+		{
+			OnRun: func() {
+				expired, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, users.EmailVerificationClaims{
+					ID:    userID,
+					Email: "caio@api.com",
+					Type:  users.EMAIL_VERIFICATION_TOKEN_TYPE,
+					RegisteredClaims: jwt.RegisteredClaims{
+						ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+					},
+				}).SignedString([]byte(cfg.Crypto.Key))
+
+				_, err := users.ParseEmailVerificationToken(cfg, expired)
+				assert.Error(t, err)
+			},
+		},
+	}
+}
+
+// GetNickChangeBatches returns a slice of BatchTest for testing the rate limit and the reservation of nick changes.
+func GetNickChangeBatches(t *testing.T) []tests.BatchTest {
+	return []tests.BatchTest{
+		{
+			OnRun: func() {
+				cfg := &configs.Conf{}
+				assert.Equal(t, users.NICK_DEFAULT_RESERVATION_PERIOD, users.GetNickReservationPeriod(cfg))
+				assert.Equal(t, users.NICK_DEFAULT_CHANGE_INTERVAL, users.GetNickChangeInterval(cfg))
+
+				cfg.Nicks.ReservationPeriod = 24
+				cfg.Nicks.ChangeInterval = 1
+				assert.Equal(t, time.Hour*24, users.GetNickReservationPeriod(cfg))
+				assert.Equal(t, time.Hour, users.GetNickChangeInterval(cfg))
+			},
+		},
+		{
+			OnRun: func() {
+				u := &users.User{}
+				assert.NoError(t, users.CanChangeNick(u, time.Hour))
+
+				changedAt := time.Now().Add(-time.Minute)
+				u.NickChangedAt = &changedAt
+				assert.ErrorContains(t, users.CanChangeNick(u, time.Hour), "nick_changed_recently")
+
+				changedAt = time.Now().Add(-time.Hour * 2)
+				assert.NoError(t, users.CanChangeNick(u, time.Hour))
+			},
+		},
+		{
+			OnRun: func() {
+				// a concurrent change claimed the interval first
+				assert.NoError(t, users.WasNickChangeClaimed(true))
+				assert.ErrorContains(t, users.WasNickChangeClaimed(false), "nick_changed_recently")
+			},
+		},
+	}
+}